@@ -78,7 +78,9 @@ func main() {
 			op.VersionProvider,
 			op.InstanceTypesProvider,
 			op.CapacityReservationProvider,
+			op.PlacementGroupProvider,
 			op.AMIResolver,
+			op.AMIHealthTracker,
 		)...).
 		Start(ctx)
 }
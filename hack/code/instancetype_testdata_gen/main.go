@@ -166,15 +166,27 @@ func getInstanceTypeInfo(info ec2types.InstanceTypeInfo) string {
 	fmt.Fprintf(src, "BurstablePerformanceSupported: aws.Bool(%t),\n", lo.FromPtr(info.BurstablePerformanceSupported))
 	fmt.Fprintf(src, "BareMetal: aws.Bool(%t),\n", lo.FromPtr(info.BareMetal))
 	fmt.Fprintf(src, "Hypervisor: \"%s\",\n", info.Hypervisor)
+	fmt.Fprintf(src, "HibernationSupported: aws.Bool(%t),\n", lo.FromPtr(info.HibernationSupported))
+	fmt.Fprintf(src, "NitroEnclavesSupport: \"%s\",\n", info.NitroEnclavesSupport)
 
 	fmt.Fprintf(src, "ProcessorInfo: &ec2types.ProcessorInfo{\n")
 	fmt.Fprintf(src, "Manufacturer: aws.String(\"%s\"),\n", lo.FromPtr(info.ProcessorInfo.Manufacturer))
 	fmt.Fprintf(src, "SupportedArchitectures: []ec2types.ArchitectureType{%s},\n", getStringSliceData(info.ProcessorInfo.SupportedArchitectures))
 	fmt.Fprintf(src, "SustainedClockSpeedInGhz: aws.Float64(%f),\n", lo.FromPtr(info.ProcessorInfo.SustainedClockSpeedInGhz))
+	if len(info.ProcessorInfo.SupportedFeatures) != 0 {
+		fmt.Fprintf(src, "SupportedFeatures: []ec2types.SupportedAdditionalProcessorFeature{%s},\n", getStringSliceData(info.ProcessorInfo.SupportedFeatures))
+	}
 	fmt.Fprintf(src, "},\n")
 	fmt.Fprintf(src, "VCpuInfo: &ec2types.VCpuInfo{\n")
 	fmt.Fprintf(src, "DefaultCores: aws.Int32(%d),\n", lo.FromPtr(info.VCpuInfo.DefaultCores))
+	fmt.Fprintf(src, "DefaultThreadsPerCore: aws.Int32(%d),\n", lo.FromPtr(info.VCpuInfo.DefaultThreadsPerCore))
 	fmt.Fprintf(src, "DefaultVCpus: aws.Int32(%d),\n", lo.FromPtr(info.VCpuInfo.DefaultVCpus))
+	if len(info.VCpuInfo.ValidCores) != 0 {
+		fmt.Fprintf(src, "ValidCores: []int32{%s},\n", getIntSliceData(info.VCpuInfo.ValidCores))
+	}
+	if len(info.VCpuInfo.ValidThreadsPerCore) != 0 {
+		fmt.Fprintf(src, "ValidThreadsPerCore: []int32{%s},\n", getIntSliceData(info.VCpuInfo.ValidThreadsPerCore))
+	}
 	fmt.Fprintf(src, "},\n")
 	fmt.Fprintf(src, "MemoryInfo: &ec2types.MemoryInfo{\n")
 	fmt.Fprintf(src, "SizeInMiB: aws.Int64(%d),\n", lo.FromPtr(info.MemoryInfo.SizeInMiB))
@@ -278,6 +290,10 @@ func getGPUDeviceInfo(info ec2types.GpuDeviceInfo) string {
 	return src.String()
 }
 
-func getStringSliceData[T ec2types.UsageClassType | ec2types.VirtualizationType | ec2types.ArchitectureType](slice []T) string {
+func getStringSliceData[T ec2types.UsageClassType | ec2types.VirtualizationType | ec2types.ArchitectureType | ec2types.SupportedAdditionalProcessorFeature](slice []T) string {
 	return strings.Join(lo.Map(slice, func(s T, _ int) string { return fmt.Sprintf(`"%s"`, s) }), ",")
 }
+
+func getIntSliceData(slice []int32) string {
+	return strings.Join(lo.Map(slice, func(i int32, _ int) string { return fmt.Sprint(i) }), ",")
+}
@@ -33,8 +33,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/samber/lo"
+	"go.uber.org/multierr"
 
-	controllerspricing "github.com/aws/karpenter-provider-aws/pkg/controllers/providers/pricing"
 	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/pricing"
 	"github.com/aws/karpenter-provider-aws/pkg/test"
@@ -110,8 +110,7 @@ func main() {
 	for _, region := range getAWSRegions(opts.partition) {
 		log.Println("fetching for", region)
 		pricingProvider := pricing.NewDefaultProvider(pricing.NewAPI(cfg), ec2api, region, false)
-		controller := controllerspricing.NewController(pricingProvider)
-		_, err := controller.Reconcile(ctx)
+		err := multierr.Combine(pricingProvider.UpdateSpotPricing(ctx), pricingProvider.UpdateOnDemandPricing(ctx))
 		if err != nil {
 			log.Fatalf("failed to initialize pricing provider %s", err)
 		}
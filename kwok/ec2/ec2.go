@@ -450,25 +450,7 @@ func (c *Client) CreateFleet(ctx context.Context, input *ec2.CreateFleetInput, _
 		instanceTypeInfo := lo.Must(lo.Find(c.instanceTypes, func(i ec2types.InstanceTypeInfo) bool {
 			return i.InstanceType == selectedOverride.InstanceType
 		}))
-		// TODO: We need to get the capacity and allocatable information from the userData
-		it := instancetype.NewInstanceType(
-			ctx,
-			instanceTypeInfo,
-			c.region,
-			nil,
-			nil,
-			nil,
-			nil,
-			nil,
-			nil,
-			nil,
-			nil,
-			nil,
-			nil,
-			// TODO: Eventually support different AMIFamilies from userData
-			"al2023",
-			nil,
-		)
+		it := c.toInstanceType(ctx, instanceTypeInfo)
 		instance := ec2types.Instance{
 			AmiLaunchIndex: nil,
 			Architecture:   lo.Ternary(it.Requirements.Get(corev1.LabelArchStable).Any() == v1.ArchitectureAmd64, ec2types.ArchitectureValuesX8664, ec2types.ArchitectureValuesArm64),
@@ -790,6 +772,126 @@ func (c *Client) CreateTags(_ context.Context, input *ec2.CreateTagsInput, _ ...
 	return &ec2.CreateTagsOutput{}, nil
 }
 
+func (c *Client) DeleteTags(_ context.Context, input *ec2.DeleteTagsInput, _ ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	if !c.rateLimiterProvider.DeleteTags().TryAccept() {
+		return nil, &smithy.GenericAPIError{
+			Code:    errors.RateLimitingErrorCode,
+			Message: "Request limit exceeded.",
+		}
+	}
+	// TODO: Eventually do more rigorous validations and auth checks for dry-run
+	if lo.FromPtr(input.DryRun) {
+		return nil, &smithy.GenericAPIError{
+			Code:    errors.DryRunOperationErrorCode,
+			Message: "Request would have succeeded, but DryRun flag is set",
+		}
+	}
+
+	for _, resource := range input.Resources {
+		raw, ok := c.instances.Load(resource)
+		if !ok {
+			// For now, we just ignore if the resource doesn't exist
+			continue
+		}
+		instance := raw.(ec2types.Instance)
+		instance.Tags = lo.Reject(instance.Tags, func(t ec2types.Tag, _ int) bool {
+			return lo.ContainsBy(input.Tags, func(d ec2types.Tag) bool {
+				return lo.FromPtr(d.Key) == lo.FromPtr(t.Key)
+			})
+		})
+		c.instances.Store(resource, instance)
+	}
+	return &ec2.DeleteTagsOutput{}, nil
+}
+
+func (c *Client) StartInstances(ctx context.Context, input *ec2.StartInstancesInput, _ ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error) {
+	if !c.rateLimiterProvider.StartInstances().TryAccept() {
+		return nil, &smithy.GenericAPIError{
+			Code:    errors.RateLimitingErrorCode,
+			Message: "Request limit exceeded.",
+		}
+	}
+	// TODO: Eventually do more rigorous validations and auth checks for dry-run
+	if lo.FromPtr(input.DryRun) {
+		return nil, &smithy.GenericAPIError{
+			Code:    errors.DryRunOperationErrorCode,
+			Message: "Request would have succeeded, but DryRun flag is set",
+		}
+	}
+
+	var stateChanges []ec2types.InstanceStateChange
+	for _, id := range input.InstanceIds {
+		raw, ok := c.instances.Load(id)
+		if !ok {
+			// TODO: Eventually we should make this a real NotFound error returned by the AWS API
+			return nil, fmt.Errorf("instance %q not found", id)
+		}
+		instance := raw.(ec2types.Instance)
+		previousState := instance.State
+		instance.State = &ec2types.InstanceState{
+			Code: lo.ToPtr[int32](16),
+			Name: ec2types.InstanceStateNameRunning,
+		}
+		instance.LaunchTime = lo.ToPtr(c.clock.Now())
+		c.instances.Store(id, instance)
+
+		// Starting a stopped instance re-registers its Node
+		instanceTypeInfo := lo.Must(lo.Find(c.instanceTypes, func(i ec2types.InstanceTypeInfo) bool {
+			return i.InstanceType == instance.InstanceType
+		}))
+		nodePoolNameTag, _ := lo.Find(instance.Tags, func(t ec2types.Tag) bool {
+			return lo.FromPtr(t.Key) == v1.NodePoolLabelKey
+		})
+		if err := c.kubeClient.Create(ctx, toNode(id, lo.FromPtr(nodePoolNameTag.Value), c.toInstanceType(ctx, instanceTypeInfo), lo.FromPtr(instance.Placement.AvailabilityZone), v1.CapacityTypeOnDemand)); err != nil {
+			return nil, fmt.Errorf("creating node, %w", err)
+		}
+		stateChanges = append(stateChanges, ec2types.InstanceStateChange{
+			CurrentState:  instance.State,
+			InstanceId:    lo.ToPtr(id),
+			PreviousState: previousState,
+		})
+	}
+	return &ec2.StartInstancesOutput{StartingInstances: stateChanges}, nil
+}
+
+func (c *Client) StopInstances(_ context.Context, input *ec2.StopInstancesInput, _ ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error) {
+	if !c.rateLimiterProvider.StopInstances().TryAccept() {
+		return nil, &smithy.GenericAPIError{
+			Code:    errors.RateLimitingErrorCode,
+			Message: "Request limit exceeded.",
+		}
+	}
+	// TODO: Eventually do more rigorous validations and auth checks for dry-run
+	if lo.FromPtr(input.DryRun) {
+		return nil, &smithy.GenericAPIError{
+			Code:    errors.DryRunOperationErrorCode,
+			Message: "Request would have succeeded, but DryRun flag is set",
+		}
+	}
+
+	var stateChanges []ec2types.InstanceStateChange
+	for _, id := range input.InstanceIds {
+		raw, ok := c.instances.Load(id)
+		if !ok {
+			// TODO: Eventually we should make this a real NotFound error returned by the AWS API
+			return nil, fmt.Errorf("instance %q not found", id)
+		}
+		instance := raw.(ec2types.Instance)
+		previousState := instance.State
+		instance.State = &ec2types.InstanceState{
+			Code: lo.ToPtr[int32](80),
+			Name: ec2types.InstanceStateNameStopped,
+		}
+		c.instances.Store(id, instance)
+		stateChanges = append(stateChanges, ec2types.InstanceStateChange{
+			CurrentState:  instance.State,
+			InstanceId:    lo.ToPtr(id),
+			PreviousState: previousState,
+		})
+	}
+	return &ec2.StopInstancesOutput{StoppingInstances: stateChanges}, nil
+}
+
 func (c *Client) CreateLaunchTemplate(_ context.Context, input *ec2.CreateLaunchTemplateInput, _ ...func(*ec2.Options)) (*ec2.CreateLaunchTemplateOutput, error) {
 	if !c.rateLimiterProvider.CreateLaunchTemplate().TryAccept() {
 		return nil, &smithy.GenericAPIError{
@@ -862,6 +964,28 @@ func (c *Client) DeleteLaunchTemplate(_ context.Context, input *ec2.DeleteLaunch
 	}, nil
 }
 
+func (c *Client) toInstanceType(ctx context.Context, info ec2types.InstanceTypeInfo) *cloudprovider.InstanceType {
+	// TODO: We need to get the capacity and allocatable information from the userData
+	return instancetype.NewInstanceType(
+		ctx,
+		info,
+		c.region,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		// TODO: Eventually support different AMIFamilies from userData
+		"al2023",
+		nil,
+	)
+}
+
 func toNode(instanceID, nodePoolName string, instanceType *cloudprovider.InstanceType, zone, capacityType string) *corev1.Node {
 	nodeName := fmt.Sprintf("%s-%d", strings.ReplaceAll(namesgenerator.GetRandomName(0), "_", "-"), rand.Uint32()) //nolint:gosec
 	return &corev1.Node{
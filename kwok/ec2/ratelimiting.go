@@ -25,6 +25,9 @@ type RateLimiterProvider interface {
 	DescribeInstances() flowcontrol.PassiveRateLimiter
 	RunInstances() flowcontrol.PassiveRateLimiter
 	CreateTags() flowcontrol.PassiveRateLimiter
+	DeleteTags() flowcontrol.PassiveRateLimiter
+	StartInstances() flowcontrol.PassiveRateLimiter
+	StopInstances() flowcontrol.PassiveRateLimiter
 	CreateLaunchTemplate() flowcontrol.PassiveRateLimiter
 	DeleteLaunchTemplate() flowcontrol.PassiveRateLimiter
 }
@@ -75,6 +78,18 @@ func (p NopRateLimiterProvider) CreateTags() flowcontrol.PassiveRateLimiter {
 	return p.nopRateLimiter
 }
 
+func (p NopRateLimiterProvider) DeleteTags() flowcontrol.PassiveRateLimiter {
+	return p.nopRateLimiter
+}
+
+func (p NopRateLimiterProvider) StartInstances() flowcontrol.PassiveRateLimiter {
+	return p.nopRateLimiter
+}
+
+func (p NopRateLimiterProvider) StopInstances() flowcontrol.PassiveRateLimiter {
+	return p.nopRateLimiter
+}
+
 func (p NopRateLimiterProvider) CreateLaunchTemplate() flowcontrol.PassiveRateLimiter {
 	return p.nopRateLimiter
 }
@@ -127,6 +142,18 @@ func (p *DefaultRateLimiterProvider) CreateTags() flowcontrol.PassiveRateLimiter
 	return p.createTags
 }
 
+func (p *DefaultRateLimiterProvider) DeleteTags() flowcontrol.PassiveRateLimiter {
+	return p.mutating
+}
+
+func (p *DefaultRateLimiterProvider) StartInstances() flowcontrol.PassiveRateLimiter {
+	return p.mutating
+}
+
+func (p *DefaultRateLimiterProvider) StopInstances() flowcontrol.PassiveRateLimiter {
+	return p.mutating
+}
+
 func (p *DefaultRateLimiterProvider) CreateLaunchTemplate() flowcontrol.PassiveRateLimiter {
 	return p.mutating
 }
@@ -92,7 +92,9 @@ func main() {
 			op.VersionProvider,
 			op.InstanceTypesProvider,
 			op.CapacityReservationProvider,
+			op.PlacementGroupProvider,
 			op.AMIResolver,
+			op.AMIHealthTracker,
 		)...).
 		Start(ctx)
 	wg.Wait()
@@ -54,11 +54,40 @@ type EC2NodeClassSpec struct {
 	// AssociatePublicIPAddress controls if public IP addresses are assigned to instances that are launched with the nodeclass.
 	// +optional
 	AssociatePublicIPAddress *bool `json:"associatePublicIPAddress,omitempty"`
+	// NetworkInterfaces configures the network interfaces attached to instances at launch. The network interface with
+	// device index 0 configures the primary network interface, which is always placed in the subnet selected by
+	// subnetSelectorTerms. Network interfaces with other device indices are attached as secondary network interfaces.
+	// +kubebuilder:validation:XValidation:message="deviceIndex must be unique",rule="self.all(x, self.exists_one(y, y.deviceIndex == x.deviceIndex))"
+	// +kubebuilder:validation:XValidation:message="subnetSelectorTerms is required for secondary network interfaces and can't be set for the primary network interface",rule="self.all(x, x.deviceIndex == 0 ? !has(x.subnetSelectorTerms) : has(x.subnetSelectorTerms))"
+	// +kubebuilder:validation:MaxItems:=8
+	// +optional
+	NetworkInterfaces []NetworkInterface `json:"networkInterfaces,omitempty"`
+	// PlacementGroup selects the placement group that instances are launched into. Karpenter doesn't create placement
+	// groups, the selector must match exactly one existing placement group.
+	// +kubebuilder:validation:XValidation:message="expected at least one, got none, ['id', 'name', 'tags']",rule="has(self.id) || has(self.name) || has(self.tags)"
+	// +kubebuilder:validation:XValidation:message="'id' is mutually exclusive, cannot be set with a combination of other fields in a placement group selector",rule="!(has(self.id) && (has(self.name) || has(self.tags)))"
+	// +kubebuilder:validation:XValidation:message="'name' is mutually exclusive, cannot be set with a combination of other fields in a placement group selector",rule="!(has(self.name) && (has(self.id) || has(self.tags)))"
+	// +optional
+	PlacementGroup *PlacementGroupSelector `json:"placementGroup,omitempty" hash:"ignore"`
+	// Tenancy is the tenancy of instances launched with this EC2NodeClass. Instances with dedicated or host tenancy
+	// can only be launched as on-demand instances.
+	// +kubebuilder:validation:Enum:={default,dedicated,host}
+	// +optional
+	Tenancy *Tenancy `json:"tenancy,omitempty"`
+	// HostSelector selects the Dedicated Host, or host resource group, that instances are launched onto. It can only
+	// be set when tenancy is host. If unset, instances are launched onto any available host with auto-placement enabled.
+	// +kubebuilder:validation:XValidation:message="expected exactly one, got both or none, ['id', 'resourceGroupARN']",rule="has(self.id) != has(self.resourceGroupARN)"
+	// +optional
+	HostSelector *HostSelector `json:"hostSelector,omitempty"`
 	// AMISelectorTerms is a list of or ami selector terms. The terms are ORed.
 	// +kubebuilder:validation:XValidation:message="expected at least one, got none, ['tags', 'id', 'name', 'alias', 'ssmParameter']",rule="self.all(x, has(x.tags) || has(x.id) || has(x.name) || has(x.alias) || has(x.ssmParameter))"
 	// +kubebuilder:validation:XValidation:message="'id' is mutually exclusive, cannot be set with a combination of other fields in amiSelectorTerms",rule="!self.exists(x, has(x.id) && (has(x.alias) || has(x.tags) || has(x.name) || has(x.owner)))"
 	// +kubebuilder:validation:XValidation:message="'alias' is mutually exclusive, cannot be set with a combination of other fields in amiSelectorTerms",rule="!self.exists(x, has(x.alias) && (has(x.id) || has(x.tags) || has(x.name) || has(x.owner)))"
 	// +kubebuilder:validation:XValidation:message="'alias' is mutually exclusive, cannot be set with a combination of other amiSelectorTerms",rule="!(self.exists(x, has(x.alias)) && self.size() != 1)"
+	// +kubebuilder:validation:XValidation:message="'minAge' and 'maxAge' may only be set with 'tags' or 'name'",rule="!self.exists(x, (has(x.minAge) || has(x.maxAge)) && !(has(x.tags) || has(x.name)))"
+	// +kubebuilder:validation:XValidation:message="'replication' may only be set with 'tags', 'name' or 'id'",rule="!self.exists(x, has(x.replication) && !(has(x.tags) || has(x.name) || has(x.id)))"
+	// +kubebuilder:validation:XValidation:message="'requirements' may not be set with 'alias'",rule="!self.exists(x, has(x.requirements) && has(x.alias))"
+	// +kubebuilder:validation:XValidation:message="'minAge' must be less than 'maxAge'",rule="!self.exists(x, has(x.minAge) && has(x.maxAge) && duration(x.minAge) >= duration(x.maxAge))"
 	// +kubebuilder:validation:MinItems:=1
 	// +kubebuilder:validation:MaxItems:=30
 	// +required
@@ -69,9 +98,19 @@ type EC2NodeClassSpec struct {
 	// alias is specified, this field is required.
 	// NOTE: We ignore the AMIFamily for hashing here because we hash the AMIFamily dynamically by using the alias using
 	// the AMIFamily() helper function
-	// +kubebuilder:validation:Enum:={AL2,AL2023,Bottlerocket,Custom,Windows2019,Windows2022}
+	// +kubebuilder:validation:Enum:={AL2,AL2023,Bottlerocket,Custom,Windows2019,Windows2022,Windows2025}
 	// +optional
 	AMIFamily *string `json:"amiFamily,omitempty" hash:"ignore"`
+	// AMIHealthPolicy excludes AMIs from status.amis when too many of the NodeClaims launched from them fail to become
+	// ready. An excluded AMI is replaced by the AMI that was previously resolved for the same requirements.
+	// +optional
+	AMIHealthPolicy *AMIHealthPolicy `json:"amiHealthPolicy,omitempty" hash:"ignore"`
+	// AMIRolloutPolicy stages the rollout of newly resolved AMIs. When set, new AMIs are only used for a percentage of
+	// launches until they've baked, and nodes aren't drifted until the new AMIs are promoted. If too many canary NodeClaims
+	// launched from a new AMI fail to launch or to become ready, the rollout is halted and nodes using the new AMIs are
+	// drifted back.
+	// +optional
+	AMIRolloutPolicy *AMIRolloutPolicy `json:"amiRolloutPolicy,omitempty" hash:"ignore"`
 	// UserData to be applied to the provisioned nodes.
 	// It must be in the appropriate format based on the AMIFamily in use. Karpenter will merge certain fields into
 	// this UserData to ensure nodes are being provisioned with the correct configuration.
@@ -93,6 +132,10 @@ type EC2NodeClassSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self != ''",message="instanceProfile cannot be empty"
 	// +optional
 	InstanceProfile *string `json:"instanceProfile,omitempty"`
+	// InstanceProfileOptions configures the instance profile that Karpenter manages for the role.
+	// This field can only be set when role is set.
+	// +optional
+	InstanceProfileOptions *InstanceProfileOptions `json:"instanceProfileOptions,omitempty" hash:"ignore"`
 	// Tags to be applied on ec2 resources like instances and launch templates.
 	// +kubebuilder:validation:XValidation:message="empty tag keys aren't supported",rule="self.all(k, k != '')"
 	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching eks:eks-cluster-name",rule="self.all(k, k !='eks:eks-cluster-name')"
@@ -100,27 +143,69 @@ type EC2NodeClassSpec struct {
 	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching karpenter.sh/nodepool",rule="self.all(k, k != 'karpenter.sh/nodepool')"
 	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching karpenter.sh/nodeclaim",rule="self.all(k, k !='karpenter.sh/nodeclaim')"
 	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching karpenter.k8s.aws/ec2nodeclass",rule="self.all(k, k !='karpenter.k8s.aws/ec2nodeclass')"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching karpenter.k8s.aws/warm-pool",rule="self.all(k, k !='karpenter.k8s.aws/warm-pool')"
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
+	// VolumeTags to be applied on the EBS volumes of provisioned nodes, in addition to tags. Volume tags take
+	// precedence over tags with the same key.
+	// +kubebuilder:validation:XValidation:message="empty tag keys aren't supported",rule="self.all(k, k != '')"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching eks:eks-cluster-name",rule="self.all(k, k !='eks:eks-cluster-name')"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching kubernetes.io/cluster/",rule="self.all(k, !k.startsWith('kubernetes.io/cluster') )"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching karpenter.sh/nodepool",rule="self.all(k, k != 'karpenter.sh/nodepool')"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching karpenter.sh/nodeclaim",rule="self.all(k, k !='karpenter.sh/nodeclaim')"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching karpenter.k8s.aws/ec2nodeclass",rule="self.all(k, k !='karpenter.k8s.aws/ec2nodeclass')"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching karpenter.k8s.aws/warm-pool",rule="self.all(k, k !='karpenter.k8s.aws/warm-pool')"
+	// +optional
+	VolumeTags map[string]string `json:"volumeTags,omitempty"`
 	// Kubelet defines args to be used when configuring kubelet on provisioned nodes.
 	// They are a subset of the upstream types, recognizing not all options may be supported.
 	// Wherever possible, the types and names should reflect the upstream kubelet types.
 	// +kubebuilder:validation:XValidation:message="imageGCHighThresholdPercent must be greater than imageGCLowThresholdPercent",rule="has(self.imageGCHighThresholdPercent) && has(self.imageGCLowThresholdPercent) ?  self.imageGCHighThresholdPercent > self.imageGCLowThresholdPercent  : true"
 	// +kubebuilder:validation:XValidation:message="evictionSoft OwnerKey does not have a matching evictionSoftGracePeriod",rule="has(self.evictionSoft) ? self.evictionSoft.all(e, (e in self.evictionSoftGracePeriod)):true"
 	// +kubebuilder:validation:XValidation:message="evictionSoftGracePeriod OwnerKey does not have a matching evictionSoft",rule="has(self.evictionSoftGracePeriod) ? self.evictionSoftGracePeriod.all(e, (e in self.evictionSoft)):true"
+	// +kubebuilder:validation:XValidation:message="shutdownGracePeriodCriticalPods requires shutdownGracePeriod",rule="has(self.shutdownGracePeriodCriticalPods) ? has(self.shutdownGracePeriod) : true"
+	// +kubebuilder:validation:XValidation:message="shutdownGracePeriodCriticalPods must be less than or equal to shutdownGracePeriod",rule="has(self.shutdownGracePeriodCriticalPods) && has(self.shutdownGracePeriod) ? duration(self.shutdownGracePeriodCriticalPods) <= duration(self.shutdownGracePeriod) : true"
 	// +optional
 	Kubelet *KubeletConfiguration `json:"kubelet,omitempty"`
+	// Bottlerocket contains settings that are merged into the UserData of Bottlerocket nodes. These settings take
+	// precedence over the same settings in userData. This field can only be set with the Bottlerocket AMIFamily.
+	// +optional
+	Bottlerocket *BottlerocketConfiguration `json:"bottlerocket,omitempty"`
+	// NodeConfig contains nodeadm settings that are merged into the NodeConfig that Karpenter generates for AL2023
+	// nodes. This field can only be set with the AL2023 AMIFamily.
+	// +optional
+	NodeConfig *NodeConfigOverlay `json:"nodeConfig,omitempty"`
 	// BlockDeviceMappings to be applied to provisioned nodes.
 	// +kubebuilder:validation:XValidation:message="must have only one blockDeviceMappings with rootVolume",rule="self.filter(x, has(x.rootVolume)?x.rootVolume==true:false).size() <= 1"
 	// +kubebuilder:validation:MaxItems:=50
 	// +optional
 	BlockDeviceMappings []*BlockDeviceMapping `json:"blockDeviceMappings,omitempty"`
+	// VolumeEncryptionPolicy requires every EBS block device mapping of provisioned nodes, including the AMI family's
+	// default mappings, to be encrypted with a specific KMS key.
+	// +optional
+	VolumeEncryptionPolicy *VolumeEncryptionPolicy `json:"volumeEncryptionPolicy,omitempty"`
 	// InstanceStorePolicy specifies how to handle instance-store disks.
 	// +optional
 	InstanceStorePolicy *InstanceStorePolicy `json:"instanceStorePolicy,omitempty"`
 	// DetailedMonitoring controls if detailed monitoring is enabled for instances that are launched
 	// +optional
 	DetailedMonitoring *bool `json:"detailedMonitoring,omitempty"`
+	// CPUOptions configures the processor of instances launched with this EC2NodeClass. Instance types that don't support
+	// the configured options aren't launched.
+	// +optional
+	CPUOptions *CPUOptions `json:"cpuOptions,omitempty"`
+	// EnclaveOptions configures AWS Nitro Enclaves for instances launched with this EC2NodeClass. When enabled, only
+	// instance types that support Nitro Enclaves are launched.
+	// +optional
+	EnclaveOptions *EnclaveOptions `json:"enclaveOptions,omitempty"`
+	// HibernationOptions configures hibernation for instances launched with this EC2NodeClass. When configured, only
+	// instance types that support hibernation are launched.
+	// +optional
+	HibernationOptions *HibernationOptions `json:"hibernationOptions,omitempty"`
+	// WarmPool keeps a pool of stopped instances for each NodePool that uses this EC2NodeClass. NodeClaims that are
+	// compatible with an instance in the pool are launched by starting it, rather than by launching a new instance.
+	// +optional
+	WarmPool *WarmPool `json:"warmPool,omitempty" hash:"ignore"`
 	// MetadataOptions for the generated launch template of provisioned nodes.
 	//
 	// This specifies the exposure of the Instance Metadata Service to
@@ -177,6 +262,51 @@ type SecurityGroupSelectorTerm struct {
 	Name string `json:"name,omitempty"`
 }
 
+// NetworkInterface configures a network interface that's attached to instances at launch.
+type NetworkInterface struct {
+	// DeviceIndex is the position of the network interface in the attachment order.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=15
+	// +required
+	DeviceIndex int32 `json:"deviceIndex"`
+	// InterfaceType is the type of the network interface.
+	// +kubebuilder:validation:Enum:={interface,efa,efa-only}
+	// +optional
+	InterfaceType *string `json:"interfaceType,omitempty"`
+	// SubnetSelectorTerms is a list of subnet selector terms for a secondary network interface. The terms are ORed.
+	// The network interface is placed in a selected subnet in the same zone as the instance.
+	// +kubebuilder:validation:XValidation:message="subnetSelectorTerms cannot be empty",rule="self.size() != 0"
+	// +kubebuilder:validation:XValidation:message="expected at least one, got none, ['tags', 'id']",rule="self.all(x, has(x.tags) || has(x.id))"
+	// +kubebuilder:validation:XValidation:message="'id' is mutually exclusive, cannot be set with a combination of other fields in a subnet selector term",rule="!self.all(x, has(x.id) && has(x.tags))"
+	// +kubebuilder:validation:MaxItems:=30
+	// +optional
+	SubnetSelectorTerms []SubnetSelectorTerm `json:"subnetSelectorTerms,omitempty" hash:"ignore"`
+	// SecurityGroupSelectorTerms is a list of security group selector terms for the network interface. The terms are ORed.
+	// If not set, the security groups selected by the EC2NodeClass's securityGroupSelectorTerms are used.
+	// +kubebuilder:validation:XValidation:message="securityGroupSelectorTerms cannot be empty",rule="self.size() != 0"
+	// +kubebuilder:validation:XValidation:message="expected at least one, got none, ['tags', 'id', 'name']",rule="self.all(x, has(x.tags) || has(x.id) || has(x.name))"
+	// +kubebuilder:validation:XValidation:message="'id' is mutually exclusive, cannot be set with a combination of other fields in a security group selector term",rule="!self.all(x, has(x.id) && (has(x.tags) || has(x.name)))"
+	// +kubebuilder:validation:XValidation:message="'name' is mutually exclusive, cannot be set with a combination of other fields in a security group selector term",rule="!self.all(x, has(x.name) && (has(x.tags) || has(x.id)))"
+	// +kubebuilder:validation:MaxItems:=30
+	// +optional
+	SecurityGroupSelectorTerms []SecurityGroupSelectorTerm `json:"securityGroupSelectorTerms,omitempty" hash:"ignore"`
+	// ENASrdSpecification configures ENA Express, which uses the Scalable Reliable Datagram (SRD) protocol to increase
+	// the bandwidth of single flows and reduce tail latency between supported instances.
+	// +optional
+	ENASrdSpecification *ENASrdSpecification `json:"enaSrdSpecification,omitempty"`
+}
+
+// ENASrdSpecification configures ENA Express for a network interface.
+// +kubebuilder:validation:XValidation:message="enaSrdUDPEnabled requires enaSrdEnabled",rule="has(self.enaSrdUDPEnabled) && self.enaSrdUDPEnabled ? has(self.enaSrdEnabled) && self.enaSrdEnabled : true"
+type ENASrdSpecification struct {
+	// ENASrdEnabled enables ENA Express for TCP traffic.
+	// +optional
+	ENASrdEnabled *bool `json:"enaSrdEnabled,omitempty"`
+	// ENASrdUDPEnabled enables ENA Express for UDP traffic.
+	// +optional
+	ENASrdUDPEnabled *bool `json:"enaSrdUDPEnabled,omitempty"`
+}
+
 type CapacityReservationSelectorTerm struct {
 	// Tags is a map of key/value tags used to select capacity reservations.
 	// Specifying '*' for a value selects all values for a given tag key.
@@ -194,18 +324,140 @@ type CapacityReservationSelectorTerm struct {
 	OwnerID string `json:"ownerID,omitempty"`
 }
 
+// PlacementGroupSelector defines selection logic for the placement group used by Karpenter to launch nodes.
+// If multiple fields are used for selection, the requirements are ANDed.
+type PlacementGroupSelector struct {
+	// ID is the placement group id in EC2
+	// +kubebuilder:validation:Pattern:="^pg-[0-9a-z]+$"
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Name is the placement group name in EC2.
+	// +kubebuilder:validation:MaxLength:=255
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Tags is a map of key/value tags used to select the placement group.
+	// Specifying '*' for a value selects all values for a given tag key.
+	// +kubebuilder:validation:XValidation:message="empty tag keys or values aren't supported",rule="self.all(k, k != '' && self[k] != '')"
+	// +kubebuilder:validation:MaxProperties:=20
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+	// Strategy is the placement strategy of the placement group. If set, only a placement group with this strategy
+	// is selected.
+	// +kubebuilder:validation:Enum:={cluster,partition,spread}
+	// +optional
+	Strategy *PlacementGroupStrategy `json:"strategy,omitempty"`
+}
+
+// PlacementGroupStrategy is the strategy used to place instances in a placement group
+type PlacementGroupStrategy string
+
+const (
+	// PlacementGroupStrategyCluster packs instances close together inside a single availability zone
+	PlacementGroupStrategyCluster PlacementGroupStrategy = "cluster"
+	// PlacementGroupStrategyPartition spreads instances across logical partitions that don't share racks
+	PlacementGroupStrategyPartition PlacementGroupStrategy = "partition"
+	// PlacementGroupStrategySpread places each instance on distinct hardware
+	PlacementGroupStrategySpread PlacementGroupStrategy = "spread"
+)
+
+// Tenancy is the tenancy of an instance
+type Tenancy string
+
+const (
+	// TenancyDefault launches instances onto shared hardware
+	TenancyDefault Tenancy = "default"
+	// TenancyDedicated launches instances onto hardware dedicated to a single account
+	TenancyDedicated Tenancy = "dedicated"
+	// TenancyHost launches instances onto Dedicated Hosts
+	TenancyHost Tenancy = "host"
+)
+
+// WarmPool configures a pool of pre-initialized instances that are kept stopped until they're needed.
+type WarmPool struct {
+	// Size is the number of instances kept in the pool for each NodePool that uses the EC2NodeClass.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +required
+	Size int32 `json:"size"`
+	// PoolState is the state instances are kept in while they're in the pool. Hibernated instances keep their memory,
+	// which requires hibernationOptions to be configured.
+	// +kubebuilder:validation:Enum:={Stopped,Hibernated}
+	// +kubebuilder:default:=Stopped
+	// +optional
+	PoolState WarmPoolState `json:"poolState,omitempty"`
+}
+
+// WarmPoolState is the state of instances while they're in a warm pool
+type WarmPoolState string
+
+const (
+	// WarmPoolStateStopped keeps instances in the pool stopped
+	WarmPoolStateStopped WarmPoolState = "Stopped"
+	// WarmPoolStateHibernated keeps instances in the pool hibernated
+	WarmPoolStateHibernated WarmPoolState = "Hibernated"
+)
+
+// AMIHealthPolicy configures when AMIs are excluded based on the health of the NodeClaims launched from them
+type AMIHealthPolicy struct {
+	// MinFailures is the number of NodeClaims launched from an AMI that must fail before the AMI can be excluded.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=3
+	// +optional
+	MinFailures *int32 `json:"minFailures,omitempty"`
+	// MaxFailurePercentage is the percentage of NodeClaims launched from an AMI that may fail before the AMI is
+	// excluded.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +kubebuilder:default:=50
+	// +optional
+	MaxFailurePercentage *int32 `json:"maxFailurePercentage,omitempty"`
+}
+
+// AMIRolloutPolicy configures the staged rollout of newly resolved AMIs
+type AMIRolloutPolicy struct {
+	// CanaryPercentage is the percentage of NodeClaim launches that use the new AMIs while they're baking.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=100
+	// +required
+	CanaryPercentage int32 `json:"canaryPercentage"`
+	// BakeTime is how long the new AMIs are used for canary launches before they're promoted.
+	// +kubebuilder:validation:Pattern:=`^(([0-9]+(s|m|h))+)$`
+	// +kubebuilder:validation:Type:=string
+	// +required
+	BakeTime metav1.Duration `json:"bakeTime"`
+	// FailureThreshold is the number of canary NodeClaims launched from a new AMI that may fail to launch or to become
+	// ready before the AMI's rollout is halted.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// HostSelector defines the Dedicated Host, or host resource group, used by Karpenter to launch nodes.
+type HostSelector struct {
+	// ID is the id of the Dedicated Host. Instances launched onto a specific host have host affinity, and are always
+	// restarted onto the same host.
+	// +kubebuilder:validation:Pattern:="^h-[0-9a-z]+$"
+	// +optional
+	ID string `json:"id,omitempty"`
+	// ResourceGroupARN is the ARN of the host resource group. Instances are launched onto any available host in the group.
+	// +kubebuilder:validation:Pattern:="^arn:aws[a-z-]*:resource-groups:[a-z0-9-]+:[0-9]{12}:group/.+$"
+	// +optional
+	ResourceGroupARN string `json:"resourceGroupARN,omitempty"`
+}
+
 // AMISelectorTerm defines selection logic for an ami used by Karpenter to launch nodes.
 // If multiple fields are used for selection, the requirements are ANDed.
 type AMISelectorTerm struct {
 	// Alias specifies which EKS optimized AMI to select.
 	// Each alias consists of a family and an AMI version, specified as "family@version".
-	// Valid families include: al2, al2023, bottlerocket, windows2019, and windows2022.
+	// Valid families include: al2, al2023, bottlerocket, windows2019, windows2022, and windows2025.
 	// The version can either be pinned to a specific AMI release, with that AMIs version format (ex: "al2023@v20240625" or "bottlerocket@v1.10.0").
 	// The version can also be set to "latest" for any family. Setting the version to latest will result in drift when a new AMI is released. This is **not** recommended for production environments.
 	// Note: The Windows families do **not** support version pinning, and only latest may be used.
 	// +kubebuilder:validation:XValidation:message="'alias' is improperly formatted, must match the format 'family@version'",rule="self.matches('^[a-zA-Z0-9]+@.+$')"
-	// +kubebuilder:validation:XValidation:message="family is not supported, must be one of the following: 'al2', 'al2023', 'bottlerocket', 'windows2019', 'windows2022'",rule="self.split('@')[0] in ['al2','al2023','bottlerocket','windows2019','windows2022']"
-	// +kubebuilder:validation:XValidation:message="windows families may only specify version 'latest'",rule="self.split('@')[0] in ['windows2019','windows2022'] ? self.split('@')[1] == 'latest' : true"
+	// +kubebuilder:validation:XValidation:message="family is not supported, must be one of the following: 'al2', 'al2023', 'bottlerocket', 'windows2019', 'windows2022', 'windows2025'",rule="self.split('@')[0] in ['al2','al2023','bottlerocket','windows2019','windows2022','windows2025']"
+	// +kubebuilder:validation:XValidation:message="windows families may only specify version 'latest'",rule="self.split('@')[0] in ['windows2019','windows2022','windows2025'] ? self.split('@')[1] == 'latest' : true"
 	// +kubebuilder:validation:MaxLength=30
 	// +optional
 	Alias string `json:"alias,omitempty"`
@@ -230,6 +482,39 @@ type AMISelectorTerm struct {
 	//SSMParameter is the name (or ARN) of the SSM parameter containing the Image ID.
 	// +optional
 	SSMParameter string `json:"ssmParameter,omitempty"`
+	// MinAge is the minimum age of the selected AMIs, based on their creation date. AMIs that are newer aren't
+	// selected until they're at least this old.
+	// +kubebuilder:validation:Pattern:=`^(([0-9]+(s|m|h))+)$`
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	MinAge *metav1.Duration `json:"minAge,omitempty"`
+	// MaxAge is the maximum age of the selected AMIs, based on their creation date.
+	// +kubebuilder:validation:Pattern:=`^(([0-9]+(s|m|h))+)$`
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// Replication selects the AMIs in another region, or shared from the account specified by owner, and copies them into
+	// the cluster's account and region. The copies are used in place of the selected AMIs once they're available.
+	// +optional
+	Replication *AMIReplication `json:"replication,omitempty"`
+	// Requirements are added to the requirements of the AMIs selected by this term, so that they're only used for the
+	// instance types that they support. This can be used to map AMIs that are built for a specific accelerator, e.g. an
+	// AMI with AMD GPU drivers can be restricted to instance types where karpenter.k8s.aws/instance-gpu-manufacturer is amd.
+	// +kubebuilder:validation:XValidation:message="requirements may only use the keys 'karpenter.k8s.aws/instance-gpu-manufacturer' and 'karpenter.k8s.aws/instance-accelerator-name'",rule="self.all(x, x.key in ['karpenter.k8s.aws/instance-gpu-manufacturer', 'karpenter.k8s.aws/instance-accelerator-name'])"
+	// +kubebuilder:validation:XValidation:message="requirements operator must be one of 'In', 'NotIn', 'Exists' or 'DoesNotExist'",rule="self.all(x, x.operator in ['In', 'NotIn', 'Exists', 'DoesNotExist'])"
+	// +kubebuilder:validation:XValidation:message="requirements with operator 'In' must have a value defined",rule="self.all(x, x.operator == 'In' ? has(x.values) && x.values.size() != 0 : true)"
+	// +kubebuilder:validation:MaxItems:=10
+	// +optional
+	Requirements []v1.NodeSelectorRequirement `json:"requirements,omitempty"`
+}
+
+// AMIReplication configures where AMIs are copied from
+type AMIReplication struct {
+	// SourceRegion is the region that AMIs are selected in. This may be the cluster's region when copying AMIs that are
+	// shared from another account.
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	SourceRegion string `json:"sourceRegion"`
 }
 
 // KubeletConfiguration defines args to be used when configuring kubelet on provisioned nodes.
@@ -299,6 +584,371 @@ type KubeletConfiguration struct {
 	// CPUCFSQuota enables CPU CFS quota enforcement for containers that specify CPU limits.
 	// +optional
 	CPUCFSQuota *bool `json:"cpuCFSQuota,omitempty"`
+	// CPUManagerPolicy is the name of the policy to use for assigning CPUs to containers.
+	// +kubebuilder:validation:Enum:={none,static}
+	// +optional
+	CPUManagerPolicy *string `json:"cpuManagerPolicy,omitempty"`
+	// CPUManagerReconcilePeriod is the reconciliation period for the CPU manager.
+	// +kubebuilder:validation:Pattern:=`^(([0-9]+(s|m|h))+)$`
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	CPUManagerReconcilePeriod *metav1.Duration `json:"cpuManagerReconcilePeriod,omitempty"`
+	// TopologyManagerPolicy is the name of the topology manager policy to use.
+	// +kubebuilder:validation:Enum:={none,best-effort,restricted,single-numa-node}
+	// +optional
+	TopologyManagerPolicy *string `json:"topologyManagerPolicy,omitempty"`
+	// TopologyManagerScope is the scope of topology hint generation that the topology manager requests and hint
+	// providers generate.
+	// +kubebuilder:validation:Enum:={container,pod}
+	// +optional
+	TopologyManagerScope *string `json:"topologyManagerScope,omitempty"`
+	// ShutdownGracePeriod is the total duration that the node delays the shutdown by for pods to terminate during a
+	// node shutdown.
+	// +kubebuilder:validation:Pattern:=`^(([0-9]+(s|m|h))+)$`
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	ShutdownGracePeriod *metav1.Duration `json:"shutdownGracePeriod,omitempty"`
+	// ShutdownGracePeriodCriticalPods is the duration of the shutdownGracePeriod that's reserved for terminating
+	// critical pods. This value must be less than or equal to shutdownGracePeriod.
+	// +kubebuilder:validation:Pattern:=`^(([0-9]+(s|m|h))+)$`
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	ShutdownGracePeriodCriticalPods *metav1.Duration `json:"shutdownGracePeriodCriticalPods,omitempty"`
+	// RegistryPullQPS is the limit of registry pulls per second. If 0, there's no limit.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	RegistryPullQPS *int32 `json:"registryPullQPS,omitempty"`
+	// RegistryBurst is the maximum size of bursty pulls, temporarily allowing pulls to burst to this number while
+	// still not exceeding registryPullQPS. Only used if registryPullQPS is greater than 0.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	RegistryBurst *int32 `json:"registryBurst,omitempty"`
+	// ContainerLogMaxSize is the maximum size (e.g. 10Mi) of a container log file before it's rotated.
+	// +kubebuilder:validation:Pattern:="^[1-9][0-9]*(Ki|Mi|Gi)$"
+	// +optional
+	ContainerLogMaxSize *string `json:"containerLogMaxSize,omitempty"`
+	// ContainerLogMaxFiles is the maximum number of container log files that can be present for a container.
+	// +kubebuilder:validation:Minimum:=2
+	// +optional
+	ContainerLogMaxFiles *int32 `json:"containerLogMaxFiles,omitempty"`
+	// SeccompDefault enables the RuntimeDefault seccomp profile as the default for all workloads.
+	// +optional
+	SeccompDefault *bool `json:"seccompDefault,omitempty"`
+	// PodPidsLimit is the maximum number of PIDs in any pod. If -1, there's no limit.
+	// +kubebuilder:validation:Minimum:=-1
+	// +optional
+	PodPidsLimit *int64 `json:"podPidsLimit,omitempty"`
+}
+
+// BottlerocketConfiguration is a typed subset of the Bottlerocket settings.
+// See https://bottlerocket.dev/en/os/latest/api/settings/
+type BottlerocketConfiguration struct {
+	// BootstrapContainers are containers that run before the kubelet starts, keyed by name.
+	// +kubebuilder:validation:XValidation:message="bootstrap container names must consist of lower case alphanumeric characters and '-'",rule="self.all(k, k.matches('^[a-z0-9]([a-z0-9-]*[a-z0-9])?$'))"
+	// +kubebuilder:validation:MaxProperties:=10
+	// +optional
+	BootstrapContainers map[string]BottlerocketBootstrapContainer `json:"bootstrapContainers,omitempty"`
+	// BootstrapCommands are commands that run before the bootstrap containers, keyed by name.
+	// +kubebuilder:validation:XValidation:message="bootstrap command names must consist of lower case alphanumeric characters and '-'",rule="self.all(k, k.matches('^[a-z0-9]([a-z0-9-]*[a-z0-9])?$'))"
+	// +kubebuilder:validation:MaxProperties:=10
+	// +optional
+	BootstrapCommands map[string]BottlerocketBootstrapCommand `json:"bootstrapCommands,omitempty"`
+	// HostContainers configures the admin and control host containers.
+	// +optional
+	HostContainers *BottlerocketHostContainers `json:"hostContainers,omitempty"`
+	// Kernel configures the kernel of Bottlerocket nodes.
+	// +optional
+	Kernel *BottlerocketKernel `json:"kernel,omitempty"`
+	// ContainerRegistry configures the registries that container images are pulled from.
+	// +optional
+	ContainerRegistry *BottlerocketContainerRegistry `json:"containerRegistry,omitempty"`
+	// NTP configures the time servers that Bottlerocket nodes synchronize with.
+	// +optional
+	NTP *BottlerocketNTP `json:"ntp,omitempty"`
+	// OCIDefaults configures the default capabilities and resource limits of containers.
+	// +optional
+	OCIDefaults *BottlerocketOCIDefaults `json:"ociDefaults,omitempty"`
+}
+
+// BottlerocketBootstrapContainer is a container that runs before the kubelet starts
+type BottlerocketBootstrapContainer struct {
+	// Source is the image of the container.
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	Source string `json:"source"`
+	// Mode determines when the container runs. Containers with mode once only run on the first boot.
+	// +kubebuilder:default:=always
+	// +optional
+	Mode BottlerocketBootstrapMode `json:"mode,omitempty"`
+	// Essential fails the boot if the container exits with an error.
+	// +optional
+	Essential *bool `json:"essential,omitempty"`
+	// UserData is base64 encoded data that's passed to the container.
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z0-9+/]*={0,2}$`
+	// +optional
+	UserData *string `json:"userData,omitempty"`
+}
+
+// BottlerocketBootstrapCommand is a set of commands that run before the bootstrap containers
+type BottlerocketBootstrapCommand struct {
+	// Commands are run in order. Each command is a list of the executable and its arguments.
+	// +kubebuilder:validation:XValidation:message="commands may not be empty",rule="self.all(c, c.size() > 0)"
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=20
+	// +required
+	Commands [][]string `json:"commands"`
+	// Mode determines when the commands run. Commands with mode once only run on the first boot.
+	// +kubebuilder:default:=always
+	// +optional
+	Mode BottlerocketBootstrapMode `json:"mode,omitempty"`
+	// Essential fails the boot if any of the commands exit with an error.
+	// +optional
+	Essential *bool `json:"essential,omitempty"`
+}
+
+// BottlerocketBootstrapMode enumerates when bootstrap containers and commands run
+// +kubebuilder:validation:Enum:={always,once,off}
+type BottlerocketBootstrapMode string
+
+const (
+	BottlerocketBootstrapModeAlways BottlerocketBootstrapMode = "always"
+	BottlerocketBootstrapModeOnce   BottlerocketBootstrapMode = "once"
+	BottlerocketBootstrapModeOff    BottlerocketBootstrapMode = "off"
+)
+
+// BottlerocketHostContainers configures the host containers that are included in Bottlerocket
+type BottlerocketHostContainers struct {
+	// Admin is the container that provides administrative access to the host.
+	// +optional
+	Admin *BottlerocketHostContainer `json:"admin,omitempty"`
+	// Control is the container that provides access to the Bottlerocket API through SSM.
+	// +optional
+	Control *BottlerocketHostContainer `json:"control,omitempty"`
+}
+
+// BottlerocketHostContainer configures a host container
+type BottlerocketHostContainer struct {
+	// Enabled runs the container.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Source is the image of the container. If unset, the image included in Bottlerocket is used.
+	// +kubebuilder:validation:MinLength:=1
+	// +optional
+	Source *string `json:"source,omitempty"`
+	// Superpowered runs the container with additional privileges on the host.
+	// +optional
+	Superpowered *bool `json:"superpowered,omitempty"`
+	// UserData is base64 encoded data that's passed to the container.
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z0-9+/]*={0,2}$`
+	// +optional
+	UserData *string `json:"userData,omitempty"`
+}
+
+// BottlerocketKernel configures the kernel of Bottlerocket nodes
+type BottlerocketKernel struct {
+	// Sysctl is a map of kernel parameters to their values.
+	// +kubebuilder:validation:XValidation:message="sysctl keys must be valid kernel parameter names",rule="self.all(k, k.matches('^[a-z0-9_]+([./][a-zA-Z0-9_-]+)+$'))"
+	// +kubebuilder:validation:MaxProperties:=100
+	// +optional
+	Sysctl map[string]string `json:"sysctl,omitempty"`
+}
+
+// BottlerocketContainerRegistry configures the registries that container images are pulled from
+type BottlerocketContainerRegistry struct {
+	// Mirrors are the endpoints that images are pulled from instead of their registry.
+	// +kubebuilder:validation:XValidation:message="mirrors must have unique registries",rule="self.all(x, self.exists_one(y, y.registry == x.registry))"
+	// +kubebuilder:validation:MaxItems:=20
+	// +optional
+	Mirrors []BottlerocketRegistryMirror `json:"mirrors,omitempty"`
+}
+
+// BottlerocketRegistryMirror configures the mirrors of a registry
+type BottlerocketRegistryMirror struct {
+	// Registry is the host of the registry that's mirrored, or '*' for all registries.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +required
+	Registry string `json:"registry"`
+	// Endpoints are the URLs of the mirrors, in the order that they're tried.
+	// +kubebuilder:validation:items:Pattern:=`^https?://`
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=10
+	// +required
+	Endpoints []string `json:"endpoints"`
+}
+
+// BottlerocketNTP configures the time servers that Bottlerocket nodes synchronize with
+type BottlerocketNTP struct {
+	// TimeServers are the NTP servers, replacing the servers that Bottlerocket uses by default.
+	// +kubebuilder:validation:MaxItems:=10
+	// +optional
+	TimeServers []string `json:"timeServers,omitempty"`
+	// Options are passed to chrony for each of the time servers, e.g. iburst.
+	// +kubebuilder:validation:MaxItems:=10
+	// +optional
+	Options []string `json:"options,omitempty"`
+}
+
+// BottlerocketOCIDefaults configures the defaults of the OCI spec that containers are created with
+type BottlerocketOCIDefaults struct {
+	// Capabilities is a map of Linux capabilities, in lower kebab case without the CAP_ prefix (e.g. sys-admin), to
+	// whether they're granted to containers by default.
+	// +kubebuilder:validation:XValidation:message="capabilities must be in lower kebab case without the 'cap-' prefix",rule="self.all(k, k.matches('^[a-z]+(-[a-z]+)*$') && !k.startsWith('cap-'))"
+	// +kubebuilder:validation:MaxProperties:=50
+	// +optional
+	Capabilities map[string]bool `json:"capabilities,omitempty"`
+	// ResourceLimits is a map of rlimits (e.g. max-open-files) to the limits that containers are created with.
+	// +kubebuilder:validation:XValidation:message="resource limits must be one of 'max-address-space', 'max-core-file-size', 'max-cpu-time', 'max-data-size', 'max-file-locks', 'max-file-size', 'max-locked-memory', 'max-msgqueue-size', 'max-nice-priority', 'max-open-files', 'max-pending-signals', 'max-processes', 'max-realtime-priority', 'max-realtime-timeout', 'max-resident-set', 'max-stack-size'",rule="self.all(k, k in ['max-address-space','max-core-file-size','max-cpu-time','max-data-size','max-file-locks','max-file-size','max-locked-memory','max-msgqueue-size','max-nice-priority','max-open-files','max-pending-signals','max-processes','max-realtime-priority','max-realtime-timeout','max-resident-set','max-stack-size'])"
+	// +optional
+	ResourceLimits map[string]BottlerocketResourceLimit `json:"resourceLimits,omitempty"`
+}
+
+// BottlerocketResourceLimit is the soft and hard limit of an rlimit. A limit of -1 is unlimited.
+// +kubebuilder:validation:XValidation:message="softLimit must be less than or equal to hardLimit",rule="self.hardLimit == -1 || (self.softLimit != -1 && self.softLimit <= self.hardLimit)"
+type BottlerocketResourceLimit struct {
+	// HardLimit is the ceiling for the soft limit.
+	// +kubebuilder:validation:Minimum:=-1
+	// +required
+	HardLimit int64 `json:"hardLimit"`
+	// SoftLimit is the limit that's enforced.
+	// +kubebuilder:validation:Minimum:=-1
+	// +required
+	SoftLimit int64 `json:"softLimit"`
+}
+
+// NodeConfigOverlay is a typed subset of the nodeadm NodeConfig.
+// See https://awslabs.github.io/amazon-eks-ami/nodeadm/doc/api/
+type NodeConfigOverlay struct {
+	// Containerd configures containerd on AL2023 nodes.
+	// +optional
+	Containerd *NodeConfigContainerd `json:"containerd,omitempty"`
+	// Instance configures the instance that nodeadm runs on.
+	// +optional
+	Instance *NodeConfigInstance `json:"instance,omitempty"`
+	// Kubelet configures the command line of the kubelet. Kubelet configuration is set with spec.kubelet.
+	// +optional
+	Kubelet *NodeConfigKubelet `json:"kubelet,omitempty"`
+}
+
+// NodeConfigContainerd configures containerd
+type NodeConfigContainerd struct {
+	// Config is inline containerd configuration in TOML, which nodeadm merges into the default containerd
+	// configuration of the AMI.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=16384
+	// +optional
+	Config *string `json:"config,omitempty"`
+}
+
+// NodeConfigInstance configures the instance that nodeadm runs on
+type NodeConfigInstance struct {
+	// LocalStorage configures the instance store disks of the instance. Use instanceStorePolicy instead unless a
+	// nodeadm strategy is required, the two can't be used together.
+	// +optional
+	LocalStorage *NodeConfigLocalStorage `json:"localStorage,omitempty"`
+}
+
+// NodeConfigLocalStorage configures how nodeadm sets up instance store disks
+type NodeConfigLocalStorage struct {
+	// Strategy is how the instance store disks are set up. RAID0 combines all of the disks into a single RAID-0
+	// array, and Mount mounts each disk individually.
+	// +required
+	Strategy NodeConfigLocalStorageStrategy `json:"strategy"`
+}
+
+// NodeConfigLocalStorageStrategy enumerates the nodeadm strategies for setting up instance store disks
+// +kubebuilder:validation:Enum:={RAID0,Mount}
+type NodeConfigLocalStorageStrategy string
+
+const (
+	NodeConfigLocalStorageStrategyRAID0 NodeConfigLocalStorageStrategy = "RAID0"
+	NodeConfigLocalStorageStrategyMount NodeConfigLocalStorageStrategy = "Mount"
+)
+
+// NodeConfigKubelet configures the command line of the kubelet
+type NodeConfigKubelet struct {
+	// Flags are appended to the kubelet command line after the flags that Karpenter sets. Node labels and taints are
+	// set by Karpenter from the NodePool, and can't be set with flags.
+	// +kubebuilder:validation:XValidation:message="flags may not set --node-labels or --register-with-taints",rule="self.all(f, !f.startsWith('--node-labels') && !f.startsWith('--register-with-taints'))"
+	// +kubebuilder:validation:items:Pattern:=`^--[a-z0-9-]+(=.*)?$`
+	// +kubebuilder:validation:items:MaxLength:=4096
+	// +kubebuilder:validation:MaxItems:=50
+	// +optional
+	Flags []string `json:"flags,omitempty"`
+}
+
+// InstanceProfileOptions contains parameters for the instance profile that Karpenter manages for the role.
+type InstanceProfileOptions struct {
+	// Path is the IAM path of the instance profile. The path is only applied when Karpenter creates the instance
+	// profile. This field is immutable.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="immutable field changed"
+	// +kubebuilder:validation:Pattern:="^/([!-~]+/)?$"
+	// +kubebuilder:validation:MaxLength:=512
+	// +optional
+	Path *string `json:"path,omitempty"`
+	// Tags to be applied on the instance profile in addition to the EC2NodeClass's tags.
+	// +kubebuilder:validation:XValidation:message="empty tag keys aren't supported",rule="self.all(k, k != '')"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching eks:eks-cluster-name",rule="self.all(k, k !='eks:eks-cluster-name')"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching kubernetes.io/cluster/",rule="self.all(k, !k.startsWith('kubernetes.io/cluster') )"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching karpenter.k8s.aws/ec2nodeclass",rule="self.all(k, k !='karpenter.k8s.aws/ec2nodeclass')"
+	// +kubebuilder:validation:XValidation:message="tag contains a restricted tag matching topology.kubernetes.io/region",rule="self.all(k, k !='topology.kubernetes.io/region')"
+	// +kubebuilder:validation:MaxProperties:=40
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+	// AdoptionPolicy determines whether Karpenter manages an instance profile that already exists with the same name.
+	// With Always, Karpenter replaces the role of an existing instance profile. With IfOwned, Karpenter only manages
+	// an existing instance profile if it's tagged as owned by the cluster and EC2NodeClass.
+	// +kubebuilder:default:=Always
+	// +optional
+	AdoptionPolicy *InstanceProfileAdoptionPolicy `json:"adoptionPolicy,omitempty"`
+}
+
+// InstanceProfileAdoptionPolicy enumerates the policies for adopting existing instance profiles.
+// +kubebuilder:validation:Enum={Always,IfOwned}
+type InstanceProfileAdoptionPolicy string
+
+const (
+	// InstanceProfileAdoptionPolicyAlways manages an existing instance profile regardless of its tags, replacing its role
+	// if it differs from the EC2NodeClass's role.
+	InstanceProfileAdoptionPolicyAlways InstanceProfileAdoptionPolicy = "Always"
+	// InstanceProfileAdoptionPolicyIfOwned only manages an existing instance profile if it's tagged as owned by the
+	// cluster and EC2NodeClass. Instance profiles that aren't owned are never modified or deleted.
+	InstanceProfileAdoptionPolicyIfOwned InstanceProfileAdoptionPolicy = "IfOwned"
+)
+
+// CPUOptions contains parameters for configuring the processor of provisioned EC2 nodes.
+// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instance-optimize-cpu.html
+type CPUOptions struct {
+	// CoreCount is the number of CPU cores of the instance. Only instance types that support the core count are launched.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	CoreCount *int32 `json:"coreCount,omitempty"`
+	// ThreadsPerCore is the number of threads per CPU core. Set this to 1 to disable simultaneous multithreading.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=2
+	// +optional
+	ThreadsPerCore *int32 `json:"threadsPerCore,omitempty"`
+	// AMDSEVSNP enables AMD SEV-SNP. When enabled, only instance types that support AMD SEV-SNP are launched.
+	// +kubebuilder:validation:Enum:={enabled,disabled}
+	// +optional
+	AMDSEVSNP *string `json:"amdSevSnp,omitempty"`
+}
+
+// EnclaveOptions contains parameters for configuring AWS Nitro Enclaves on provisioned EC2 nodes.
+// See https://docs.aws.amazon.com/enclaves/latest/user/nitro-enclave.html
+type EnclaveOptions struct {
+	// Enabled launches instances with AWS Nitro Enclaves enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// HibernationOptions contains parameters for configuring hibernation on provisioned EC2 nodes.
+// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/Hibernate.html
+type HibernationOptions struct {
+	// Configured launches instances that can be hibernated. Hibernation requires an encrypted root volume that is large
+	// enough to store the instance's memory.
+	// +optional
+	Configured *bool `json:"configured,omitempty"`
 }
 
 // MetadataOptions contains parameters for specifying the exposure of the
@@ -356,7 +1006,8 @@ type BlockDeviceMapping struct {
 	// +optional
 	DeviceName *string `json:"deviceName,omitempty"`
 	// EBS contains parameters used to automatically set up EBS volumes when an instance is launched.
-	// +kubebuilder:validation:XValidation:message="snapshotID or volumeSize must be defined",rule="has(self.snapshotID) || has(self.volumeSize)"
+	// +kubebuilder:validation:XValidation:message="snapshotID, volumeSize, or volumeSizeExpression must be defined",rule="has(self.snapshotID) || has(self.volumeSize) || has(self.volumeSizeExpression)"
+	// +kubebuilder:validation:XValidation:message="volumeSize and volumeSizeExpression are mutually exclusive",rule="!(has(self.volumeSize) && has(self.volumeSizeExpression))"
 	// +kubebuilder:validation:XValidation:message="snapshotID must be set when volumeInitializationRate is set",rule="!has(self.volumeInitializationRate) || (has(self.snapshotID) && self.snapshotID != '')"
 	// +optional
 	EBS *BlockDevice `json:"ebs,omitempty"`
@@ -433,6 +1084,11 @@ type BlockDevice struct {
 	// +kubebuilder:validation:Type:=string
 	// +optional
 	VolumeSize *resource.Quantity `json:"volumeSize,omitempty" hash:"string"`
+	// VolumeSizeExpression computes the volume size from the vCPUs and memory of each instance type, rather than
+	// using a fixed volumeSize. Instance types that resolve to different sizes are launched with separate launch
+	// templates.
+	// +optional
+	VolumeSizeExpression *VolumeSizeExpression `json:"volumeSizeExpression,omitempty"`
 	// VolumeType of the block device.
 	// For more information, see Amazon EBS volume types (https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/EBSVolumeTypes.html)
 	// in the Amazon Elastic Compute Cloud User Guide.
@@ -441,8 +1097,90 @@ type BlockDevice struct {
 	VolumeType *string `json:"volumeType,omitempty"`
 }
 
+// VolumeSizeExpression computes the size of a volume as base + perVCPU * vCPUs + perGiBMemory * memory in GiB, clamped
+// between min and max. The computed size is rounded up to the nearest GiB.
+// +kubebuilder:validation:XValidation:message="base, perVCPU, or perGiBMemory must be defined",rule="has(self.base) || has(self.perVCPU) || has(self.perGiBMemory)"
+type VolumeSizeExpression struct {
+	// Base is the size of the volume before any per-vCPU or per-memory sizes are added.
+	// +kubebuilder:validation:Pattern:="^[0-9]+(Mi|Gi|Ti|M|G|T)$"
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Base *resource.Quantity `json:"base,omitempty" hash:"string"`
+	// PerVCPU is the size added to the volume for each vCPU of the instance type.
+	// +kubebuilder:validation:Pattern:="^[0-9]+(Mi|Gi|Ti|M|G|T)$"
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	PerVCPU *resource.Quantity `json:"perVCPU,omitempty" hash:"string"`
+	// PerGiBMemory is the size added to the volume for each GiB of memory of the instance type.
+	// +kubebuilder:validation:Pattern:="^[0-9]+(Mi|Gi|Ti|M|G|T)$"
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	PerGiBMemory *resource.Quantity `json:"perGiBMemory,omitempty" hash:"string"`
+	// Min is the minimum size of the volume.
+	// +kubebuilder:validation:Pattern:="^[0-9]+(Mi|Gi|Ti|M|G|T)$"
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Min *resource.Quantity `json:"min,omitempty" hash:"string"`
+	// Max is the maximum size of the volume.
+	// +kubebuilder:validation:Pattern:="^[0-9]+(Mi|Gi|Ti|M|G|T)$"
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Max *resource.Quantity `json:"max,omitempty" hash:"string"`
+}
+
+// VolumeSize returns the size of the volume for an instance type with the given number of vCPUs and memory in MiB
+func (in *VolumeSizeExpression) VolumeSize(vcpus int64, memoryMiB int64) *resource.Quantity {
+	var bytes int64
+	if in.Base != nil {
+		bytes += in.Base.Value()
+	}
+	if in.PerVCPU != nil {
+		bytes += in.PerVCPU.Value() * vcpus
+	}
+	if in.PerGiBMemory != nil {
+		bytes += in.PerGiBMemory.Value() * memoryMiB / 1024
+	}
+	if in.Min != nil {
+		bytes = max(bytes, in.Min.Value())
+	}
+	if in.Max != nil {
+		bytes = min(bytes, in.Max.Value())
+	}
+	// EBS volumes are sized in whole GiB
+	gib := (bytes + 1<<30 - 1) >> 30
+	return resource.NewQuantity(max(gib, 1)<<30, resource.BinarySI)
+}
+
+// VolumeEncryptionPolicy enforces encryption of EBS volumes with a KMS key
+type VolumeEncryptionPolicy struct {
+	// KMSKeyID is the identifier (key ID, key alias, key ARN, or alias ARN) of the KMS key that every EBS volume must be
+	// encrypted with.
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	KMSKeyID string `json:"kmsKeyID"`
+	// Mode determines how block device mappings that aren't encrypted with the KMS key are handled. Reject marks the
+	// EC2NodeClass as not ready until the mappings are fixed, Override encrypts them with the KMS key at launch.
+	// +kubebuilder:default:=Reject
+	// +optional
+	Mode VolumeEncryptionPolicyMode `json:"mode,omitempty"`
+}
+
+// VolumeEncryptionPolicyMode enumerates how a VolumeEncryptionPolicy is enforced
+// +kubebuilder:validation:Enum:={Reject,Override}
+type VolumeEncryptionPolicyMode string
+
+const (
+	VolumeEncryptionPolicyModeReject   VolumeEncryptionPolicyMode = "Reject"
+	VolumeEncryptionPolicyModeOverride VolumeEncryptionPolicyMode = "Override"
+)
+
 // InstanceStorePolicy enumerates options for configuring instance store disks.
-// +kubebuilder:validation:Enum={RAID0}
+// +kubebuilder:validation:Enum={RAID0,Mount,ContainerdOnly,Disabled}
 type InstanceStorePolicy string
 
 const (
@@ -451,6 +1189,16 @@ const (
 	// ephemeral storage for more and faster node ephemeral-storage. The node's ephemeral storage can be shared among
 	// pods that request ephemeral storage and container images that are downloaded to the node.
 	InstanceStorePolicyRAID0 InstanceStorePolicy = "RAID0"
+	// InstanceStorePolicyMount formats and mounts the first ephemeral NVMe instance storage disk without RAID. The
+	// containerd and kubelet state directories will then use the disk, and any other disks are left unused.
+	InstanceStorePolicyMount InstanceStorePolicy = "Mount"
+	// InstanceStorePolicyContainerdOnly formats and mounts the first ephemeral NVMe instance storage disk, and only
+	// uses it for the containerd state directory. The kubelet state directory, and therefore the node's
+	// ephemeral-storage, remains on the EBS root volume.
+	InstanceStorePolicyContainerdOnly InstanceStorePolicy = "ContainerdOnly"
+	// InstanceStorePolicyDisabled leaves the ephemeral NVMe instance storage disks unformatted, for use by tools such
+	// as the local static provisioner.
+	InstanceStorePolicyDisabled InstanceStorePolicy = "Disabled"
 )
 
 // EC2NodeClass is the Schema for the EC2NodeClass API
@@ -466,13 +1214,21 @@ type EC2NodeClass struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	// +kubebuilder:validation:XValidation:message="must specify exactly one of ['role', 'instanceProfile']",rule="(has(self.role) && !has(self.instanceProfile)) || (!has(self.role) && has(self.instanceProfile))"
+	// +kubebuilder:validation:XValidation:message="instanceProfileOptions can only be set when role is set",rule="!has(self.instanceProfileOptions) || has(self.role)"
 	// +kubebuilder:validation:XValidation:message="changing from 'instanceProfile' to 'role' is not supported. You must delete and recreate this node class if you want to change this.",rule="(has(oldSelf.role) && has(self.role)) || (has(oldSelf.instanceProfile) && has(self.instanceProfile))"
 	// +kubebuilder:validation:XValidation:message="if set, amiFamily must be 'AL2' or 'Custom' when using an AL2 alias",rule="!has(self.amiFamily) || (self.amiSelectorTerms.exists(x, has(x.alias) && x.alias.find('^[^@]+') == 'al2') ? (self.amiFamily == 'Custom' || self.amiFamily == 'AL2') : true)"
 	// +kubebuilder:validation:XValidation:message="if set, amiFamily must be 'AL2023' or 'Custom' when using an AL2023 alias",rule="!has(self.amiFamily) || (self.amiSelectorTerms.exists(x, has(x.alias) && x.alias.find('^[^@]+') == 'al2023') ? (self.amiFamily == 'Custom' || self.amiFamily == 'AL2023') : true)"
 	// +kubebuilder:validation:XValidation:message="if set, amiFamily must be 'Bottlerocket' or 'Custom' when using a Bottlerocket alias",rule="!has(self.amiFamily) || (self.amiSelectorTerms.exists(x, has(x.alias) && x.alias.find('^[^@]+') == 'bottlerocket') ? (self.amiFamily == 'Custom' || self.amiFamily == 'Bottlerocket') : true)"
 	// +kubebuilder:validation:XValidation:message="if set, amiFamily must be 'Windows2019' or 'Custom' when using a Windows2019 alias",rule="!has(self.amiFamily) || (self.amiSelectorTerms.exists(x, has(x.alias) && x.alias.find('^[^@]+') == 'windows2019') ? (self.amiFamily == 'Custom' || self.amiFamily == 'Windows2019') : true)"
 	// +kubebuilder:validation:XValidation:message="if set, amiFamily must be 'Windows2022' or 'Custom' when using a Windows2022 alias",rule="!has(self.amiFamily) || (self.amiSelectorTerms.exists(x, has(x.alias) && x.alias.find('^[^@]+') == 'windows2022') ? (self.amiFamily == 'Custom' || self.amiFamily == 'Windows2022') : true)"
+	// +kubebuilder:validation:XValidation:message="if set, amiFamily must be 'Windows2025' or 'Custom' when using a Windows2025 alias",rule="!has(self.amiFamily) || (self.amiSelectorTerms.exists(x, has(x.alias) && x.alias.find('^[^@]+') == 'windows2025') ? (self.amiFamily == 'Custom' || self.amiFamily == 'Windows2025') : true)"
+	// +kubebuilder:validation:XValidation:message="bottlerocket can only be set with the Bottlerocket amiFamily",rule="!has(self.bottlerocket) || (has(self.amiFamily) ? self.amiFamily == 'Bottlerocket' : self.amiSelectorTerms.exists(x, has(x.alias) && x.alias.find('^[^@]+') == 'bottlerocket'))"
+	// +kubebuilder:validation:XValidation:message="nodeConfig can only be set with the AL2023 amiFamily",rule="!has(self.nodeConfig) || (has(self.amiFamily) ? self.amiFamily == 'AL2023' : self.amiSelectorTerms.exists(x, has(x.alias) && x.alias.find('^[^@]+') == 'al2023'))"
+	// +kubebuilder:validation:XValidation:message="nodeConfig.instance.localStorage can't be set with instanceStorePolicy",rule="!has(self.instanceStorePolicy) || !has(self.nodeConfig) || !has(self.nodeConfig.instance) || !has(self.nodeConfig.instance.localStorage)"
 	// +kubebuilder:validation:XValidation:message="must specify amiFamily if amiSelectorTerms does not contain an alias",rule="self.amiSelectorTerms.exists(x, has(x.alias)) ? true : has(self.amiFamily)"
+	// +kubebuilder:validation:XValidation:message="hostSelector can only be set when tenancy is 'host'",rule="!has(self.hostSelector) || (has(self.tenancy) && self.tenancy == 'host')"
+	// +kubebuilder:validation:XValidation:message="warmPool poolState 'Hibernated' requires hibernationOptions to be configured",rule="!has(self.warmPool) || !has(self.warmPool.poolState) || self.warmPool.poolState != 'Hibernated' || (has(self.hibernationOptions) && has(self.hibernationOptions.configured) && self.hibernationOptions.configured)"
+	// +kubebuilder:validation:XValidation:message="enclaveOptions and hibernationOptions can't both be enabled",rule="!(has(self.enclaveOptions) && has(self.enclaveOptions.enabled) && self.enclaveOptions.enabled && has(self.hibernationOptions) && has(self.hibernationOptions.configured) && self.hibernationOptions.configured)"
 	Spec   EC2NodeClassSpec   `json:"spec,omitempty"`
 	Status EC2NodeClassStatus `json:"status,omitempty"`
 }
@@ -497,6 +1253,64 @@ func (in *EC2NodeClass) Hash() string {
 	})))
 }
 
+// Tenancy returns the tenancy of instances launched with the EC2NodeClass, defaulting to default tenancy
+func (in *EC2NodeClass) Tenancy() Tenancy {
+	if in.Spec.Tenancy == nil {
+		return TenancyDefault
+	}
+	return *in.Spec.Tenancy
+}
+
+// WarmPoolSize returns the number of instances kept in the warm pool of each NodePool that uses the EC2NodeClass
+func (in *EC2NodeClass) WarmPoolSize() int {
+	if in.Spec.WarmPool == nil {
+		return 0
+	}
+	return int(in.Spec.WarmPool.Size)
+}
+
+// IsAMIHealthy returns false if the EC2NodeClass has an AMI health policy, and the failed NodeClaims out of the total
+// NodeClaims launched from an AMI exceed it
+func (in *EC2NodeClass) IsAMIHealthy(total, failed int) bool {
+	if in.Spec.AMIHealthPolicy == nil || total == 0 {
+		return true
+	}
+	minFailures, maxFailurePercentage := int32(3), int32(50)
+	if in.Spec.AMIHealthPolicy.MinFailures != nil {
+		minFailures = *in.Spec.AMIHealthPolicy.MinFailures
+	}
+	if in.Spec.AMIHealthPolicy.MaxFailurePercentage != nil {
+		maxFailurePercentage = *in.Spec.AMIHealthPolicy.MaxFailurePercentage
+	}
+	return failed < int(minFailures) || failed*100 <= int(maxFailurePercentage)*total
+}
+
+// AMIRolloutFailureThreshold returns the number of failed canary NodeClaims launched from an AMI that halts its rollout
+func (in *EC2NodeClass) AMIRolloutFailureThreshold() int {
+	if in.Spec.AMIRolloutPolicy == nil || in.Spec.AMIRolloutPolicy.FailureThreshold == nil {
+		return 1
+	}
+	return int(*in.Spec.AMIRolloutPolicy.FailureThreshold)
+}
+
+// EnclavesEnabled returns true if instances launched with the EC2NodeClass have AWS Nitro Enclaves enabled
+func (in *EC2NodeClass) EnclavesEnabled() bool {
+	return in.Spec.EnclaveOptions != nil && lo.FromPtr(in.Spec.EnclaveOptions.Enabled)
+}
+
+// HibernationConfigured returns true if instances launched with the EC2NodeClass can be hibernated
+func (in *EC2NodeClass) HibernationConfigured() bool {
+	return in.Spec.HibernationOptions != nil && lo.FromPtr(in.Spec.HibernationOptions.Configured)
+}
+
+// VolumeEncryptionPolicyMode returns how the EC2NodeClass's VolumeEncryptionPolicy is enforced, defaulting to Reject
+func (in *EC2NodeClass) VolumeEncryptionPolicyMode() VolumeEncryptionPolicyMode {
+	if in.Spec.VolumeEncryptionPolicy == nil || in.Spec.VolumeEncryptionPolicy.Mode == "" {
+		return VolumeEncryptionPolicyModeReject
+	}
+	return in.Spec.VolumeEncryptionPolicy.Mode
+}
+
 func (in *EC2NodeClass) InstanceProfileName(clusterName, region string) string {
 	return fmt.Sprintf("%s_%d", clusterName, lo.Must(hashstructure.Hash(fmt.Sprintf("%s%s", region, in.Name), hashstructure.FormatV2, nil)))
 }
@@ -505,8 +1319,28 @@ func (in *EC2NodeClass) InstanceProfileRole() string {
 	return in.Spec.Role
 }
 
+// InstanceProfilePath returns the IAM path of the instance profile, or an empty string if the default path should be used
+func (in *EC2NodeClass) InstanceProfilePath() string {
+	if in.Spec.InstanceProfileOptions == nil {
+		return ""
+	}
+	return lo.FromPtr(in.Spec.InstanceProfileOptions.Path)
+}
+
+// InstanceProfileAdoptionPolicy returns the policy for adopting an existing instance profile, defaulting to Always
+func (in *EC2NodeClass) InstanceProfileAdoptionPolicy() InstanceProfileAdoptionPolicy {
+	if in.Spec.InstanceProfileOptions == nil || in.Spec.InstanceProfileOptions.AdoptionPolicy == nil {
+		return InstanceProfileAdoptionPolicyAlways
+	}
+	return *in.Spec.InstanceProfileOptions.AdoptionPolicy
+}
+
 func (in *EC2NodeClass) InstanceProfileTags(clusterName string, region string) map[string]string {
-	return lo.Assign(in.Spec.Tags, map[string]string{
+	var instanceProfileTags map[string]string
+	if in.Spec.InstanceProfileOptions != nil {
+		instanceProfileTags = in.Spec.InstanceProfileOptions.Tags
+	}
+	return lo.Assign(in.Spec.Tags, instanceProfileTags, map[string]string{
 		fmt.Sprintf("kubernetes.io/cluster/%s", clusterName): "owned",
 		EKSClusterNameTagKey:   clusterName,
 		LabelNodeClass:         in.Name,
@@ -567,6 +1401,7 @@ func amiFamilyFromAlias(alias string) string {
 		AMIFamilyBottlerocket,
 		AMIFamilyWindows2019,
 		AMIFamilyWindows2022,
+		AMIFamilyWindows2025,
 	}, func(family string) bool {
 		return strings.ToLower(family) == components[0]
 	})
@@ -15,6 +15,8 @@ limitations under the License.
 package v1_test
 
 import (
+	"time"
+
 	"github.com/imdario/mergo"
 	"github.com/samber/lo"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -84,14 +86,28 @@ var _ = Describe("Hash", func() {
 
 		Entry("UserData", "9034828637236670345", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{UserData: aws.String("userdata-test-2")}}),
 		Entry("Tags", "6878220270322275255", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Tags: map[string]string{"keyTag-test-3": "valueTag-test-3"}}}),
+		Entry("VolumeTags", "16360860379115801097", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{VolumeTags: map[string]string{"cost-center": "1234"}}}),
+		Entry("VolumeEncryptionPolicy", "15833144565878795942", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{VolumeEncryptionPolicy: &v1.VolumeEncryptionPolicy{KMSKeyID: "arn:aws:kms:us-west-2:111122223333:key/test", Mode: v1.VolumeEncryptionPolicyModeOverride}}}),
 		Entry("Context", "13953931752662869657", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Context: aws.String("context-2")}}),
 		Entry("DetailedMonitoring", "14187487647319890991", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{DetailedMonitoring: aws.Bool(true)}}),
 		Entry("InstanceStorePolicy", "4160809219257698490", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{InstanceStorePolicy: lo.ToPtr(v1.InstanceStorePolicyRAID0)}}),
+		Entry("Bottlerocket", "2026809893417188310", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Bottlerocket: &v1.BottlerocketConfiguration{Kernel: &v1.BottlerocketKernel{Sysctl: map[string]string{"vm.max_map_count": "262144"}}}}}),
+		Entry("NodeConfig", "10185613205523714122", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{NodeConfig: &v1.NodeConfigOverlay{Kubelet: &v1.NodeConfigKubelet{Flags: []string{"--v=4"}}}}}),
 		Entry("AssociatePublicIPAddress", "4469320567057431454", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{AssociatePublicIPAddress: lo.ToPtr(true)}}),
+		Entry("CPUOptions ThreadsPerCore", "16263077798267922354", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{CPUOptions: &v1.CPUOptions{ThreadsPerCore: lo.ToPtr[int32](1)}}}),
+		Entry("CPUOptions CoreCount", "3576269491013093065", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{CPUOptions: &v1.CPUOptions{CoreCount: lo.ToPtr[int32](4)}}}),
+		Entry("CPUOptions AMDSEVSNP", "14140514907081795596", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{CPUOptions: &v1.CPUOptions{AMDSEVSNP: lo.ToPtr("enabled")}}}),
+		Entry("EnclaveOptions", "15725043724894548485", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{EnclaveOptions: &v1.EnclaveOptions{Enabled: lo.ToPtr(true)}}}),
+		Entry("HibernationOptions", "17093845094678853676", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{HibernationOptions: &v1.HibernationOptions{Configured: lo.ToPtr(true)}}}),
+		Entry("Tenancy", "927238785013740409", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Tenancy: lo.ToPtr(v1.TenancyDedicated)}}),
+		Entry("HostSelector", "12470663383796506295", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Tenancy: lo.ToPtr(v1.TenancyHost), HostSelector: &v1.HostSelector{ID: "h-0123456789abcdef0"}}}),
 		Entry("MetadataOptions HTTPEndpoint", "1277386558528601282", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{MetadataOptions: &v1.MetadataOptions{HTTPEndpoint: lo.ToPtr("enabled")}}}),
 		Entry("MetadataOptions HTTPProtocolIPv6", "14697047633165484196", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{MetadataOptions: &v1.MetadataOptions{HTTPProtocolIPv6: lo.ToPtr("enabled")}}}),
 		Entry("MetadataOptions HTTPPutResponseHopLimit", "2086799014304536137", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{MetadataOptions: &v1.MetadataOptions{HTTPPutResponseHopLimit: lo.ToPtr(int64(10))}}}),
 		Entry("MetadataOptions HTTPTokens", "14750841460622248593", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{MetadataOptions: &v1.MetadataOptions{HTTPTokens: lo.ToPtr("required")}}}),
+		Entry("Kubelet CPUManagerPolicy", "10416060926465238909", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Kubelet: &v1.KubeletConfiguration{CPUManagerPolicy: lo.ToPtr("static")}}}),
+		Entry("Kubelet ShutdownGracePeriod", "10990062496302002442", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Kubelet: &v1.KubeletConfiguration{ShutdownGracePeriod: &metav1.Duration{Duration: time.Minute}}}}),
+		Entry("Kubelet ContainerLogMaxSize", "11918942309051742115", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Kubelet: &v1.KubeletConfiguration{ContainerLogMaxSize: lo.ToPtr("50Mi")}}}),
 		Entry("BlockDeviceMapping DeviceName", "11716516558705174498", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{BlockDeviceMappings: []*v1.BlockDeviceMapping{{DeviceName: lo.ToPtr("map-device-test-3")}}}}),
 		Entry("BlockDeviceMapping RootVolume", "11900810786014401721", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{BlockDeviceMappings: []*v1.BlockDeviceMapping{{RootVolume: true}}}}),
 		Entry("BlockDeviceMapping DeleteOnTermination", "14586255897156659742", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{BlockDeviceMappings: []*v1.BlockDeviceMapping{{EBS: &v1.BlockDevice{DeleteOnTermination: lo.ToPtr(true)}}}}}),
@@ -100,12 +116,15 @@ var _ = Describe("Hash", func() {
 		Entry("BlockDeviceMapping KMSKeyID", "14601456769467439478", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{BlockDeviceMappings: []*v1.BlockDeviceMapping{{EBS: &v1.BlockDevice{KMSKeyID: lo.ToPtr("test")}}}}}),
 		Entry("BlockDeviceMapping SnapshotID", "8031059801598053215", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{BlockDeviceMappings: []*v1.BlockDeviceMapping{{EBS: &v1.BlockDevice{SnapshotID: lo.ToPtr("test")}}}}}),
 		Entry("BlockDeviceMapping Throughput", "14410045481146650034", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{BlockDeviceMappings: []*v1.BlockDeviceMapping{{EBS: &v1.BlockDevice{Throughput: lo.ToPtr(int64(10))}}}}}),
+		Entry("BlockDeviceMapping VolumeSizeExpression", "17795252326601031113", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{BlockDeviceMappings: []*v1.BlockDeviceMapping{{EBS: &v1.BlockDevice{VolumeSizeExpression: &v1.VolumeSizeExpression{PerVCPU: lo.ToPtr(resource.MustParse("10Gi"))}}}}}}),
 		Entry("BlockDeviceMapping VolumeType", "9480251663542054235", v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{BlockDeviceMappings: []*v1.BlockDeviceMapping{{EBS: &v1.BlockDevice{VolumeType: lo.ToPtr("io1")}}}}}),
 
 		// Behavior / Dynamic fields, expect same hash as base
 		Entry("Modified AMISelector", staticHash, v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{AMISelectorTerms: []v1.AMISelectorTerm{{Tags: map[string]string{"": "ami-test-value"}}}}}),
 		Entry("Modified SubnetSelector", staticHash, v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{SubnetSelectorTerms: []v1.SubnetSelectorTerm{{Tags: map[string]string{"subnet-test-key": "subnet-test-value"}}}}}),
 		Entry("Modified SecurityGroupSelector", staticHash, v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{SecurityGroupSelectorTerms: []v1.SecurityGroupSelectorTerm{{Tags: map[string]string{"security-group-test-key": "security-group-test-value"}}}}}),
+		Entry("Modified InstanceProfileOptions", staticHash, v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{InstanceProfileOptions: &v1.InstanceProfileOptions{Path: lo.ToPtr("/karpenter/"), Tags: map[string]string{"team": "platform"}}}}),
+		Entry("Modified PlacementGroup", staticHash, v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{PlacementGroup: &v1.PlacementGroupSelector{Name: "placement-group-test-name"}}}),
 	)
 	// We create a separate test for updating blockDeviceMapping volumeSize, since resource.Quantity is a struct, and mergo.WithSliceDeepCopy
 	// doesn't work well with unexported fields, like the ones that are present in resource.Quantity
@@ -168,6 +187,21 @@ var _ = Describe("Hash", func() {
 		updatedHash := nodeClass.Hash()
 		Expect(hash).ToNot(Equal(updatedHash))
 	})
+	It("should change hash when networkInterfaces are updated", func() {
+		hash := nodeClass.Hash()
+		nodeClass.Spec.NetworkInterfaces = []v1.NetworkInterface{{DeviceIndex: 1, SubnetSelectorTerms: []v1.SubnetSelectorTerm{{ID: "subnet-test"}}}}
+		updatedHash := nodeClass.Hash()
+		Expect(hash).ToNot(Equal(updatedHash))
+		nodeClass.Spec.NetworkInterfaces[0].ENASrdSpecification = &v1.ENASrdSpecification{ENASrdEnabled: lo.ToPtr(true)}
+		Expect(nodeClass.Hash()).ToNot(Equal(updatedHash))
+	})
+	It("should not change hash when networkInterface selector terms are updated", func() {
+		nodeClass.Spec.NetworkInterfaces = []v1.NetworkInterface{{DeviceIndex: 1, SubnetSelectorTerms: []v1.SubnetSelectorTerm{{ID: "subnet-test"}}}}
+		hash := nodeClass.Hash()
+		nodeClass.Spec.NetworkInterfaces[0].SubnetSelectorTerms = []v1.SubnetSelectorTerm{{ID: "subnet-test-2"}}
+		nodeClass.Spec.NetworkInterfaces[0].SecurityGroupSelectorTerms = []v1.SecurityGroupSelectorTerm{{ID: "sg-test"}}
+		Expect(nodeClass.Hash()).To(Equal(hash))
+	})
 	It("should not change hash when tags are re-ordered", func() {
 		hash := nodeClass.Hash()
 		nodeClass.Spec.Tags = map[string]string{"keyTag-2": "valueTag-2", "keyTag-1": "valueTag-1"}
@@ -32,6 +32,17 @@ const (
 	ConditionTypeInstanceProfileReady      = "InstanceProfileReady"
 	ConditionTypeCapacityReservationsReady = "CapacityReservationsReady"
 	ConditionTypeValidationSucceeded       = "ValidationSucceeded"
+	ConditionTypeNetworkInterfacesReady    = "NetworkInterfacesReady"
+	ConditionTypePlacementGroupReady       = "PlacementGroupReady"
+	// ConditionTypeVolumeEncryptionCompliant reports whether every EBS block device mapping satisfies the
+	// VolumeEncryptionPolicy. It's only included in readiness when a VolumeEncryptionPolicy is configured.
+	ConditionTypeVolumeEncryptionCompliant = "VolumeEncryptionCompliant"
+	// ConditionTypeSubnetsHaveCapacity reports whether every zone has a subnet with available IP addresses. It's
+	// informational and doesn't affect the readiness of the EC2NodeClass.
+	ConditionTypeSubnetsHaveCapacity = "SubnetsHaveCapacity"
+	// ConditionTypeSecurityGroupRulesValid reports whether the resolved security groups allow the traffic nodes need to
+	// join the cluster. It's only set when security group rule validation is enabled and doesn't affect readiness.
+	ConditionTypeSecurityGroupRulesValid = "SecurityGroupRulesValid"
 )
 
 // Subnet contains resolved Subnet selector values utilized for node launch
@@ -71,8 +82,33 @@ type AMI struct {
 	// Requirements of the AMI to be utilized on an instance type
 	// +required
 	Requirements []corev1.NodeSelectorRequirement `json:"requirements"`
+	// RolloutPhase of the AMI, set when the EC2NodeClass has an AMI rollout policy
+	// +kubebuilder:validation:Enum:={Stable,Canary,Halted}
+	// +optional
+	RolloutPhase AMIRolloutPhase `json:"rolloutPhase,omitempty"`
+	// RolloutStartTime is when the AMI entered the Canary phase
+	// +optional
+	RolloutStartTime *metav1.Time `json:"rolloutStartTime,omitempty"`
+	// RolloutFailures is the number of canary NodeClaims launched from the AMI that failed to launch or to become ready
+	// +optional
+	RolloutFailures int32 `json:"rolloutFailures,omitempty"`
+	// RolloutFailuresObservedTime is when the failures of the AMI's canary NodeClaims were last counted
+	// +optional
+	RolloutFailuresObservedTime *metav1.Time `json:"rolloutFailuresObservedTime,omitempty"`
 }
 
+// AMIRolloutPhase is the phase of an AMI in a staged rollout
+type AMIRolloutPhase string
+
+const (
+	// AMIRolloutPhaseStable AMIs are used for all launches that aren't canaries
+	AMIRolloutPhaseStable AMIRolloutPhase = "Stable"
+	// AMIRolloutPhaseCanary AMIs are newly resolved and are only used for canary launches until they're promoted
+	AMIRolloutPhaseCanary AMIRolloutPhase = "Canary"
+	// AMIRolloutPhaseHalted AMIs had too many canary failures and aren't used for launches
+	AMIRolloutPhaseHalted AMIRolloutPhase = "Halted"
+)
+
 type CapacityReservation struct {
 	// The availability zone the capacity reservation is available in.
 	// +required
@@ -98,6 +134,74 @@ type CapacityReservation struct {
 	OwnerID string `json:"ownerID"`
 }
 
+// NetworkInterfaceStatus contains the resolved subnets and security groups of a network interface
+type NetworkInterfaceStatus struct {
+	// DeviceIndex of the network interface
+	// +required
+	DeviceIndex int32 `json:"deviceIndex"`
+	// Subnets contains the current subnet values that are available to the network interface under its subnet selectors.
+	// This is only set for secondary network interfaces.
+	// +optional
+	Subnets []Subnet `json:"subnets,omitempty"`
+	// SecurityGroups contains the current security group values that are available to the network interface.
+	// +optional
+	SecurityGroups []SecurityGroup `json:"securityGroups,omitempty"`
+}
+
+// PlacementGroup contains the resolved placement group utilized for node launch
+type PlacementGroup struct {
+	// ID of the placement group
+	// +required
+	ID string `json:"id"`
+	// Name of the placement group
+	// +required
+	Name string `json:"name"`
+	// Strategy of the placement group
+	// +kubebuilder:validation:Enum:={cluster,partition,spread}
+	// +required
+	Strategy PlacementGroupStrategy `json:"strategy"`
+	// PartitionCount is the number of partitions in a partition placement group
+	// +optional
+	PartitionCount int32 `json:"partitionCount,omitempty"`
+	// SpreadLevel is the level at which instances in a spread placement group are spread
+	// +optional
+	SpreadLevel string `json:"spreadLevel,omitempty"`
+}
+
+// AMIDeprecation summarizes the deprecation of the AMIs used by the EC2NodeClass's instances
+type AMIDeprecation struct {
+	// DeprecatedNodes is the number of instances using an AMI that has passed its deprecation time
+	// +optional
+	DeprecatedNodes int32 `json:"deprecatedNodes,omitempty"`
+	// EarliestDeprecationTime is the earliest deprecation time of the AMIs used by instances, including AMIs that
+	// aren't deprecated yet
+	// +optional
+	EarliestDeprecationTime *metav1.Time `json:"earliestDeprecationTime,omitempty"`
+}
+
+// AMIHealth records the state of the EC2NodeClass's AMI health policy, so that it's retained across restarts
+type AMIHealth struct {
+	// KnownGood are the most recently resolved healthy AMIs for each set of requirements. An excluded AMI is replaced
+	// by the known good AMI with the same requirements. A newly resolved AMI only replaces a known good AMI once a
+	// NodeClaim launched from it has become ready.
+	// +optional
+	KnownGood []AMI `json:"knownGood,omitempty"`
+	// Excluded are the AMIs that were excluded because too many of the NodeClaims launched from them failed to become
+	// ready
+	// +optional
+	Excluded []ExcludedAMI `json:"excluded,omitempty"`
+}
+
+// ExcludedAMI is an AMI that was excluded by the AMI health policy
+type ExcludedAMI struct {
+	// ID of the AMI
+	// +required
+	ID string `json:"id"`
+	// ExclusionTime is when the AMI was excluded. Exclusions expire after 24 hours, after which the AMI can be used again.
+	// +required
+	ExclusionTime metav1.Time `json:"exclusionTime"`
+}
+
 // EC2NodeClassStatus contains the resolved state of the EC2NodeClass
 type EC2NodeClassStatus struct {
 	// Subnets contains the current subnet values that are available to the
@@ -108,6 +212,10 @@ type EC2NodeClassStatus struct {
 	// cluster under the SecurityGroups selectors.
 	// +optional
 	SecurityGroups []SecurityGroup `json:"securityGroups,omitempty"`
+	// NetworkInterfaces contains the resolved subnets and security groups of the network interfaces configured by
+	// spec.networkInterfaces.
+	// +optional
+	NetworkInterfaces []NetworkInterfaceStatus `json:"networkInterfaces,omitempty"`
 	// CapacityReservations contains the current capacity reservation values that are available to this NodeClass under the
 	// CapacityReservation selectors.
 	// +optional
@@ -116,6 +224,19 @@ type EC2NodeClassStatus struct {
 	// cluster under the AMI selectors.
 	// +optional
 	AMIs []AMI `json:"amis,omitempty"`
+	// AMIDeprecation summarizes the deprecation of the AMIs used by the EC2NodeClass's instances
+	// +optional
+	AMIDeprecation *AMIDeprecation `json:"amiDeprecation,omitempty"`
+	// AMIHealth records the AMIs excluded by the AMI health policy and the AMIs they're replaced by
+	// +optional
+	AMIHealth *AMIHealth `json:"amiHealth,omitempty"`
+	// AMICopies contains the IDs of the AMIs that have been copied from another region for the EC2NodeClass and haven't
+	// been deleted yet. Copies are only listed while replication is configured or copies remain.
+	// +optional
+	AMICopies []string `json:"amiCopies,omitempty"`
+	// PlacementGroup contains the placement group resolved by the placement group selector
+	// +optional
+	PlacementGroup *PlacementGroup `json:"placementGroup,omitempty"`
 	// InstanceProfile contains the resolved instance profile for the role
 	// +optional
 	InstanceProfile string `json:"instanceProfile,omitempty"`
@@ -135,6 +256,15 @@ func (in *EC2NodeClass) StatusConditions() status.ConditionSet {
 	if CapacityReservationsEnabled {
 		conds = append(conds, ConditionTypeCapacityReservationsReady)
 	}
+	if len(in.Spec.NetworkInterfaces) != 0 {
+		conds = append(conds, ConditionTypeNetworkInterfacesReady)
+	}
+	if in.Spec.PlacementGroup != nil {
+		conds = append(conds, ConditionTypePlacementGroupReady)
+	}
+	if in.Spec.VolumeEncryptionPolicy != nil {
+		conds = append(conds, ConditionTypeVolumeEncryptionCompliant)
+	}
 	return status.NewReadyConditions(conds...).For(in)
 }
 
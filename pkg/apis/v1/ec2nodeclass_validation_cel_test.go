@@ -84,7 +84,7 @@ var _ = Describe("CEL/Validation", func() {
 		})
 	})
 	Context("AMIFamily", func() {
-		amiFamilies := []string{v1.AMIFamilyAL2, v1.AMIFamilyAL2023, v1.AMIFamilyBottlerocket, v1.AMIFamilyWindows2019, v1.AMIFamilyWindows2022, v1.AMIFamilyCustom}
+		amiFamilies := []string{v1.AMIFamilyAL2, v1.AMIFamilyAL2023, v1.AMIFamilyBottlerocket, v1.AMIFamilyWindows2019, v1.AMIFamilyWindows2022, v1.AMIFamilyWindows2025, v1.AMIFamilyCustom}
 		DescribeTable("should succeed with valid families", func() []interface{} {
 			f := func(amiFamily string) {
 				// Set a custom AMI family so it's compatible with all ami family types
@@ -198,6 +198,10 @@ var _ = Describe("CEL/Validation", func() {
 				v1.EKSClusterNameTagKey: "test",
 			}
 			Expect(env.Client.Create(ctx, nc)).To(Not(Succeed()))
+			nc.Spec.Tags = map[string]string{
+				v1.WarmPoolTagKey: "default",
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Not(Succeed()))
 			nc.Spec.Tags = map[string]string{
 				v1.LabelNodeClass: "test",
 			}
@@ -208,6 +212,49 @@ var _ = Describe("CEL/Validation", func() {
 			Expect(env.Client.Create(ctx, nc)).To(Not(Succeed()))
 		})
 	})
+	Context("VolumeTags", func() {
+		It("should succeed when volume tags aren't restricted", func() {
+			nc.Spec.VolumeTags = map[string]string{"cost-center": "1234"}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail if volume tags contain a restricted domain key", func() {
+			nc.Spec.VolumeTags = map[string]string{
+				karpv1.NodePoolLabelKey: "value",
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Not(Succeed()))
+			nc.Spec.VolumeTags = map[string]string{
+				"kubernetes.io/cluster/test": "value",
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Not(Succeed()))
+			nc.Spec.VolumeTags = map[string]string{
+				v1.LabelNodeClass: "test",
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Not(Succeed()))
+		})
+		It("should fail if volume tags contain an empty key", func() {
+			nc.Spec.VolumeTags = map[string]string{"": "value"}
+			Expect(env.Client.Create(ctx, nc)).To(Not(Succeed()))
+		})
+	})
+	Context("VolumeEncryptionPolicy", func() {
+		It("should default the mode to Reject", func() {
+			nc.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{KMSKeyID: "alias/ebs"}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			Expect(nc.Spec.VolumeEncryptionPolicy.Mode).To(Equal(v1.VolumeEncryptionPolicyModeReject))
+		})
+		It("should succeed with the Override mode", func() {
+			nc.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{KMSKeyID: "alias/ebs", Mode: v1.VolumeEncryptionPolicyModeOverride}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail with an unsupported mode", func() {
+			nc.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{KMSKeyID: "alias/ebs", Mode: v1.VolumeEncryptionPolicyMode("Audit")}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an empty KMS key", func() {
+			nc.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
 	Context("SubnetSelectorTerms", func() {
 		It("should succeed with a valid subnet selector on tags", func() {
 			nc.Spec.SubnetSelectorTerms = []v1.SubnetSelectorTerm{
@@ -432,6 +479,214 @@ var _ = Describe("CEL/Validation", func() {
 			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
 		})
 	})
+	Context("NetworkInterfaces", func() {
+		It("should succeed with a secondary network interface", func() {
+			nc.Spec.NetworkInterfaces = []v1.NetworkInterface{
+				{
+					DeviceIndex:                1,
+					SubnetSelectorTerms:        []v1.SubnetSelectorTerm{{Tags: map[string]string{"purpose": "storage"}}},
+					SecurityGroupSelectorTerms: []v1.SecurityGroupSelectorTerm{{ID: "sg-12345749"}},
+					ENASrdSpecification:        &v1.ENASrdSpecification{ENASrdEnabled: lo.ToPtr(true), ENASrdUDPEnabled: lo.ToPtr(true)},
+				},
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should succeed when configuring the primary network interface without subnet selector terms", func() {
+			nc.Spec.NetworkInterfaces = []v1.NetworkInterface{
+				{
+					DeviceIndex:         0,
+					InterfaceType:       lo.ToPtr("interface"),
+					ENASrdSpecification: &v1.ENASrdSpecification{ENASrdEnabled: lo.ToPtr(true)},
+				},
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail when the primary network interface specifies subnet selector terms", func() {
+			nc.Spec.NetworkInterfaces = []v1.NetworkInterface{
+				{
+					DeviceIndex:         0,
+					SubnetSelectorTerms: []v1.SubnetSelectorTerm{{ID: "subnet-12345749"}},
+				},
+			}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when a secondary network interface doesn't specify subnet selector terms", func() {
+			nc.Spec.NetworkInterfaces = []v1.NetworkInterface{{DeviceIndex: 1}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when device indices aren't unique", func() {
+			nc.Spec.NetworkInterfaces = []v1.NetworkInterface{
+				{DeviceIndex: 1, SubnetSelectorTerms: []v1.SubnetSelectorTerm{{ID: "subnet-12345749"}}},
+				{DeviceIndex: 1, SubnetSelectorTerms: []v1.SubnetSelectorTerm{{ID: "subnet-12345750"}}},
+			}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an unsupported interface type", func() {
+			nc.Spec.NetworkInterfaces = []v1.NetworkInterface{{DeviceIndex: 0, InterfaceType: lo.ToPtr("trunk")}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when enabling ENA Express for UDP without TCP", func() {
+			nc.Spec.NetworkInterfaces = []v1.NetworkInterface{
+				{DeviceIndex: 0, ENASrdSpecification: &v1.ENASrdSpecification{ENASrdUDPEnabled: lo.ToPtr(true)}},
+			}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
+	Context("PlacementGroup", func() {
+		It("should succeed with a valid placement group selector on id", func() {
+			nc.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-12345749"}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should succeed with a valid placement group selector on name and strategy", func() {
+			nc.Spec.PlacementGroup = &v1.PlacementGroupSelector{Name: "hpc", Strategy: lo.ToPtr(v1.PlacementGroupStrategyCluster)}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should succeed with a valid placement group selector on tags", func() {
+			nc.Spec.PlacementGroup = &v1.PlacementGroupSelector{Tags: map[string]string{"workload": "cassandra"}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail when no selector fields are set", func() {
+			nc.Spec.PlacementGroup = &v1.PlacementGroupSelector{Strategy: lo.ToPtr(v1.PlacementGroupStrategyPartition)}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when id is set with other selector fields", func() {
+			nc.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-12345749", Tags: map[string]string{"workload": "cassandra"}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when name is set with other selector fields", func() {
+			nc.Spec.PlacementGroup = &v1.PlacementGroupSelector{Name: "hpc", Tags: map[string]string{"workload": "cassandra"}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an invalid id", func() {
+			nc.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "sg-12345749"}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with empty tag values", func() {
+			nc.Spec.PlacementGroup = &v1.PlacementGroupSelector{Tags: map[string]string{"workload": ""}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an unsupported strategy", func() {
+			nc.Spec.PlacementGroup = &v1.PlacementGroupSelector{Name: "hpc", Strategy: lo.ToPtr(v1.PlacementGroupStrategy("host"))}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
+	Context("CPUOptions", func() {
+		It("should succeed with valid cpu options", func() {
+			nc.Spec.CPUOptions = &v1.CPUOptions{
+				CoreCount:      lo.ToPtr[int32](4),
+				ThreadsPerCore: lo.ToPtr[int32](1),
+				AMDSEVSNP:      lo.ToPtr("enabled"),
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail when coreCount is less than 1", func() {
+			nc.Spec.CPUOptions = &v1.CPUOptions{CoreCount: lo.ToPtr[int32](0)}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		DescribeTable("should fail with an unsupported threadsPerCore", func(threadsPerCore int32) {
+			nc.Spec.CPUOptions = &v1.CPUOptions{ThreadsPerCore: lo.ToPtr(threadsPerCore)}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		},
+			Entry("0", int32(0)),
+			Entry("3", int32(3)),
+		)
+		It("should fail with an unsupported amdSevSnp value", func() {
+			nc.Spec.CPUOptions = &v1.CPUOptions{AMDSEVSNP: lo.ToPtr("on")}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
+	Context("EnclaveOptions and HibernationOptions", func() {
+		It("should succeed when enclaves are enabled", func() {
+			nc.Spec.EnclaveOptions = &v1.EnclaveOptions{Enabled: lo.ToPtr(true)}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should succeed when hibernation is configured", func() {
+			nc.Spec.HibernationOptions = &v1.HibernationOptions{Configured: lo.ToPtr(true)}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should succeed when enclaves are disabled and hibernation is configured", func() {
+			nc.Spec.EnclaveOptions = &v1.EnclaveOptions{Enabled: lo.ToPtr(false)}
+			nc.Spec.HibernationOptions = &v1.HibernationOptions{Configured: lo.ToPtr(true)}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail when enclaves are enabled and hibernation is configured", func() {
+			nc.Spec.EnclaveOptions = &v1.EnclaveOptions{Enabled: lo.ToPtr(true)}
+			nc.Spec.HibernationOptions = &v1.HibernationOptions{Configured: lo.ToPtr(true)}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
+	Context("WarmPool", func() {
+		It("should succeed with a stopped warm pool", func() {
+			nc.Spec.WarmPool = &v1.WarmPool{Size: 2}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			Expect(nc.Spec.WarmPool.PoolState).To(Equal(v1.WarmPoolStateStopped))
+		})
+		It("should succeed with a hibernated warm pool when hibernation is configured", func() {
+			nc.Spec.WarmPool = &v1.WarmPool{Size: 2, PoolState: v1.WarmPoolStateHibernated}
+			nc.Spec.HibernationOptions = &v1.HibernationOptions{Configured: lo.ToPtr(true)}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail with a hibernated warm pool when hibernation isn't configured", func() {
+			nc.Spec.WarmPool = &v1.WarmPool{Size: 2, PoolState: v1.WarmPoolStateHibernated}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an unsupported pool state", func() {
+			nc.Spec.WarmPool = &v1.WarmPool{Size: 2, PoolState: v1.WarmPoolState("Running")}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when the size exceeds the maximum", func() {
+			nc.Spec.WarmPool = &v1.WarmPool{Size: 101}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
+	Context("Tenancy", func() {
+		DescribeTable("should succeed with a supported tenancy", func(tenancy v1.Tenancy) {
+			nc.Spec.Tenancy = lo.ToPtr(tenancy)
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		},
+			Entry("default", v1.TenancyDefault),
+			Entry("dedicated", v1.TenancyDedicated),
+			Entry("host", v1.TenancyHost),
+		)
+		It("should fail with an unsupported tenancy", func() {
+			nc.Spec.Tenancy = lo.ToPtr(v1.Tenancy("shared"))
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should succeed with a host id", func() {
+			nc.Spec.Tenancy = lo.ToPtr(v1.TenancyHost)
+			nc.Spec.HostSelector = &v1.HostSelector{ID: "h-0123456789abcdef0"}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should succeed with a host resource group arn", func() {
+			nc.Spec.Tenancy = lo.ToPtr(v1.TenancyHost)
+			nc.Spec.HostSelector = &v1.HostSelector{ResourceGroupARN: "arn:aws:resource-groups:us-west-2:123456789012:group/mac-hosts"}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail with both a host id and a host resource group arn", func() {
+			nc.Spec.Tenancy = lo.ToPtr(v1.TenancyHost)
+			nc.Spec.HostSelector = &v1.HostSelector{ID: "h-0123456789abcdef0", ResourceGroupARN: "arn:aws:resource-groups:us-west-2:123456789012:group/mac-hosts"}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an empty host selector", func() {
+			nc.Spec.Tenancy = lo.ToPtr(v1.TenancyHost)
+			nc.Spec.HostSelector = &v1.HostSelector{}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an invalid host id", func() {
+			nc.Spec.Tenancy = lo.ToPtr(v1.TenancyHost)
+			nc.Spec.HostSelector = &v1.HostSelector{ID: "i-0123456789abcdef0"}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		DescribeTable("should fail with a host selector when tenancy isn't host", func(tenancy *v1.Tenancy) {
+			nc.Spec.Tenancy = tenancy
+			nc.Spec.HostSelector = &v1.HostSelector{ID: "h-0123456789abcdef0"}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		},
+			Entry("unset", nil),
+			Entry("default", lo.ToPtr(v1.TenancyDefault)),
+			Entry("dedicated", lo.ToPtr(v1.TenancyDedicated)),
+		)
+	})
 	Context("CapacityReservationSelectorTerms", func() {
 		It("should succeed with a valid capacity reservation selector on tags", func() {
 			nc.Spec.CapacityReservationSelectorTerms = []v1.CapacityReservationSelectorTerm{{
@@ -713,6 +968,7 @@ var _ = Describe("CEL/Validation", func() {
 			Entry("bottlerocket (pinned)", "bottlerocket@1.10.0", v1.AMIFamilyBottlerocket),
 			Entry("windows2019 (latest)", "windows2019@latest", v1.AMIFamilyWindows2019),
 			Entry("windows2022 (latest)", "windows2022@latest", v1.AMIFamilyWindows2022),
+			Entry("windows2025 (latest)", "windows2025@latest", v1.AMIFamilyWindows2025),
 		)
 		DescribeTable(
 			"should fail for incorrectly formatted aliases",
@@ -738,7 +994,293 @@ var _ = Describe("CEL/Validation", func() {
 			},
 			Entry("Windows2019", "windows2019@v1.0.0"),
 			Entry("Windows2022", "windows2022@v1.0.0"),
+			Entry("Windows2025", "windows2025@v1.0.0"),
+		)
+		It("should succeed when specifying minAge and maxAge with tags", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Tags:   map[string]string{"test": "testvalue"},
+				MinAge: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+				MaxAge: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+			}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should succeed when specifying minAge with name", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Name:   "my-ami",
+				MinAge: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+			}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail when specifying minAge with alias", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Alias:  "al2023@latest",
+				MinAge: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when specifying maxAge with id", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				ID:     "ami-12345749",
+				MaxAge: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when minAge isn't less than maxAge", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Tags:   map[string]string{"test": "testvalue"},
+				MinAge: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+				MaxAge: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should succeed when specifying replication with tags", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Tags:        map[string]string{"test": "testvalue"},
+				Replication: &v1.AMIReplication{SourceRegion: "us-east-1"},
+			}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should succeed when specifying replication with id", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				ID:          "ami-12345749",
+				Replication: &v1.AMIReplication{SourceRegion: "us-east-1"},
+			}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail when specifying replication with alias", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Alias:       "al2023@latest",
+				Replication: &v1.AMIReplication{SourceRegion: "us-east-1"},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when specifying replication without a source region", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Tags:        map[string]string{"test": "testvalue"},
+				Replication: &v1.AMIReplication{},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should succeed when specifying requirements", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Tags: map[string]string{"test": "testvalue"},
+				Requirements: []corev1.NodeSelectorRequirement{
+					{Key: v1.LabelInstanceGPUManufacturer, Operator: corev1.NodeSelectorOpIn, Values: []string{"amd"}},
+					{Key: v1.LabelInstanceAcceleratorName, Operator: corev1.NodeSelectorOpDoesNotExist},
+				},
+			}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail when specifying requirements with alias", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Alias: "al2023@latest",
+				Requirements: []corev1.NodeSelectorRequirement{
+					{Key: v1.LabelInstanceGPUManufacturer, Operator: corev1.NodeSelectorOpIn, Values: []string{"amd"}},
+				},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when specifying requirements with an unsupported key", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Tags: map[string]string{"test": "testvalue"},
+				Requirements: []corev1.NodeSelectorRequirement{
+					{Key: corev1.LabelInstanceTypeStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"g4ad.xlarge"}},
+				},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when specifying requirements with an unsupported operator", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Tags: map[string]string{"test": "testvalue"},
+				Requirements: []corev1.NodeSelectorRequirement{
+					{Key: v1.LabelInstanceGPUManufacturer, Operator: corev1.NodeSelectorOpGt, Values: []string{"1"}},
+				},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when specifying requirements with operator 'In' without values", func() {
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Tags: map[string]string{"test": "testvalue"},
+				Requirements: []corev1.NodeSelectorRequirement{
+					{Key: v1.LabelInstanceGPUManufacturer, Operator: corev1.NodeSelectorOpIn},
+				},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
+	Context("AMIHealthPolicy", func() {
+		It("should default the health policy thresholds", func() {
+			nc.Spec.AMIHealthPolicy = &v1.AMIHealthPolicy{}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			Expect(nc.Spec.AMIHealthPolicy.MinFailures).To(Equal(lo.ToPtr[int32](3)))
+			Expect(nc.Spec.AMIHealthPolicy.MaxFailurePercentage).To(Equal(lo.ToPtr[int32](50)))
+		})
+		It("should fail when minFailures is 0", func() {
+			nc.Spec.AMIHealthPolicy = &v1.AMIHealthPolicy{MinFailures: lo.ToPtr[int32](0)}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when maxFailurePercentage exceeds 100", func() {
+			nc.Spec.AMIHealthPolicy = &v1.AMIHealthPolicy{MaxFailurePercentage: lo.ToPtr[int32](101)}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
+	Context("AMIRolloutPolicy", func() {
+		It("should succeed with a valid rollout policy", func() {
+			nc.Spec.AMIRolloutPolicy = &v1.AMIRolloutPolicy{CanaryPercentage: 10, BakeTime: metav1.Duration{Duration: 24 * time.Hour}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			Expect(nc.Spec.AMIRolloutPolicy.FailureThreshold).To(Equal(lo.ToPtr[int32](1)))
+		})
+		It("should fail when the canary percentage is 0", func() {
+			nc.Spec.AMIRolloutPolicy = &v1.AMIRolloutPolicy{CanaryPercentage: 0, BakeTime: metav1.Duration{Duration: time.Hour}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when the canary percentage exceeds 100", func() {
+			nc.Spec.AMIRolloutPolicy = &v1.AMIRolloutPolicy{CanaryPercentage: 101, BakeTime: metav1.Duration{Duration: time.Hour}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail when the failure threshold is 0", func() {
+			nc.Spec.AMIRolloutPolicy = &v1.AMIRolloutPolicy{CanaryPercentage: 10, BakeTime: metav1.Duration{Duration: time.Hour}, FailureThreshold: lo.ToPtr[int32](0)}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
+	Context("Bottlerocket", func() {
+		BeforeEach(func() {
+			nc.Spec.AMIFamily = nil
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "bottlerocket@latest"}}
+		})
+		It("should succeed with a valid configuration", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{
+				BootstrapContainers: map[string]v1.BottlerocketBootstrapContainer{"setup": {Source: "public.ecr.aws/example/setup:latest", UserData: lo.ToPtr("aGVsbG8=")}},
+				BootstrapCommands:   map[string]v1.BottlerocketBootstrapCommand{"hello": {Commands: [][]string{{"echo", "hello"}}}},
+				HostContainers:      &v1.BottlerocketHostContainers{Admin: &v1.BottlerocketHostContainer{Enabled: lo.ToPtr(true)}},
+				Kernel:              &v1.BottlerocketKernel{Sysctl: map[string]string{"vm.max_map_count": "262144", "net/ipv4/ip_forward": "1"}},
+				ContainerRegistry:   &v1.BottlerocketContainerRegistry{Mirrors: []v1.BottlerocketRegistryMirror{{Registry: "*", Endpoints: []string{"https://mirror.example.com"}}}},
+				NTP:                 &v1.BottlerocketNTP{TimeServers: []string{"169.254.169.123"}},
+				OCIDefaults: &v1.BottlerocketOCIDefaults{
+					Capabilities:   map[string]bool{"sys-admin": true},
+					ResourceLimits: map[string]v1.BottlerocketResourceLimit{"max-open-files": {HardLimit: -1, SoftLimit: -1}},
+				},
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			Expect(nc.Spec.Bottlerocket.BootstrapContainers["setup"].Mode).To(Equal(v1.BottlerocketBootstrapModeAlways))
+			Expect(nc.Spec.Bottlerocket.BootstrapCommands["hello"].Mode).To(Equal(v1.BottlerocketBootstrapModeAlways))
+		})
+		It("should succeed with the Bottlerocket amiFamily", func() {
+			nc.Spec.AMIFamily = lo.ToPtr(v1.AMIFamilyBottlerocket)
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{ID: "ami-01234567890123456"}}
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{NTP: &v1.BottlerocketNTP{TimeServers: []string{"169.254.169.123"}}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		DescribeTable(
+			"should fail with other AMI families",
+			func(family string, terms []v1.AMISelectorTerm) {
+				nc.Spec.AMIFamily = lo.EmptyableToPtr(family)
+				nc.Spec.AMISelectorTerms = terms
+				nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{NTP: &v1.BottlerocketNTP{TimeServers: []string{"169.254.169.123"}}}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			},
+			Entry("AL2023 alias", "", []v1.AMISelectorTerm{{Alias: "al2023@latest"}}),
+			Entry("Custom with a Bottlerocket alias", v1.AMIFamilyCustom, []v1.AMISelectorTerm{{Alias: "bottlerocket@latest"}}),
+			Entry("Custom", v1.AMIFamilyCustom, []v1.AMISelectorTerm{{ID: "ami-01234567890123456"}}),
+		)
+		It("should fail with an invalid bootstrap container name", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{BootstrapContainers: map[string]v1.BottlerocketBootstrapContainer{"Setup_1": {Source: "image"}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with a bootstrap container without a source", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{BootstrapContainers: map[string]v1.BottlerocketBootstrapContainer{"setup": {}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with a bootstrap container with an invalid mode", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{BootstrapContainers: map[string]v1.BottlerocketBootstrapContainer{"setup": {Source: "image", Mode: "sometimes"}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with user data that isn't base64 encoded", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{HostContainers: &v1.BottlerocketHostContainers{Admin: &v1.BottlerocketHostContainer{UserData: lo.ToPtr("not base64!")}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an empty bootstrap command", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{BootstrapCommands: map[string]v1.BottlerocketBootstrapCommand{"hello": {Commands: [][]string{{}}}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an invalid sysctl key", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{Kernel: &v1.BottlerocketKernel{Sysctl: map[string]string{"vm max_map_count": "1"}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with a mirror endpoint that isn't a URL", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{ContainerRegistry: &v1.BottlerocketContainerRegistry{Mirrors: []v1.BottlerocketRegistryMirror{{Registry: "docker.io", Endpoints: []string{"mirror.example.com"}}}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with duplicate mirror registries", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{ContainerRegistry: &v1.BottlerocketContainerRegistry{Mirrors: []v1.BottlerocketRegistryMirror{
+				{Registry: "docker.io", Endpoints: []string{"https://a.example.com"}},
+				{Registry: "docker.io", Endpoints: []string{"https://b.example.com"}},
+			}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with a capability that has the cap prefix", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{OCIDefaults: &v1.BottlerocketOCIDefaults{Capabilities: map[string]bool{"CAP_SYS_ADMIN": true}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with an unknown resource limit", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{OCIDefaults: &v1.BottlerocketOCIDefaults{ResourceLimits: map[string]v1.BottlerocketResourceLimit{"max-widgets": {HardLimit: 1, SoftLimit: 1}}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail with a soft limit greater than the hard limit", func() {
+			nc.Spec.Bottlerocket = &v1.BottlerocketConfiguration{OCIDefaults: &v1.BottlerocketOCIDefaults{ResourceLimits: map[string]v1.BottlerocketResourceLimit{"max-open-files": {HardLimit: 1024, SoftLimit: -1}}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+	})
+	Context("NodeConfig", func() {
+		BeforeEach(func() {
+			nc.Spec.AMIFamily = nil
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "al2023@latest"}}
+		})
+		It("should succeed with a valid configuration", func() {
+			nc.Spec.NodeConfig = &v1.NodeConfigOverlay{
+				Containerd: &v1.NodeConfigContainerd{Config: lo.ToPtr("version = 2\n")},
+				Instance:   &v1.NodeConfigInstance{LocalStorage: &v1.NodeConfigLocalStorage{Strategy: v1.NodeConfigLocalStorageStrategyRAID0}},
+				Kubelet:    &v1.NodeConfigKubelet{Flags: []string{"--v=4", "--node-ip=10.0.0.1"}},
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should succeed with the AL2023 amiFamily", func() {
+			nc.Spec.AMIFamily = lo.ToPtr(v1.AMIFamilyAL2023)
+			nc.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{ID: "ami-01234567890123456"}}
+			nc.Spec.NodeConfig = &v1.NodeConfigOverlay{Kubelet: &v1.NodeConfigKubelet{Flags: []string{"--v=4"}}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		DescribeTable(
+			"should fail with other AMI families",
+			func(family string, terms []v1.AMISelectorTerm) {
+				nc.Spec.AMIFamily = lo.EmptyableToPtr(family)
+				nc.Spec.AMISelectorTerms = terms
+				nc.Spec.NodeConfig = &v1.NodeConfigOverlay{Kubelet: &v1.NodeConfigKubelet{Flags: []string{"--v=4"}}}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			},
+			Entry("Bottlerocket alias", "", []v1.AMISelectorTerm{{Alias: "bottlerocket@latest"}}),
+			Entry("Custom with an AL2023 alias", v1.AMIFamilyCustom, []v1.AMISelectorTerm{{Alias: "al2023@latest"}}),
+			Entry("Custom", v1.AMIFamilyCustom, []v1.AMISelectorTerm{{ID: "ami-01234567890123456"}}),
+		)
+		It("should fail when localStorage is set with instanceStorePolicy", func() {
+			nc.Spec.InstanceStorePolicy = lo.ToPtr(v1.InstanceStorePolicyRAID0)
+			nc.Spec.NodeConfig = &v1.NodeConfigOverlay{Instance: &v1.NodeConfigInstance{LocalStorage: &v1.NodeConfigLocalStorage{Strategy: v1.NodeConfigLocalStorageStrategyMount}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		DescribeTable(
+			"should fail with invalid kubelet flags",
+			func(flag string) {
+				nc.Spec.NodeConfig = &v1.NodeConfigOverlay{Kubelet: &v1.NodeConfigKubelet{Flags: []string{flag}}}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			},
+			Entry("without a prefix", "v=4"),
+			Entry("node labels", "--node-labels=foo=bar"),
+			Entry("taints", "--register-with-taints=foo=bar:NoSchedule"),
 		)
+		It("should fail with an invalid localStorage strategy", func() {
+			nc.Spec.NodeConfig = &v1.NodeConfigOverlay{Instance: &v1.NodeConfigInstance{LocalStorage: &v1.NodeConfigLocalStorage{Strategy: "RAID10"}}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
 	})
 	Context("Kubelet", func() {
 		It("should fail on kubeReserved with invalid keys", func() {
@@ -942,6 +1484,78 @@ var _ = Describe("CEL/Validation", func() {
 				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
 			})
 		})
+		Context("Shutdown Grace Period", func() {
+			It("should succeed when shutdownGracePeriodCriticalPods is less than shutdownGracePeriod", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					ShutdownGracePeriod:             &metav1.Duration{Duration: time.Minute},
+					ShutdownGracePeriodCriticalPods: &metav1.Duration{Duration: time.Second * 30},
+				}
+				Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			})
+			It("should fail when shutdownGracePeriodCriticalPods is specified without shutdownGracePeriod", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					ShutdownGracePeriodCriticalPods: &metav1.Duration{Duration: time.Second * 30},
+				}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			})
+			It("should fail when shutdownGracePeriodCriticalPods is greater than shutdownGracePeriod", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					ShutdownGracePeriod:             &metav1.Duration{Duration: time.Second * 30},
+					ShutdownGracePeriodCriticalPods: &metav1.Duration{Duration: time.Minute},
+				}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			})
+		})
+		Context("Resource Managers", func() {
+			It("should succeed on valid cpu and topology manager settings", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					CPUManagerPolicy:          lo.ToPtr("static"),
+					CPUManagerReconcilePeriod: &metav1.Duration{Duration: time.Second * 10},
+					TopologyManagerPolicy:     lo.ToPtr("single-numa-node"),
+					TopologyManagerScope:      lo.ToPtr("pod"),
+				}
+				Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			})
+			It("should fail on an invalid cpuManagerPolicy", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					CPUManagerPolicy: lo.ToPtr("dynamic"),
+				}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			})
+			It("should fail on an invalid topologyManagerPolicy", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					TopologyManagerPolicy: lo.ToPtr("strict"),
+				}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			})
+			It("should fail on an invalid topologyManagerScope", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					TopologyManagerScope: lo.ToPtr("node"),
+				}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			})
+		})
+		Context("Container Logs", func() {
+			It("should succeed on valid container log rotation settings", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					ContainerLogMaxSize:  lo.ToPtr("50Mi"),
+					ContainerLogMaxFiles: lo.ToPtr(int32(5)),
+				}
+				Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			})
+			It("should fail on an invalid containerLogMaxSize", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					ContainerLogMaxSize: lo.ToPtr("50MB"),
+				}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			})
+			It("should fail when containerLogMaxFiles is less than 2", func() {
+				nc.Spec.Kubelet = &v1.KubeletConfiguration{
+					ContainerLogMaxFiles: lo.ToPtr(int32(1)),
+				}
+				Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+			})
+		})
 	})
 	Context("MetadataOptions", func() {
 		It("should succeed for valid inputs", func() {
@@ -979,6 +1593,49 @@ var _ = Describe("CEL/Validation", func() {
 		})
 	})
 	Context("BlockDeviceMappings", func() {
+		It("should succeed with a volume size expression", func() {
+			nc.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{{
+				DeviceName: aws.String("/dev/xvda"),
+				EBS: &v1.BlockDevice{
+					VolumeSizeExpression: &v1.VolumeSizeExpression{
+						Base:         lo.ToPtr(resource.MustParse("20Gi")),
+						PerVCPU:      lo.ToPtr(resource.MustParse("5Gi")),
+						PerGiBMemory: lo.ToPtr(resource.MustParse("512Mi")),
+						Min:          lo.ToPtr(resource.MustParse("50Gi")),
+						Max:          lo.ToPtr(resource.MustParse("1Ti")),
+					},
+				},
+			}}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should fail if volumeSize and volumeSizeExpression are both specified", func() {
+			nc.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{{
+				DeviceName: aws.String("/dev/xvda"),
+				EBS: &v1.BlockDevice{
+					VolumeSize:           lo.ToPtr(resource.MustParse("20Gi")),
+					VolumeSizeExpression: &v1.VolumeSizeExpression{PerVCPU: lo.ToPtr(resource.MustParse("5Gi"))},
+				},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail if the volume size expression doesn't define a size", func() {
+			nc.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{{
+				DeviceName: aws.String("/dev/xvda"),
+				EBS: &v1.BlockDevice{
+					VolumeSizeExpression: &v1.VolumeSizeExpression{Min: lo.ToPtr(resource.MustParse("50Gi"))},
+				},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		It("should fail if the volume size expression uses an invalid quantity", func() {
+			nc.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{{
+				DeviceName: aws.String("/dev/xvda"),
+				EBS: &v1.BlockDevice{
+					VolumeSizeExpression: &v1.VolumeSizeExpression{PerVCPU: lo.ToPtr(resource.MustParse("5Ki"))},
+				},
+			}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
 		It("should succeed if more than one root volume is specified", func() {
 			nodeClass := &v1.EC2NodeClass{
 				ObjectMeta: test.ObjectMeta(metav1.ObjectMeta{}),
@@ -1211,6 +1868,50 @@ var _ = Describe("CEL/Validation", func() {
 			Expect(env.Client.Create(ctx, nodeClass)).To(Not(Succeed()))
 		})
 	})
+	Context("InstanceProfileOptions", func() {
+		It("should succeed with a path, tags and an adoption policy", func() {
+			nc.Spec.InstanceProfileOptions = &v1.InstanceProfileOptions{
+				Path:           lo.ToPtr("/karpenter/nodes/"),
+				Tags:           map[string]string{"team": "platform"},
+				AdoptionPolicy: lo.ToPtr(v1.InstanceProfileAdoptionPolicyIfOwned),
+			}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+		})
+		It("should default the adoption policy to Always", func() {
+			nc.Spec.InstanceProfileOptions = &v1.InstanceProfileOptions{}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			Expect(lo.FromPtr(nc.Spec.InstanceProfileOptions.AdoptionPolicy)).To(Equal(v1.InstanceProfileAdoptionPolicyAlways))
+		})
+		It("should fail when set with an instance profile", func() {
+			nc.Spec.Role = ""
+			nc.Spec.InstanceProfile = lo.ToPtr("test-instance-profile")
+			nc.Spec.InstanceProfileOptions = &v1.InstanceProfileOptions{Path: lo.ToPtr("/karpenter/")}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		})
+		DescribeTable("should fail with an invalid path", func(path string) {
+			nc.Spec.InstanceProfileOptions = &v1.InstanceProfileOptions{Path: lo.ToPtr(path)}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		},
+			Entry("without a leading slash", "karpenter/"),
+			Entry("without a trailing slash", "/karpenter"),
+			Entry("with a space", "/kar penter/"),
+		)
+		It("should fail when updating the path", func() {
+			nc.Spec.InstanceProfileOptions = &v1.InstanceProfileOptions{Path: lo.ToPtr("/karpenter/")}
+			Expect(env.Client.Create(ctx, nc)).To(Succeed())
+			nc.Spec.InstanceProfileOptions.Path = lo.ToPtr("/other/")
+			Expect(env.Client.Update(ctx, nc)).ToNot(Succeed())
+		})
+		DescribeTable("should fail with restricted tags", func(key string) {
+			nc.Spec.InstanceProfileOptions = &v1.InstanceProfileOptions{Tags: map[string]string{key: "value"}}
+			Expect(env.Client.Create(ctx, nc)).ToNot(Succeed())
+		},
+			Entry("eks:eks-cluster-name", "eks:eks-cluster-name"),
+			Entry("kubernetes.io/cluster", "kubernetes.io/cluster/test"),
+			Entry("karpenter.k8s.aws/ec2nodeclass", v1.LabelNodeClass),
+			Entry("topology.kubernetes.io/region", "topology.kubernetes.io/region"),
+		)
+	})
 	Context("Role Immutability", func() {
 		It("should fail if role is not defined", func() {
 			nc.Spec.Role = ""
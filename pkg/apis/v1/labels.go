@@ -50,6 +50,10 @@ func init() {
 		LabelInstanceAcceleratorName,
 		LabelInstanceAcceleratorManufacturer,
 		LabelInstanceAcceleratorCount,
+		LabelInstanceTenancy,
+		LabelInstanceEnclaveSupported,
+		LabelInstanceHibernationSupported,
+		LabelPlacementGroupPartition,
 		LabelTopologyZoneID,
 		corev1.LabelWindowsBuild,
 	)
@@ -77,6 +81,7 @@ var (
 		ResourceAWSNeuronCore,
 		ResourceHabanaGaudi,
 		ResourceEFA,
+		ResourceAWSNitroEnclaves,
 	)
 	WellKnownExoticResources = sets.New[corev1.ResourceName](
 		ResourceNVIDIAGPU,
@@ -96,6 +101,7 @@ var (
 		regexp.MustCompile(fmt.Sprintf("^%s$", regexp.QuoteMeta(EKSClusterNameTagKey))),
 		regexp.MustCompile(fmt.Sprintf("^%s$", regexp.QuoteMeta(NodeClassTagKey))),
 		regexp.MustCompile(fmt.Sprintf("^%s$", regexp.QuoteMeta(NodeClaimTagKey))),
+		regexp.MustCompile(fmt.Sprintf("^%s$", regexp.QuoteMeta(WarmPoolTagKey))),
 	}
 	AMIFamilyBottlerocket                          = "Bottlerocket"
 	AMIFamilyAL2                                   = "AL2"
@@ -103,12 +109,15 @@ var (
 	AMIFamilyUbuntu                                = "Ubuntu"
 	AMIFamilyWindows2019                           = "Windows2019"
 	AMIFamilyWindows2022                           = "Windows2022"
+	AMIFamilyWindows2025                           = "Windows2025"
 	AMIFamilyCustom                                = "Custom"
 	Windows2019                                    = "2019"
 	Windows2022                                    = "2022"
+	Windows2025                                    = "2025"
 	WindowsCore                                    = "Core"
 	Windows2019Build                               = "10.0.17763"
 	Windows2022Build                               = "10.0.20348"
+	Windows2025Build                               = "10.0.26100"
 	ResourceNVIDIAGPU          corev1.ResourceName = "nvidia.com/gpu"
 	ResourceAMDGPU             corev1.ResourceName = "amd.com/gpu"
 	ResourceAWSNeuron          corev1.ResourceName = "aws.amazon.com/neuron"
@@ -117,6 +126,7 @@ var (
 	ResourceAWSPodENI          corev1.ResourceName = "vpc.amazonaws.com/pod-eni"
 	ResourcePrivateIPv4Address corev1.ResourceName = "vpc.amazonaws.com/PrivateIPv4Address"
 	ResourceEFA                corev1.ResourceName = "vpc.amazonaws.com/efa"
+	ResourceAWSNitroEnclaves   corev1.ResourceName = "aws.amazon.com/nitro-enclaves"
 
 	LabelCapacityReservationID                = apis.Group + "/capacity-reservation-id"
 	LabelInstanceHypervisor                   = apis.Group + "/instance-hypervisor"
@@ -139,7 +149,12 @@ var (
 	LabelInstanceAcceleratorName              = apis.Group + "/instance-accelerator-name"
 	LabelInstanceAcceleratorManufacturer      = apis.Group + "/instance-accelerator-manufacturer"
 	LabelInstanceAcceleratorCount             = apis.Group + "/instance-accelerator-count"
+	LabelInstanceTenancy                      = apis.Group + "/instance-tenancy"
+	LabelInstanceEnclaveSupported             = apis.Group + "/instance-enclave-supported"
+	LabelInstanceHibernationSupported         = apis.Group + "/instance-hibernation-supported"
 	LabelNodeClass                            = apis.Group + "/ec2nodeclass"
+	// LabelPlacementGroupPartition is the partition number of instances launched into a partition placement group
+	LabelPlacementGroupPartition = apis.Group + "/placement-group-partition"
 
 	LabelTopologyZoneID = "topology.k8s.aws/zone-id"
 
@@ -154,4 +169,17 @@ var (
 	NodeClassTagKey          = LabelNodeClass
 	LaunchTemplateNamePrefix = apis.Group
 	EKSClusterNameTagKey     = "eks:eks-cluster-name"
+	// WarmPoolTagKey is set to the name of the NodePool on instances in its warm pool. It's removed once the instance is
+	// started for a NodeClaim.
+	WarmPoolTagKey = apis.Group + "/warm-pool"
+	// WarmPoolNoScheduleTaint is set on the Nodes of hibernated warm pool members, which are kept while the member is
+	// hibernated since the kubelet resumes with them. It's removed once the member is claimed by a NodeClaim.
+	WarmPoolNoScheduleTaint = corev1.Taint{
+		Key:    WarmPoolTagKey,
+		Effect: corev1.TaintEffectNoSchedule,
+	}
+	// AMISourceIDTagKey and AMISourceRegionTagKey are set on AMIs that are copied for an AMISelectorTerm's replication,
+	// to the ID and region of the AMI they were copied from
+	AMISourceIDTagKey     = apis.Group + "/ami-source-id"
+	AMISourceRegionTagKey = apis.Group + "/ami-source-region"
 )
@@ -35,6 +35,14 @@ func (in *AMI) DeepCopyInto(out *AMI) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RolloutStartTime != nil {
+		in, out := &in.RolloutStartTime, &out.RolloutStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RolloutFailuresObservedTime != nil {
+		in, out := &in.RolloutFailuresObservedTime, &out.RolloutFailuresObservedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMI.
@@ -47,6 +55,115 @@ func (in *AMI) DeepCopy() *AMI {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AMIDeprecation) DeepCopyInto(out *AMIDeprecation) {
+	*out = *in
+	if in.EarliestDeprecationTime != nil {
+		in, out := &in.EarliestDeprecationTime, &out.EarliestDeprecationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMIDeprecation.
+func (in *AMIDeprecation) DeepCopy() *AMIDeprecation {
+	if in == nil {
+		return nil
+	}
+	out := new(AMIDeprecation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AMIHealth) DeepCopyInto(out *AMIHealth) {
+	*out = *in
+	if in.KnownGood != nil {
+		in, out := &in.KnownGood, &out.KnownGood
+		*out = make([]AMI, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Excluded != nil {
+		in, out := &in.Excluded, &out.Excluded
+		*out = make([]ExcludedAMI, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMIHealth.
+func (in *AMIHealth) DeepCopy() *AMIHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(AMIHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AMIHealthPolicy) DeepCopyInto(out *AMIHealthPolicy) {
+	*out = *in
+	if in.MinFailures != nil {
+		in, out := &in.MinFailures, &out.MinFailures
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxFailurePercentage != nil {
+		in, out := &in.MaxFailurePercentage, &out.MaxFailurePercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMIHealthPolicy.
+func (in *AMIHealthPolicy) DeepCopy() *AMIHealthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AMIHealthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AMIReplication) DeepCopyInto(out *AMIReplication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMIReplication.
+func (in *AMIReplication) DeepCopy() *AMIReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(AMIReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AMIRolloutPolicy) DeepCopyInto(out *AMIRolloutPolicy) {
+	*out = *in
+	out.BakeTime = in.BakeTime
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMIRolloutPolicy.
+func (in *AMIRolloutPolicy) DeepCopy() *AMIRolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AMIRolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AMISelectorTerm) DeepCopyInto(out *AMISelectorTerm) {
 	*out = *in
@@ -57,6 +174,28 @@ func (in *AMISelectorTerm) DeepCopyInto(out *AMISelectorTerm) {
 			(*out)[key] = val
 		}
 	}
+	if in.MinAge != nil {
+		in, out := &in.MinAge, &out.MinAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(AMIReplication)
+		**out = **in
+	}
+	if in.Requirements != nil {
+		in, out := &in.Requirements, &out.Requirements
+		*out = make([]corev1.NodeSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMISelectorTerm.
@@ -127,6 +266,11 @@ func (in *BlockDevice) DeepCopyInto(out *BlockDevice) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.VolumeSizeExpression != nil {
+		in, out := &in.VolumeSizeExpression, &out.VolumeSizeExpression
+		*out = new(VolumeSizeExpression)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VolumeType != nil {
 		in, out := &in.VolumeType, &out.VolumeType
 		*out = new(string)
@@ -170,179 +314,571 @@ func (in *BlockDeviceMapping) DeepCopy() *BlockDeviceMapping {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CapacityReservation) DeepCopyInto(out *CapacityReservation) {
+func (in *BottlerocketBootstrapCommand) DeepCopyInto(out *BottlerocketBootstrapCommand) {
 	*out = *in
-	if in.EndTime != nil {
-		in, out := &in.EndTime, &out.EndTime
-		*out = (*in).DeepCopy()
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([][]string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.Essential != nil {
+		in, out := &in.Essential, &out.Essential
+		*out = new(bool)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservation.
-func (in *CapacityReservation) DeepCopy() *CapacityReservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketBootstrapCommand.
+func (in *BottlerocketBootstrapCommand) DeepCopy() *BottlerocketBootstrapCommand {
 	if in == nil {
 		return nil
 	}
-	out := new(CapacityReservation)
+	out := new(BottlerocketBootstrapCommand)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CapacityReservationSelectorTerm) DeepCopyInto(out *CapacityReservationSelectorTerm) {
+func (in *BottlerocketBootstrapContainer) DeepCopyInto(out *BottlerocketBootstrapContainer) {
 	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Essential != nil {
+		in, out := &in.Essential, &out.Essential
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UserData != nil {
+		in, out := &in.UserData, &out.UserData
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservationSelectorTerm.
-func (in *CapacityReservationSelectorTerm) DeepCopy() *CapacityReservationSelectorTerm {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketBootstrapContainer.
+func (in *BottlerocketBootstrapContainer) DeepCopy() *BottlerocketBootstrapContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(CapacityReservationSelectorTerm)
+	out := new(BottlerocketBootstrapContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EC2NodeClass) DeepCopyInto(out *EC2NodeClass) {
+func (in *BottlerocketConfiguration) DeepCopyInto(out *BottlerocketConfiguration) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.BootstrapContainers != nil {
+		in, out := &in.BootstrapContainers, &out.BootstrapContainers
+		*out = make(map[string]BottlerocketBootstrapContainer, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.BootstrapCommands != nil {
+		in, out := &in.BootstrapCommands, &out.BootstrapCommands
+		*out = make(map[string]BottlerocketBootstrapCommand, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.HostContainers != nil {
+		in, out := &in.HostContainers, &out.HostContainers
+		*out = new(BottlerocketHostContainers)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kernel != nil {
+		in, out := &in.Kernel, &out.Kernel
+		*out = new(BottlerocketKernel)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerRegistry != nil {
+		in, out := &in.ContainerRegistry, &out.ContainerRegistry
+		*out = new(BottlerocketContainerRegistry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NTP != nil {
+		in, out := &in.NTP, &out.NTP
+		*out = new(BottlerocketNTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCIDefaults != nil {
+		in, out := &in.OCIDefaults, &out.OCIDefaults
+		*out = new(BottlerocketOCIDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EC2NodeClass.
-func (in *EC2NodeClass) DeepCopy() *EC2NodeClass {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketConfiguration.
+func (in *BottlerocketConfiguration) DeepCopy() *BottlerocketConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(EC2NodeClass)
+	out := new(BottlerocketConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EC2NodeClass) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EC2NodeClassList) DeepCopyInto(out *EC2NodeClassList) {
+func (in *BottlerocketContainerRegistry) DeepCopyInto(out *BottlerocketContainerRegistry) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]EC2NodeClass, len(*in))
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]BottlerocketRegistryMirror, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EC2NodeClassList.
-func (in *EC2NodeClassList) DeepCopy() *EC2NodeClassList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketContainerRegistry.
+func (in *BottlerocketContainerRegistry) DeepCopy() *BottlerocketContainerRegistry {
 	if in == nil {
 		return nil
 	}
-	out := new(EC2NodeClassList)
+	out := new(BottlerocketContainerRegistry)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EC2NodeClassList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EC2NodeClassSpec) DeepCopyInto(out *EC2NodeClassSpec) {
+func (in *BottlerocketHostContainer) DeepCopyInto(out *BottlerocketHostContainer) {
 	*out = *in
-	if in.SubnetSelectorTerms != nil {
-		in, out := &in.SubnetSelectorTerms, &out.SubnetSelectorTerms
-		*out = make([]SubnetSelectorTerm, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.SecurityGroupSelectorTerms != nil {
-		in, out := &in.SecurityGroupSelectorTerms, &out.SecurityGroupSelectorTerms
-		*out = make([]SecurityGroupSelectorTerm, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.CapacityReservationSelectorTerms != nil {
-		in, out := &in.CapacityReservationSelectorTerms, &out.CapacityReservationSelectorTerms
-		*out = make([]CapacityReservationSelectorTerm, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AssociatePublicIPAddress != nil {
-		in, out := &in.AssociatePublicIPAddress, &out.AssociatePublicIPAddress
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.AMISelectorTerms != nil {
-		in, out := &in.AMISelectorTerms, &out.AMISelectorTerms
-		*out = make([]AMISelectorTerm, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AMIFamily != nil {
-		in, out := &in.AMIFamily, &out.AMIFamily
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
 		*out = new(string)
 		**out = **in
 	}
+	if in.Superpowered != nil {
+		in, out := &in.Superpowered, &out.Superpowered
+		*out = new(bool)
+		**out = **in
+	}
 	if in.UserData != nil {
 		in, out := &in.UserData, &out.UserData
 		*out = new(string)
 		**out = **in
 	}
-	if in.InstanceProfile != nil {
-		in, out := &in.InstanceProfile, &out.InstanceProfile
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketHostContainer.
+func (in *BottlerocketHostContainer) DeepCopy() *BottlerocketHostContainer {
+	if in == nil {
+		return nil
 	}
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
+	out := new(BottlerocketHostContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketHostContainers) DeepCopyInto(out *BottlerocketHostContainers) {
+	*out = *in
+	if in.Admin != nil {
+		in, out := &in.Admin, &out.Admin
+		*out = new(BottlerocketHostContainer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Control != nil {
+		in, out := &in.Control, &out.Control
+		*out = new(BottlerocketHostContainer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketHostContainers.
+func (in *BottlerocketHostContainers) DeepCopy() *BottlerocketHostContainers {
+	if in == nil {
+		return nil
+	}
+	out := new(BottlerocketHostContainers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketKernel) DeepCopyInto(out *BottlerocketKernel) {
+	*out = *in
+	if in.Sysctl != nil {
+		in, out := &in.Sysctl, &out.Sysctl
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.Kubelet != nil {
-		in, out := &in.Kubelet, &out.Kubelet
-		*out = new(KubeletConfiguration)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketKernel.
+func (in *BottlerocketKernel) DeepCopy() *BottlerocketKernel {
+	if in == nil {
+		return nil
 	}
-	if in.BlockDeviceMappings != nil {
-		in, out := &in.BlockDeviceMappings, &out.BlockDeviceMappings
-		*out = make([]*BlockDeviceMapping, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
+	out := new(BottlerocketKernel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketNTP) DeepCopyInto(out *BottlerocketNTP) {
+	*out = *in
+	if in.TimeServers != nil {
+		in, out := &in.TimeServers, &out.TimeServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketNTP.
+func (in *BottlerocketNTP) DeepCopy() *BottlerocketNTP {
+	if in == nil {
+		return nil
+	}
+	out := new(BottlerocketNTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketOCIDefaults) DeepCopyInto(out *BottlerocketOCIDefaults) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = make(map[string]BottlerocketResourceLimit, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketOCIDefaults.
+func (in *BottlerocketOCIDefaults) DeepCopy() *BottlerocketOCIDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(BottlerocketOCIDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketRegistryMirror) DeepCopyInto(out *BottlerocketRegistryMirror) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketRegistryMirror.
+func (in *BottlerocketRegistryMirror) DeepCopy() *BottlerocketRegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(BottlerocketRegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketResourceLimit) DeepCopyInto(out *BottlerocketResourceLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketResourceLimit.
+func (in *BottlerocketResourceLimit) DeepCopy() *BottlerocketResourceLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(BottlerocketResourceLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUOptions) DeepCopyInto(out *CPUOptions) {
+	*out = *in
+	if in.CoreCount != nil {
+		in, out := &in.CoreCount, &out.CoreCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ThreadsPerCore != nil {
+		in, out := &in.ThreadsPerCore, &out.ThreadsPerCore
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AMDSEVSNP != nil {
+		in, out := &in.AMDSEVSNP, &out.AMDSEVSNP
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUOptions.
+func (in *CPUOptions) DeepCopy() *CPUOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityReservation) DeepCopyInto(out *CapacityReservation) {
+	*out = *in
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservation.
+func (in *CapacityReservation) DeepCopy() *CapacityReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityReservationSelectorTerm) DeepCopyInto(out *CapacityReservationSelectorTerm) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservationSelectorTerm.
+func (in *CapacityReservationSelectorTerm) DeepCopy() *CapacityReservationSelectorTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityReservationSelectorTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EC2NodeClass) DeepCopyInto(out *EC2NodeClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EC2NodeClass.
+func (in *EC2NodeClass) DeepCopy() *EC2NodeClass {
+	if in == nil {
+		return nil
+	}
+	out := new(EC2NodeClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EC2NodeClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EC2NodeClassList) DeepCopyInto(out *EC2NodeClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EC2NodeClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EC2NodeClassList.
+func (in *EC2NodeClassList) DeepCopy() *EC2NodeClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(EC2NodeClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EC2NodeClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EC2NodeClassSpec) DeepCopyInto(out *EC2NodeClassSpec) {
+	*out = *in
+	if in.SubnetSelectorTerms != nil {
+		in, out := &in.SubnetSelectorTerms, &out.SubnetSelectorTerms
+		*out = make([]SubnetSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityGroupSelectorTerms != nil {
+		in, out := &in.SecurityGroupSelectorTerms, &out.SecurityGroupSelectorTerms
+		*out = make([]SecurityGroupSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CapacityReservationSelectorTerms != nil {
+		in, out := &in.CapacityReservationSelectorTerms, &out.CapacityReservationSelectorTerms
+		*out = make([]CapacityReservationSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AssociatePublicIPAddress != nil {
+		in, out := &in.AssociatePublicIPAddress, &out.AssociatePublicIPAddress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = make([]NetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlacementGroup != nil {
+		in, out := &in.PlacementGroup, &out.PlacementGroup
+		*out = new(PlacementGroupSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tenancy != nil {
+		in, out := &in.Tenancy, &out.Tenancy
+		*out = new(Tenancy)
+		**out = **in
+	}
+	if in.HostSelector != nil {
+		in, out := &in.HostSelector, &out.HostSelector
+		*out = new(HostSelector)
+		**out = **in
+	}
+	if in.AMISelectorTerms != nil {
+		in, out := &in.AMISelectorTerms, &out.AMISelectorTerms
+		*out = make([]AMISelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AMIFamily != nil {
+		in, out := &in.AMIFamily, &out.AMIFamily
+		*out = new(string)
+		**out = **in
+	}
+	if in.AMIHealthPolicy != nil {
+		in, out := &in.AMIHealthPolicy, &out.AMIHealthPolicy
+		*out = new(AMIHealthPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AMIRolloutPolicy != nil {
+		in, out := &in.AMIRolloutPolicy, &out.AMIRolloutPolicy
+		*out = new(AMIRolloutPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserData != nil {
+		in, out := &in.UserData, &out.UserData
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceProfile != nil {
+		in, out := &in.InstanceProfile, &out.InstanceProfile
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceProfileOptions != nil {
+		in, out := &in.InstanceProfileOptions, &out.InstanceProfileOptions
+		*out = new(InstanceProfileOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VolumeTags != nil {
+		in, out := &in.VolumeTags, &out.VolumeTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Kubelet != nil {
+		in, out := &in.Kubelet, &out.Kubelet
+		*out = new(KubeletConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bottlerocket != nil {
+		in, out := &in.Bottlerocket, &out.Bottlerocket
+		*out = new(BottlerocketConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeConfig != nil {
+		in, out := &in.NodeConfig, &out.NodeConfig
+		*out = new(NodeConfigOverlay)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlockDeviceMappings != nil {
+		in, out := &in.BlockDeviceMappings, &out.BlockDeviceMappings
+		*out = make([]*BlockDeviceMapping, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
 				*out = new(BlockDeviceMapping)
 				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
+	if in.VolumeEncryptionPolicy != nil {
+		in, out := &in.VolumeEncryptionPolicy, &out.VolumeEncryptionPolicy
+		*out = new(VolumeEncryptionPolicy)
+		**out = **in
+	}
 	if in.InstanceStorePolicy != nil {
 		in, out := &in.InstanceStorePolicy, &out.InstanceStorePolicy
 		*out = new(InstanceStorePolicy)
@@ -353,6 +889,26 @@ func (in *EC2NodeClassSpec) DeepCopyInto(out *EC2NodeClassSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CPUOptions != nil {
+		in, out := &in.CPUOptions, &out.CPUOptions
+		*out = new(CPUOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnclaveOptions != nil {
+		in, out := &in.EnclaveOptions, &out.EnclaveOptions
+		*out = new(EnclaveOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HibernationOptions != nil {
+		in, out := &in.HibernationOptions, &out.HibernationOptions
+		*out = new(HibernationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmPool != nil {
+		in, out := &in.WarmPool, &out.WarmPool
+		*out = new(WarmPool)
+		**out = **in
+	}
 	if in.MetadataOptions != nil {
 		in, out := &in.MetadataOptions, &out.MetadataOptions
 		*out = new(MetadataOptions)
@@ -388,6 +944,13 @@ func (in *EC2NodeClassStatus) DeepCopyInto(out *EC2NodeClassStatus) {
 		*out = make([]SecurityGroup, len(*in))
 		copy(*out, *in)
 	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = make([]NetworkInterfaceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.CapacityReservations != nil {
 		in, out := &in.CapacityReservations, &out.CapacityReservations
 		*out = make([]CapacityReservation, len(*in))
@@ -395,28 +958,176 @@ func (in *EC2NodeClassStatus) DeepCopyInto(out *EC2NodeClassStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.AMIs != nil {
-		in, out := &in.AMIs, &out.AMIs
-		*out = make([]AMI, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.AMIs != nil {
+		in, out := &in.AMIs, &out.AMIs
+		*out = make([]AMI, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AMIDeprecation != nil {
+		in, out := &in.AMIDeprecation, &out.AMIDeprecation
+		*out = new(AMIDeprecation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AMIHealth != nil {
+		in, out := &in.AMIHealth, &out.AMIHealth
+		*out = new(AMIHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AMICopies != nil {
+		in, out := &in.AMICopies, &out.AMICopies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlacementGroup != nil {
+		in, out := &in.PlacementGroup, &out.PlacementGroup
+		*out = new(PlacementGroup)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]status.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EC2NodeClassStatus.
+func (in *EC2NodeClassStatus) DeepCopy() *EC2NodeClassStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EC2NodeClassStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ENASrdSpecification) DeepCopyInto(out *ENASrdSpecification) {
+	*out = *in
+	if in.ENASrdEnabled != nil {
+		in, out := &in.ENASrdEnabled, &out.ENASrdEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ENASrdUDPEnabled != nil {
+		in, out := &in.ENASrdUDPEnabled, &out.ENASrdUDPEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ENASrdSpecification.
+func (in *ENASrdSpecification) DeepCopy() *ENASrdSpecification {
+	if in == nil {
+		return nil
+	}
+	out := new(ENASrdSpecification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnclaveOptions) DeepCopyInto(out *EnclaveOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnclaveOptions.
+func (in *EnclaveOptions) DeepCopy() *EnclaveOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EnclaveOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedAMI) DeepCopyInto(out *ExcludedAMI) {
+	*out = *in
+	in.ExclusionTime.DeepCopyInto(&out.ExclusionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedAMI.
+func (in *ExcludedAMI) DeepCopy() *ExcludedAMI {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedAMI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationOptions) DeepCopyInto(out *HibernationOptions) {
+	*out = *in
+	if in.Configured != nil {
+		in, out := &in.Configured, &out.Configured
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationOptions.
+func (in *HibernationOptions) DeepCopy() *HibernationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSelector) DeepCopyInto(out *HostSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSelector.
+func (in *HostSelector) DeepCopy() *HostSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceProfileOptions) DeepCopyInto(out *InstanceProfileOptions) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]status.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.AdoptionPolicy != nil {
+		in, out := &in.AdoptionPolicy, &out.AdoptionPolicy
+		*out = new(InstanceProfileAdoptionPolicy)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EC2NodeClassStatus.
-func (in *EC2NodeClassStatus) DeepCopy() *EC2NodeClassStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceProfileOptions.
+func (in *InstanceProfileOptions) DeepCopy() *InstanceProfileOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(EC2NodeClassStatus)
+	out := new(InstanceProfileOptions)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -494,6 +1205,66 @@ func (in *KubeletConfiguration) DeepCopyInto(out *KubeletConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CPUManagerPolicy != nil {
+		in, out := &in.CPUManagerPolicy, &out.CPUManagerPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.CPUManagerReconcilePeriod != nil {
+		in, out := &in.CPUManagerReconcilePeriod, &out.CPUManagerReconcilePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TopologyManagerPolicy != nil {
+		in, out := &in.TopologyManagerPolicy, &out.TopologyManagerPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.TopologyManagerScope != nil {
+		in, out := &in.TopologyManagerScope, &out.TopologyManagerScope
+		*out = new(string)
+		**out = **in
+	}
+	if in.ShutdownGracePeriod != nil {
+		in, out := &in.ShutdownGracePeriod, &out.ShutdownGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ShutdownGracePeriodCriticalPods != nil {
+		in, out := &in.ShutdownGracePeriodCriticalPods, &out.ShutdownGracePeriodCriticalPods
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RegistryPullQPS != nil {
+		in, out := &in.RegistryPullQPS, &out.RegistryPullQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RegistryBurst != nil {
+		in, out := &in.RegistryBurst, &out.RegistryBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContainerLogMaxSize != nil {
+		in, out := &in.ContainerLogMaxSize, &out.ContainerLogMaxSize
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContainerLogMaxFiles != nil {
+		in, out := &in.ContainerLogMaxFiles, &out.ContainerLogMaxFiles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SeccompDefault != nil {
+		in, out := &in.SeccompDefault, &out.SeccompDefault
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodPidsLimit != nil {
+		in, out := &in.PodPidsLimit, &out.PodPidsLimit
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfiguration.
@@ -541,6 +1312,217 @@ func (in *MetadataOptions) DeepCopy() *MetadataOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
+	*out = *in
+	if in.InterfaceType != nil {
+		in, out := &in.InterfaceType, &out.InterfaceType
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubnetSelectorTerms != nil {
+		in, out := &in.SubnetSelectorTerms, &out.SubnetSelectorTerms
+		*out = make([]SubnetSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityGroupSelectorTerms != nil {
+		in, out := &in.SecurityGroupSelectorTerms, &out.SecurityGroupSelectorTerms
+		*out = make([]SecurityGroupSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ENASrdSpecification != nil {
+		in, out := &in.ENASrdSpecification, &out.ENASrdSpecification
+		*out = new(ENASrdSpecification)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterface.
+func (in *NetworkInterface) DeepCopy() *NetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterfaceStatus) DeepCopyInto(out *NetworkInterfaceStatus) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]Subnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]SecurityGroup, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceStatus.
+func (in *NetworkInterfaceStatus) DeepCopy() *NetworkInterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigContainerd) DeepCopyInto(out *NodeConfigContainerd) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigContainerd.
+func (in *NodeConfigContainerd) DeepCopy() *NodeConfigContainerd {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigContainerd)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigInstance) DeepCopyInto(out *NodeConfigInstance) {
+	*out = *in
+	if in.LocalStorage != nil {
+		in, out := &in.LocalStorage, &out.LocalStorage
+		*out = new(NodeConfigLocalStorage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigInstance.
+func (in *NodeConfigInstance) DeepCopy() *NodeConfigInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigKubelet) DeepCopyInto(out *NodeConfigKubelet) {
+	*out = *in
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigKubelet.
+func (in *NodeConfigKubelet) DeepCopy() *NodeConfigKubelet {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigKubelet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigLocalStorage) DeepCopyInto(out *NodeConfigLocalStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigLocalStorage.
+func (in *NodeConfigLocalStorage) DeepCopy() *NodeConfigLocalStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigLocalStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigOverlay) DeepCopyInto(out *NodeConfigOverlay) {
+	*out = *in
+	if in.Containerd != nil {
+		in, out := &in.Containerd, &out.Containerd
+		*out = new(NodeConfigContainerd)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Instance != nil {
+		in, out := &in.Instance, &out.Instance
+		*out = new(NodeConfigInstance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kubelet != nil {
+		in, out := &in.Kubelet, &out.Kubelet
+		*out = new(NodeConfigKubelet)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigOverlay.
+func (in *NodeConfigOverlay) DeepCopy() *NodeConfigOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementGroup) DeepCopyInto(out *PlacementGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementGroup.
+func (in *PlacementGroup) DeepCopy() *PlacementGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementGroupSelector) DeepCopyInto(out *PlacementGroupSelector) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(PlacementGroupStrategy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementGroupSelector.
+func (in *PlacementGroupSelector) DeepCopy() *PlacementGroupSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementGroupSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
@@ -614,3 +1596,73 @@ func (in *SubnetSelectorTerm) DeepCopy() *SubnetSelectorTerm {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeEncryptionPolicy) DeepCopyInto(out *VolumeEncryptionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeEncryptionPolicy.
+func (in *VolumeEncryptionPolicy) DeepCopy() *VolumeEncryptionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeEncryptionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSizeExpression) DeepCopyInto(out *VolumeSizeExpression) {
+	*out = *in
+	if in.Base != nil {
+		in, out := &in.Base, &out.Base
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PerVCPU != nil {
+		in, out := &in.PerVCPU, &out.PerVCPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PerGiBMemory != nil {
+		in, out := &in.PerGiBMemory, &out.PerGiBMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSizeExpression.
+func (in *VolumeSizeExpression) DeepCopy() *VolumeSizeExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSizeExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmPool) DeepCopyInto(out *WarmPool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmPool.
+func (in *WarmPool) DeepCopy() *WarmPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmPool)
+	in.DeepCopyInto(out)
+	return out
+}
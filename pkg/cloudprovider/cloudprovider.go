@@ -121,6 +121,17 @@ func (c *CloudProvider) Create(ctx context.Context, nodeClaim *karpv1.NodeClaim)
 	if instance.CapacityType == karpv1.CapacityTypeReserved {
 		c.capacityReservationProvider.MarkLaunched(instance.CapacityReservationID)
 	}
+	// CreateFleet doesn't return the partition an instance was placed in, so we describe the instance to label the
+	// NodeClaim with it. A newly launched instance may not be visible to DescribeInstances yet, in which case the
+	// nodeclaim.placementgroup controller labels the NodeClaim and its Node once it is.
+	if pg := nodeClass.Status.PlacementGroup; pg != nil && pg.Strategy == v1.PlacementGroupStrategyPartition {
+		if launched, err := c.instanceProvider.Get(ctx, instance.ID); err != nil {
+			log.FromContext(ctx).WithValues("instance-id", instance.ID).V(1).Info(fmt.Sprintf("deferring placement group partition resolution, %s", err))
+		} else {
+			instance.PlacementGroupID = launched.PlacementGroupID
+			instance.PartitionNumber = launched.PartitionNumber
+		}
+	}
 	instanceType, _ := lo.Find(instanceTypes, func(i *cloudprovider.InstanceType) bool {
 		return i.Name == string(instance.Type)
 	})
@@ -139,6 +150,10 @@ func (c *CloudProvider) List(ctx context.Context) ([]*karpv1.NodeClaim, error) {
 	}
 	var nodeClaims []*karpv1.NodeClaim
 	for _, it := range instances {
+		// Warm pool members aren't backed by a NodeClaim until they're claimed, and are managed by the warm pool controller
+		if it.WarmPool != "" {
+			continue
+		}
 		instanceType, err := c.resolveInstanceTypeFromInstance(ctx, it)
 		if err != nil {
 			return nil, fmt.Errorf("resolving instance type, %w", err)
@@ -420,6 +435,12 @@ func (c *CloudProvider) instanceToNodeClaim(i *instance.Instance, instanceType *
 	if i.CapacityType == karpv1.CapacityTypeReserved {
 		labels[cloudprovider.ReservationIDLabel] = i.CapacityReservationID
 	}
+	if i.PartitionNumber != 0 {
+		labels[v1.LabelPlacementGroupPartition] = fmt.Sprint(i.PartitionNumber)
+	}
+	if i.Tenancy != "" {
+		labels[v1.LabelInstanceTenancy] = i.Tenancy
+	}
 	if v, ok := i.Tags[karpv1.NodePoolLabelKey]; ok {
 		labels[karpv1.NodePoolLabelKey] = v
 	}
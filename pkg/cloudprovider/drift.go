@@ -37,6 +37,8 @@ const (
 	SubnetDrift              cloudprovider.DriftReason = "SubnetDrift"
 	SecurityGroupDrift       cloudprovider.DriftReason = "SecurityGroupDrift"
 	CapacityReservationDrift cloudprovider.DriftReason = "CapacityReservationDrift"
+	NetworkInterfaceDrift    cloudprovider.DriftReason = "NetworkInterfaceDrift"
+	PlacementGroupDrift      cloudprovider.DriftReason = "PlacementGroupDrift"
 	NodeClassDrift           cloudprovider.DriftReason = "NodeClassDrift"
 )
 
@@ -62,11 +64,15 @@ func (c *CloudProvider) isNodeClassDrifted(ctx context.Context, nodeClaim *karpv
 		return "", fmt.Errorf("calculating subnet drift, %w", err)
 	}
 	capacityReservationsDrifted := c.isCapacityReservationDrifted(instance, nodeClass)
+	networkInterfacesDrifted := c.areNetworkInterfacesDrifted(instance, nodeClass)
+	placementGroupDrifted := c.isPlacementGroupDrifted(instance, nodeClass)
 	drifted := lo.FindOrElse([]cloudprovider.DriftReason{
 		amiDrifted,
 		securitygroupDrifted,
 		subnetDrifted,
 		capacityReservationsDrifted,
+		networkInterfacesDrifted,
+		placementGroupDrifted,
 	}, "", func(i cloudprovider.DriftReason) bool {
 		return string(i) != ""
 	})
@@ -88,7 +94,12 @@ func (c *CloudProvider) isAMIDrifted(ctx context.Context, nodeClaim *karpv1.Node
 	if len(nodeClass.Status.AMIs) == 0 {
 		return "", fmt.Errorf("no amis exist given constraints")
 	}
-	mappedAMIs := amifamily.MapToInstanceTypes([]*cloudprovider.InstanceType{nodeInstanceType}, nodeClass.Status.AMIs)
+	// Nodes launched with either the stable or canary AMIs of a rollout aren't drifted. Once the rollout is promoted or
+	// halted, only one of them will be mapped.
+	mappedAMIs := lo.Assign(
+		amifamily.MapToInstanceTypes([]*cloudprovider.InstanceType{nodeInstanceType}, nodeClass.Status.AMIs, false),
+		amifamily.MapToInstanceTypes([]*cloudprovider.InstanceType{nodeInstanceType}, nodeClass.Status.AMIs, true),
+	)
 	if !lo.Contains(lo.Keys(mappedAMIs), instance.ImageID) {
 		return AMIDrift, nil
 	}
@@ -121,12 +132,56 @@ func (c *CloudProvider) areSecurityGroupsDrifted(ec2Instance *instance.Instance,
 		return "", fmt.Errorf("no security groups are present in the status")
 	}
 
-	if !securityGroupIds.Equal(sets.New(ec2Instance.SecurityGroupIDs...)) {
+	// If the primary network interface selects its own security groups, those are compared by the network interface drift check
+	if lo.ContainsBy(nodeClass.Status.NetworkInterfaces, func(ni v1.NetworkInterfaceStatus) bool {
+		return ni.DeviceIndex == 0 && len(ni.SecurityGroups) != 0
+	}) {
+		return "", nil
+	}
+	instanceSecurityGroupIDs := ec2Instance.SecurityGroupIDs
+	// The instance's security groups include the security groups of every attached network interface, so we only
+	// consider the primary network interface when secondary network interfaces are configured
+	if primary, ok := lo.Find(ec2Instance.NetworkInterfaces, func(ni instance.NetworkInterface) bool { return ni.DeviceIndex == 0 }); ok && len(nodeClass.Spec.NetworkInterfaces) != 0 {
+		instanceSecurityGroupIDs = primary.SecurityGroupIDs
+	}
+	if !securityGroupIds.Equal(sets.New(instanceSecurityGroupIDs...)) {
 		return SecurityGroupDrift, nil
 	}
 	return "", nil
 }
 
+// Checks if the network interfaces are drifted, by comparing the subnets and security groups resolved for each network
+// interface configured on the EC2NodeClass to those of the instance's network interface at the same device index
+func (c *CloudProvider) areNetworkInterfacesDrifted(ec2Instance *instance.Instance, nodeClass *v1.EC2NodeClass) cloudprovider.DriftReason {
+	for _, ni := range nodeClass.Status.NetworkInterfaces {
+		instanceNetworkInterface, ok := lo.Find(ec2Instance.NetworkInterfaces, func(i instance.NetworkInterface) bool { return i.DeviceIndex == ni.DeviceIndex })
+		if !ok {
+			return NetworkInterfaceDrift
+		}
+		if len(ni.Subnets) != 0 && !lo.ContainsBy(ni.Subnets, func(s v1.Subnet) bool { return s.ID == instanceNetworkInterface.SubnetID }) {
+			return NetworkInterfaceDrift
+		}
+		securityGroupIDs := lo.Map(lo.Ternary(len(ni.SecurityGroups) != 0, ni.SecurityGroups, nodeClass.Status.SecurityGroups), func(sg v1.SecurityGroup, _ int) string { return sg.ID })
+		if !sets.New(securityGroupIDs...).Equal(sets.New(instanceNetworkInterface.SecurityGroupIDs...)) {
+			return NetworkInterfaceDrift
+		}
+	}
+	return ""
+}
+
+// Checks if the placement group is drifted, by comparing the placement group resolved for the NodeClass to the
+// instance's placement group
+func (c *CloudProvider) isPlacementGroupDrifted(instance *instance.Instance, nodeClass *v1.EC2NodeClass) cloudprovider.DriftReason {
+	// Don't drift nodes while the placement group selector can't be resolved, the NodeClass won't be ready in this case
+	if nodeClass.Spec.PlacementGroup != nil && nodeClass.Status.PlacementGroup == nil {
+		return ""
+	}
+	if instance.PlacementGroupID != lo.FromPtr(nodeClass.Status.PlacementGroup).ID {
+		return PlacementGroupDrift
+	}
+	return ""
+}
+
 // Checks if capacity reservations are drifted, by comparing the capacity reservations persisted to the NodeClass to
 // the instance's capacity reservation.
 // NOTE: We handle drift dynamically for capacity reservations rather than relying on the offerings inducing drift since
@@ -296,6 +296,45 @@ var _ = Describe("CloudProvider", func() {
 		Expect(ok).To(BeTrue())
 		Expect(zoneID).To(Equal(subnet.ZoneID))
 	})
+	It("should return the placement group partition as a label on the nodeClaim", func() {
+		awsEnv.EC2API.DescribePlacementGroupsOutput.Set(&ec2.DescribePlacementGroupsOutput{
+			PlacementGroups: []ec2types.PlacementGroup{{
+				GroupId:        aws.String("pg-test1"),
+				GroupName:      aws.String("placementGroup-test1"),
+				State:          ec2types.PlacementGroupStateAvailable,
+				Strategy:       ec2types.PlacementStrategyPartition,
+				PartitionCount: lo.ToPtr[int32](3),
+			}},
+		})
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-test1"}
+		nodeClass.Status.PlacementGroup = &v1.PlacementGroup{ID: "pg-test1", Name: "placementGroup-test1", Strategy: v1.PlacementGroupStrategyPartition, PartitionCount: 3}
+		nodeClass.StatusConditions().SetTrue(v1.ConditionTypePlacementGroupReady)
+		ExpectApplied(ctx, env.Client, nodePool, nodeClass, nodeClaim)
+		cloudProviderNodeClaim, err := cloudProvider.Create(ctx, nodeClaim)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cloudProviderNodeClaim).ToNot(BeNil())
+		Expect(cloudProviderNodeClaim.GetLabels()).To(HaveKeyWithValue(v1.LabelPlacementGroupPartition, "1"))
+	})
+	It("should not return the placement group partition label when no placement group is selected", func() {
+		ExpectApplied(ctx, env.Client, nodePool, nodeClass, nodeClaim)
+		cloudProviderNodeClaim, err := cloudProvider.Create(ctx, nodeClaim)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cloudProviderNodeClaim).ToNot(BeNil())
+		Expect(cloudProviderNodeClaim.GetLabels()).ToNot(HaveKey(v1.LabelPlacementGroupPartition))
+	})
+	It("should return the instance tenancy as a label on the nodeClaim", func() {
+		nodeClass.Spec.Tenancy = lo.ToPtr(v1.TenancyDedicated)
+		ExpectApplied(ctx, env.Client, nodePool, nodeClass, nodeClaim)
+		cloudProviderNodeClaim, err := cloudProvider.Create(ctx, nodeClaim)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cloudProviderNodeClaim).ToNot(BeNil())
+		Expect(cloudProviderNodeClaim.GetLabels()).To(HaveKeyWithValue(v1.LabelInstanceTenancy, string(v1.TenancyDedicated)))
+
+		// The tenancy is resolved from the instance's placement when the NodeClaim is retrieved
+		retrieved, err := cloudProvider.Get(ctx, cloudProviderNodeClaim.Status.ProviderID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retrieved.GetLabels()).To(HaveKeyWithValue(v1.LabelInstanceTenancy, string(v1.TenancyDedicated)))
+	})
 	It("should expect a strict set of annotation keys", func() {
 		ExpectApplied(ctx, env.Client, nodePool, nodeClass, nodeClaim)
 		cloudProviderNodeClaim, err := cloudProvider.Create(ctx, nodeClaim)
@@ -907,6 +946,82 @@ var _ = Describe("CloudProvider", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(isDrifted).To(Equal(cloudprovider.SecurityGroupDrift))
 		})
+		It("should return drifted if the instance's network interfaces don't match the discovered values", func() {
+			secondarySecurityGroup := fake.SecurityGroupID()
+			nodeClass.Status.NetworkInterfaces = []v1.NetworkInterfaceStatus{
+				{
+					DeviceIndex:    1,
+					Subnets:        []v1.Subnet{{ID: validSubnet2, Zone: "zone-2"}},
+					SecurityGroups: []v1.SecurityGroup{{ID: secondarySecurityGroup}},
+				},
+			}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			setNetworkInterfaces := func(networkInterfaces ...ec2types.InstanceNetworkInterface) {
+				instance.NetworkInterfaces = networkInterfaces
+				awsEnv.EC2API.DescribeInstancesBehavior.Output.Set(&ec2.DescribeInstancesOutput{
+					Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{instance}}},
+				})
+			}
+			primary := ec2types.InstanceNetworkInterface{
+				Attachment: &ec2types.InstanceNetworkInterfaceAttachment{DeviceIndex: lo.ToPtr[int32](0), NetworkCardIndex: lo.ToPtr[int32](0)},
+				SubnetId:   aws.String(validSubnet1),
+				Groups:     []ec2types.GroupIdentifier{{GroupId: aws.String(validSecurityGroup)}},
+			}
+			secondary := ec2types.InstanceNetworkInterface{
+				Attachment: &ec2types.InstanceNetworkInterfaceAttachment{DeviceIndex: lo.ToPtr[int32](1), NetworkCardIndex: lo.ToPtr[int32](0)},
+				SubnetId:   aws.String(validSubnet2),
+				Groups:     []ec2types.GroupIdentifier{{GroupId: aws.String(secondarySecurityGroup)}},
+			}
+
+			// The secondary network interface is missing
+			setNetworkInterfaces(primary)
+			isDrifted, err := cloudProvider.IsDrifted(ctx, nodeClaim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isDrifted).To(Equal(cloudprovider.NetworkInterfaceDrift))
+
+			// The secondary network interface uses a different security group
+			secondary.Groups = []ec2types.GroupIdentifier{{GroupId: aws.String(validSecurityGroup)}}
+			setNetworkInterfaces(primary, secondary)
+			isDrifted, err = cloudProvider.IsDrifted(ctx, nodeClaim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isDrifted).To(Equal(cloudprovider.NetworkInterfaceDrift))
+
+			// The secondary network interface matches
+			secondary.Groups = []ec2types.GroupIdentifier{{GroupId: aws.String(secondarySecurityGroup)}}
+			setNetworkInterfaces(primary, secondary)
+			isDrifted, err = cloudProvider.IsDrifted(ctx, nodeClaim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isDrifted).To(BeEmpty())
+		})
+		It("should return drifted if the instance's placement group doesn't match the discovered value", func() {
+			nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-test1"}
+			nodeClass.Status.PlacementGroup = &v1.PlacementGroup{ID: "pg-test1", Name: "placementGroup-test1", Strategy: v1.PlacementGroupStrategyCluster}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			setPlacementGroup := func(id *string) {
+				instance.Placement.GroupId = id
+				awsEnv.EC2API.DescribeInstancesBehavior.Output.Set(&ec2.DescribeInstancesOutput{
+					Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{instance}}},
+				})
+			}
+
+			// The instance wasn't launched into a placement group
+			setPlacementGroup(nil)
+			isDrifted, err := cloudProvider.IsDrifted(ctx, nodeClaim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isDrifted).To(Equal(cloudprovider.PlacementGroupDrift))
+
+			// The instance was launched into a different placement group
+			setPlacementGroup(aws.String("pg-test2"))
+			isDrifted, err = cloudProvider.IsDrifted(ctx, nodeClaim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isDrifted).To(Equal(cloudprovider.PlacementGroupDrift))
+
+			// The instance's placement group matches
+			setPlacementGroup(aws.String("pg-test1"))
+			isDrifted, err = cloudProvider.IsDrifted(ctx, nodeClaim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isDrifted).To(BeEmpty())
+		})
 		It("should dynamically drift nodeclaims for capacity reservations", func() {
 			nodeClass.Status.CapacityReservations = []v1.CapacityReservation{
 				{
@@ -975,6 +1090,31 @@ var _ = Describe("CloudProvider", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(isDrifted).To(Equal(cloudprovider.AMIDrift))
 		})
+		DescribeTable("should honour the rollout phase of the instance's AMI",
+			func(phase v1.AMIRolloutPhase, drifted bool) {
+				amd64Requirements := []corev1.NodeSelectorRequirement{
+					{Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: []string{karpv1.ArchitectureAmd64}},
+				}
+				nodeClass.Spec.AMIFamily = lo.ToPtr(v1.AMIFamilyCustom)
+				nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Tags: map[string]string{"*": "*"}}}
+				nodeClass.Spec.AMIRolloutPolicy = &v1.AMIRolloutPolicy{CanaryPercentage: 10, BakeTime: metav1.Duration{Duration: time.Hour}}
+				nodeClass.Status.AMIs = []v1.AMI{
+					{ID: armAMIID, Requirements: amd64Requirements, RolloutPhase: phase, RolloutStartTime: lo.ToPtr(metav1.Now())},
+					{ID: amdAMIID, Requirements: amd64Requirements, RolloutPhase: v1.AMIRolloutPhaseStable},
+				}
+				instance.ImageId = aws.String(armAMIID)
+				awsEnv.EC2API.DescribeInstancesBehavior.Output.Set(&ec2.DescribeInstancesOutput{
+					Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{instance}}},
+				})
+				ExpectApplied(ctx, env.Client, nodeClass)
+				isDrifted, err := cloudProvider.IsDrifted(ctx, nodeClaim)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(isDrifted).To(Equal(lo.Ternary(drifted, cloudprovider.AMIDrift, "")))
+			},
+			Entry("Stable", v1.AMIRolloutPhaseStable, false),
+			Entry("Canary", v1.AMIRolloutPhaseCanary, false),
+			Entry("Halted", v1.AMIRolloutPhaseHalted, true),
+		)
 		Context("Static Drift Detection", func() {
 			BeforeEach(func() {
 				armRequirements := []corev1.NodeSelectorRequirement{
@@ -1220,7 +1360,7 @@ var _ = Describe("CloudProvider", func() {
 				{SubnetId: aws.String("test-subnet-2"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(100),
 					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-2")}}},
 			}})
-			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver)
+			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.PlacementGroupProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver, awsEnv.AMIHealthTracker)
 			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
 			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
 			pod := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelTopologyZone: "test-zone-1a"}})
@@ -1237,7 +1377,7 @@ var _ = Describe("CloudProvider", func() {
 				{SubnetId: aws.String("test-subnet-2"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(11),
 					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-2")}}},
 			}})
-			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver)
+			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.PlacementGroupProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver, awsEnv.AMIHealthTracker)
 			nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{
 				MaxPods: aws.Int32(1),
 			}
@@ -1258,6 +1398,127 @@ var _ = Describe("CloudProvider", func() {
 			createFleetInput = awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Pop()
 			Expect(fake.SubnetsFromFleetRequest(createFleetInput)).To(ConsistOf("test-subnet-1"))
 		})
+		It("should include multiple subnets per zone when subnets-per-zone is set", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{SubnetsPerZone: lo.ToPtr(2)}))
+			awsEnv.SubnetCache.Flush()
+			awsEnv.EC2API.DescribeSubnetsBehavior.Output.Set(&ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{
+				{SubnetId: aws.String("test-subnet-1"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(10),
+					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-1")}}},
+				{SubnetId: aws.String("test-subnet-2"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(100),
+					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-2")}}},
+				{SubnetId: aws.String("test-subnet-3"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(50),
+					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-3")}}},
+			}})
+			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.PlacementGroupProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver, awsEnv.AMIHealthTracker)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			pod := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelTopologyZone: "test-zone-1a"}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			createFleetInput := awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Pop()
+			Expect(fake.SubnetsFromFleetRequest(createFleetInput)).To(ConsistOf("test-subnet-2", "test-subnet-3"))
+			// Overrides for the subnet with the most available IPs should come first
+			Expect(lo.FromPtr(createFleetInput.LaunchTemplateConfigs[0].Overrides[0].SubnetId)).To(Equal("test-subnet-2"))
+		})
+		It("should not mark an offering as unavailable when only one of the zone's subnets runs out of addresses", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{SubnetsPerZone: lo.ToPtr(2)}))
+			awsEnv.SubnetCache.Flush()
+			awsEnv.EC2API.DescribeSubnetsBehavior.Output.Set(&ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{
+				{SubnetId: aws.String("test-subnet-1"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(10),
+					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-1")}}},
+				{SubnetId: aws.String("test-subnet-2"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(100),
+					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-2")}}},
+			}})
+			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.PlacementGroupProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver, awsEnv.AMIHealthTracker)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			awsEnv.EC2API.CreateFleetBehavior.Output.Set(&ec2.CreateFleetOutput{
+				Errors: []ec2types.CreateFleetError{{
+					ErrorCode: aws.String("InsufficientFreeAddressesInSubnet"),
+					LaunchTemplateAndOverrides: &ec2types.LaunchTemplateAndOverridesResponse{
+						Overrides: &ec2types.FleetLaunchTemplateOverrides{
+							InstanceType:     "m5.large",
+							SubnetId:         aws.String("test-subnet-2"),
+							AvailabilityZone: aws.String("test-zone-1a"),
+						},
+					},
+				}},
+				Instances: []ec2types.CreateFleetInstance{{
+					InstanceIds:  []string{"i-test"},
+					InstanceType: "m5.large",
+					LaunchTemplateAndOverrides: &ec2types.LaunchTemplateAndOverridesResponse{
+						Overrides: &ec2types.FleetLaunchTemplateOverrides{
+							InstanceType:     "m5.large",
+							SubnetId:         aws.String("test-subnet-1"),
+							AvailabilityZone: aws.String("test-zone-1a"),
+						},
+					},
+				}},
+			})
+			pod := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{
+				corev1.LabelTopologyZone:       "test-zone-1a",
+				corev1.LabelInstanceTypeStable: "m5.large",
+			}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.UnavailableOfferingsCache.IsUnavailable("m5.large", "test-zone-1a", karpv1.CapacityTypeOnDemand)).To(BeFalse())
+		})
+		It("should deduct whole prefixes from subnets when prefix delegation is enabled", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{IPAccountingMode: lo.ToPtr(options.IPAccountingModePrefixDelegation)}))
+			awsEnv.SubnetCache.Flush()
+			awsEnv.EC2API.DescribeSubnetsBehavior.Output.Set(&ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{
+				{SubnetId: aws.String("test-subnet-1"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(10),
+					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-1")}}},
+				{SubnetId: aws.String("test-subnet-2"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(20),
+					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-2")}}},
+			}})
+			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.PlacementGroupProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver, awsEnv.AMIHealthTracker)
+			nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{
+				MaxPods: aws.Int32(1),
+			}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			pod1 := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelTopologyZone: "test-zone-1a"}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod1)
+			ExpectScheduled(ctx, env.Client, pod1)
+			createFleetInput := awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Pop()
+			Expect(fake.SubnetsFromFleetRequest(createFleetInput)).To(ConsistOf("test-subnet-2"))
+			// A single pod consumes a /28 prefix, leaving test-subnet-2 with 4 available IPs
+			pod2 := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelTopologyZone: "test-zone-1a"}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod2)
+			ExpectScheduled(ctx, env.Client, pod2)
+			createFleetInput = awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Pop()
+			Expect(fake.SubnetsFromFleetRequest(createFleetInput)).To(ConsistOf("test-subnet-1"))
+		})
+		It("should only deduct the node IP from subnets when custom networking is enabled", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{IPAccountingMode: lo.ToPtr(options.IPAccountingModeCustomNetworking)}))
+			awsEnv.SubnetCache.Flush()
+			awsEnv.EC2API.DescribeSubnetsBehavior.Output.Set(&ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{
+				{SubnetId: aws.String("test-subnet-1"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(10),
+					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-1")}}},
+				{SubnetId: aws.String("test-subnet-2"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(12),
+					Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("test-subnet-2")}}},
+			}})
+			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.PlacementGroupProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver, awsEnv.AMIHealthTracker)
+			nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{
+				MaxPods: aws.Int32(20),
+			}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			pod1 := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelTopologyZone: "test-zone-1a"}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod1)
+			ExpectScheduled(ctx, env.Client, pod1)
+			createFleetInput := awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Pop()
+			Expect(fake.SubnetsFromFleetRequest(createFleetInput)).To(ConsistOf("test-subnet-2"))
+			// Pods use the ENIConfig subnet, leaving test-subnet-2 with 11 available IPs
+			pod2 := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelTopologyZone: "test-zone-1a"}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod2)
+			ExpectScheduled(ctx, env.Client, pod2)
+			createFleetInput = awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Pop()
+			Expect(fake.SubnetsFromFleetRequest(createFleetInput)).To(ConsistOf("test-subnet-2"))
+		})
 		It("should update in-flight IPs when a CreateFleet error occurs", func() {
 			awsEnv.EC2API.DescribeSubnetsBehavior.Output.Set(&ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{
 				{SubnetId: aws.String("test-subnet-1"), AvailabilityZone: aws.String("test-zone-1a"), AvailableIpAddressCount: aws.Int32(10),
@@ -1286,7 +1547,7 @@ var _ = Describe("CloudProvider", func() {
 			})
 			nodeClass.Spec.SubnetSelectorTerms = []v1.SubnetSelectorTerm{{Tags: map[string]string{"Name": "test-subnet-1"}}}
 			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
-			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver)
+			controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.PlacementGroupProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver, awsEnv.AMIHealthTracker)
 			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
 			podSubnet1 := coretest.UnschedulablePod()
 			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, podSubnet1)
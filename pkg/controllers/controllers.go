@@ -30,7 +30,10 @@ import (
 	sdk "github.com/aws/karpenter-provider-aws/pkg/aws"
 	"github.com/aws/karpenter-provider-aws/pkg/controllers/metrics"
 	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass"
+	nodeclassamideprecation "github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass/amideprecation"
 	nodeclasshash "github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass/hash"
+	nodeclasswarmpool "github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass/warmpool"
+	instanceprofilegarbagecollection "github.com/aws/karpenter-provider-aws/pkg/controllers/providers/instanceprofile/garbagecollection"
 	controllersinstancetype "github.com/aws/karpenter-provider-aws/pkg/controllers/providers/instancetype"
 	controllersinstancetypecapacity "github.com/aws/karpenter-provider-aws/pkg/controllers/providers/instancetype/capacity"
 	controllerspricing "github.com/aws/karpenter-provider-aws/pkg/controllers/providers/pricing"
@@ -47,14 +50,17 @@ import (
 
 	awscache "github.com/aws/karpenter-provider-aws/pkg/cache"
 	"github.com/aws/karpenter-provider-aws/pkg/controllers/interruption"
+	nodeclaimamihealth "github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclaim/amihealth"
 	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclaim/capacityreservation"
 	nodeclaimgarbagecollection "github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclaim/garbagecollection"
+	nodeclaimplacementgroup "github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclaim/placementgroup"
 	nodeclaimtagging "github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclaim/tagging"
 	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/amifamily"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instance"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instanceprofile"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instancetype"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/placementgroup"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/pricing"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/securitygroup"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/sqs"
@@ -83,14 +89,21 @@ func NewControllers(
 	versionProvider *version.DefaultProvider,
 	instanceTypeProvider *instancetype.DefaultProvider,
 	capacityReservationProvider capacityreservationprovider.Provider,
+	placementGroupProvider placementgroup.Provider,
 	amiResolver amifamily.Resolver,
+	amiHealthTracker *amifamily.HealthTracker,
 ) []controller.Controller {
 	controllers := []controller.Controller{
 		nodeclasshash.NewController(kubeClient),
-		nodeclass.NewController(clk, kubeClient, cloudProvider, recorder, cfg.Region, subnetProvider, securityGroupProvider, amiProvider, instanceProfileProvider, instanceTypeProvider, launchTemplateProvider, capacityReservationProvider, ec2api, validationCache, amiResolver),
+		nodeclass.NewController(clk, kubeClient, cloudProvider, recorder, cfg.Region, subnetProvider, securityGroupProvider, amiProvider, instanceProfileProvider, instanceTypeProvider, launchTemplateProvider, capacityReservationProvider, placementGroupProvider, ec2api, validationCache, amiResolver, amiHealthTracker),
+		nodeclasswarmpool.NewController(clk, kubeClient, cloudProvider, instanceProvider),
+		nodeclassamideprecation.NewController(clk, kubeClient, instanceProvider, ec2api),
 		nodeclaimgarbagecollection.NewController(kubeClient, cloudProvider),
 		nodeclaimtagging.NewController(kubeClient, cloudProvider, instanceProvider),
-		controllerspricing.NewController(pricingProvider),
+		nodeclaimamihealth.NewController(clk, kubeClient, cloudProvider, amiHealthTracker),
+		nodeclaimplacementgroup.NewController(kubeClient, cloudProvider, instanceProvider),
+		instanceprofilegarbagecollection.NewController(clk, kubeClient, instanceProfileProvider, instanceProvider, cfg.Region),
+		controllerspricing.NewController(kubeClient, pricingProvider),
 		controllersinstancetype.NewController(instanceTypeProvider),
 		controllersinstancetypecapacity.NewController(kubeClient, cloudProvider, instanceTypeProvider),
 		ssminvalidation.NewController(ssmCache, amiProvider),
@@ -72,7 +72,7 @@ var _ = BeforeSuite(func() {
 		env.Client, awsEnv.AMIProvider, awsEnv.SecurityGroupProvider, awsEnv.CapacityReservationProvider)
 	controller = metrics.NewController(env.Client, cloudProvider)
 
-	pricingController = pricing.NewController(awsEnv.PricingProvider)
+	pricingController = pricing.NewController(env.Client, awsEnv.PricingProvider)
 })
 
 var _ = AfterSuite(func() {
@@ -0,0 +1,168 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amihealth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/awslabs/operatorpkg/object"
+	"github.com/samber/lo"
+	"k8s.io/client-go/tools/record"
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/events"
+	coretest "sigs.k8s.io/karpenter/pkg/test"
+	"sigs.k8s.io/karpenter/pkg/test/v1alpha1"
+
+	"github.com/aws/karpenter-provider-aws/pkg/apis"
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/cloudprovider"
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclaim/amihealth"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	coreoptions "sigs.k8s.io/karpenter/pkg/operator/options"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+	. "sigs.k8s.io/karpenter/pkg/utils/testing"
+)
+
+var ctx context.Context
+var awsEnv *test.Environment
+var env *coretest.Environment
+var amiHealthController *amihealth.Controller
+
+func TestAPIs(t *testing.T) {
+	ctx = TestContextWithLogger(t)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AMIHealthController")
+}
+
+var _ = BeforeSuite(func() {
+	env = coretest.NewEnvironment(coretest.WithCRDs(apis.CRDs...), coretest.WithCRDs(v1alpha1.CRDs...))
+	ctx = coreoptions.ToContext(ctx, coretest.Options(coretest.OptionsFields{FeatureGates: coretest.FeatureGates{ReservedCapacity: lo.ToPtr(true)}}))
+	ctx = options.ToContext(ctx, test.Options())
+	awsEnv = test.NewEnvironment(ctx, env)
+	cloudProvider := cloudprovider.New(awsEnv.InstanceTypesProvider, awsEnv.InstanceProvider, events.NewRecorder(&record.FakeRecorder{}),
+		env.Client, awsEnv.AMIProvider, awsEnv.SecurityGroupProvider, awsEnv.CapacityReservationProvider)
+	amiHealthController = amihealth.NewController(awsEnv.Clock, env.Client, cloudProvider, awsEnv.AMIHealthTracker)
+})
+var _ = AfterSuite(func() {
+	Expect(env.Stop()).To(Succeed(), "Failed to stop environment")
+})
+
+var _ = BeforeEach(func() {
+	awsEnv.Reset()
+	awsEnv.Clock.SetTime(time.Now())
+})
+
+var _ = AfterEach(func() {
+	ExpectCleanedUp(ctx, env.Client)
+})
+
+var _ = Describe("AMIHealthController", func() {
+	var nodeClaim *karpv1.NodeClaim
+	BeforeEach(func() {
+		nodeClaim = coretest.NodeClaim(karpv1.NodeClaim{
+			Status: karpv1.NodeClaimStatus{
+				ImageID: "ami-123",
+			},
+		})
+		nodeClaim.StatusConditions().SetTrue(karpv1.ConditionTypeLaunched)
+	})
+	It("should record a healthy outcome once the NodeClaim is initialized", func() {
+		nodeClaim.StatusConditions().SetTrue(karpv1.ConditionTypeInitialized)
+		ExpectApplied(ctx, env.Client, nodeClaim)
+		ExpectObjectReconciled(ctx, env.Client, amiHealthController, nodeClaim)
+		total, failed := awsEnv.AMIHealthTracker.Outcomes("ami-123")
+		Expect(total).To(Equal(1))
+		Expect(failed).To(Equal(0))
+	})
+	It("should requeue while the NodeClaim is within its ready timeout", func() {
+		ExpectApplied(ctx, env.Client, nodeClaim)
+		res := ExpectObjectReconciled(ctx, env.Client, amiHealthController, nodeClaim)
+		Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+		total, _ := awsEnv.AMIHealthTracker.Outcomes("ami-123")
+		Expect(total).To(Equal(0))
+	})
+	It("should record a failure once the NodeClaim exceeds its ready timeout", func() {
+		ExpectApplied(ctx, env.Client, nodeClaim)
+		awsEnv.Clock.Step(15 * time.Minute)
+		ExpectObjectReconciled(ctx, env.Client, amiHealthController, nodeClaim)
+		total, failed := awsEnv.AMIHealthTracker.Outcomes("ami-123")
+		Expect(total).To(Equal(1))
+		Expect(failed).To(Equal(1))
+	})
+	It("should record a NodeClaim that becomes ready after failing as healthy", func() {
+		ExpectApplied(ctx, env.Client, nodeClaim)
+		awsEnv.Clock.Step(15 * time.Minute)
+		ExpectObjectReconciled(ctx, env.Client, amiHealthController, nodeClaim)
+		nodeClaim = ExpectExists(ctx, env.Client, nodeClaim)
+		nodeClaim.StatusConditions().SetTrue(karpv1.ConditionTypeInitialized)
+		ExpectApplied(ctx, env.Client, nodeClaim)
+		ExpectObjectReconciled(ctx, env.Client, amiHealthController, nodeClaim)
+		total, failed := awsEnv.AMIHealthTracker.Outcomes("ami-123")
+		Expect(total).To(Equal(1))
+		Expect(failed).To(Equal(0))
+	})
+	It("should ignore NodeClaims that haven't launched", func() {
+		nodeClaim.Status.ImageID = ""
+		nodeClaim.StatusConditions().SetUnknown(karpv1.ConditionTypeLaunched)
+		ExpectApplied(ctx, env.Client, nodeClaim)
+		awsEnv.Clock.Step(15 * time.Minute)
+		ExpectObjectReconciled(ctx, env.Client, amiHealthController, nodeClaim)
+		total, _ := awsEnv.AMIHealthTracker.Outcomes("ami-123")
+		Expect(total).To(Equal(0))
+	})
+	Context("Launch Failures", func() {
+		var nodeClass *v1.EC2NodeClass
+		BeforeEach(func() {
+			nodeClass = test.EC2NodeClass()
+			nodeClass.Status.AMIs = []v1.AMI{{ID: "ami-456", Name: "test-ami"}}
+			nodeClaim.Spec.NodeClassRef = &karpv1.NodeClassReference{
+				Group: object.GVK(nodeClass).Group,
+				Kind:  object.GVK(nodeClass).Kind,
+				Name:  nodeClass.Name,
+			}
+			nodeClaim.Status.ImageID = ""
+			nodeClaim.StatusConditions().SetUnknownWithReason(karpv1.ConditionTypeLaunched, "LaunchFailed", "failed to launch")
+		})
+		It("should record a failure against the NodeClass's AMIs once the NodeClaim exceeds its ready timeout", func() {
+			ExpectApplied(ctx, env.Client, nodeClass, nodeClaim)
+			awsEnv.Clock.Step(15 * time.Minute)
+			ExpectObjectReconciled(ctx, env.Client, amiHealthController, nodeClaim)
+			total, failed := awsEnv.AMIHealthTracker.Outcomes("ami-456")
+			Expect(total).To(Equal(1))
+			Expect(failed).To(Equal(1))
+		})
+		It("should requeue while the NodeClaim is within its ready timeout", func() {
+			ExpectApplied(ctx, env.Client, nodeClass, nodeClaim)
+			res := ExpectObjectReconciled(ctx, env.Client, amiHealthController, nodeClaim)
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+			total, _ := awsEnv.AMIHealthTracker.Outcomes("ami-456")
+			Expect(total).To(Equal(0))
+		})
+		It("should ignore launch failures when the NodeClass doesn't exist", func() {
+			ExpectApplied(ctx, env.Client, nodeClaim)
+			awsEnv.Clock.Step(15 * time.Minute)
+			ExpectObjectReconciled(ctx, env.Client, amiHealthController, nodeClaim)
+			total, _ := awsEnv.AMIHealthTracker.Outcomes("ami-456")
+			Expect(total).To(Equal(0))
+		})
+	})
+})
@@ -306,6 +306,21 @@ var _ = Describe("GarbageCollection", func() {
 		_, err := cloudProvider.Get(ctx, providerID)
 		Expect(err).NotTo(HaveOccurred())
 	})
+	It("should not delete an instance if it is a warm pool member", func() {
+		instance.Tags = append(instance.Tags, ec2types.Tag{
+			Key:   aws.String(v1.WarmPoolTagKey),
+			Value: aws.String("default"),
+		})
+		instance.State = &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped}
+
+		// Launch time was 1m ago
+		instance.LaunchTime = aws.Time(time.Now().Add(-time.Minute))
+		awsEnv.EC2API.Instances.Store(aws.ToString(instance.InstanceId), *instance)
+
+		ExpectSingletonReconciled(ctx, garbageCollectionController)
+		_, err := cloudProvider.Get(ctx, providerID)
+		Expect(err).NotTo(HaveOccurred())
+	})
 	It("should not delete the instance or node if it already has a NodeClaim that matches it", func() {
 		// Launch time was 1m ago
 		instance.LaunchTime = aws.Time(time.Now().Add(-time.Minute))
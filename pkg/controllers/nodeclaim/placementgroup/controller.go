@@ -0,0 +1,141 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementgroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awslabs/operatorpkg/reasonable"
+	"github.com/samber/lo"
+	"k8s.io/klog/v2"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/operator/injection"
+	nodeclaimutils "sigs.k8s.io/karpenter/pkg/utils/nodeclaim"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/instance"
+	"github.com/aws/karpenter-provider-aws/pkg/utils"
+)
+
+// Controller labels NodeClaims launched into a partition placement group, and their Nodes, with the partition their
+// instance was placed in. The label is set when the NodeClaim is created if the instance is already visible to
+// DescribeInstances, and this controller fills it in otherwise.
+type Controller struct {
+	kubeClient       client.Client
+	cloudProvider    cloudprovider.CloudProvider
+	instanceProvider instance.Provider
+}
+
+func NewController(kubeClient client.Client, cloudProvider cloudprovider.CloudProvider, instanceProvider instance.Provider) *Controller {
+	return &Controller{
+		kubeClient:       kubeClient,
+		cloudProvider:    cloudProvider,
+		instanceProvider: instanceProvider,
+	}
+}
+
+func (c *Controller) Reconcile(ctx context.Context, nodeClaim *karpv1.NodeClaim) (reconcile.Result, error) {
+	ctx = injection.WithControllerName(ctx, "nodeclaim.placementgroup")
+
+	if !nodeClaim.DeletionTimestamp.IsZero() || nodeClaim.Status.ProviderID == "" {
+		return reconcile.Result{}, nil
+	}
+	partition, ok := nodeClaim.Labels[v1.LabelPlacementGroupPartition]
+	if !ok {
+		var err error
+		if partition, err = c.resolvePartition(ctx, nodeClaim); err != nil {
+			return reconcile.Result{}, err
+		}
+		if partition == "" {
+			return reconcile.Result{}, nil
+		}
+		stored := nodeClaim.DeepCopy()
+		nodeClaim.Labels = lo.Assign(nodeClaim.Labels, map[string]string{v1.LabelPlacementGroupPartition: partition})
+		if err := c.kubeClient.Patch(ctx, nodeClaim, client.MergeFrom(stored)); err != nil {
+			return reconcile.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+	// Labels are only copied from the NodeClaim to its Node when the Node registers, so Nodes which registered before
+	// the NodeClaim was labeled are labeled here
+	nodes, err := nodeclaimutils.AllNodesForNodeClaim(ctx, c.kubeClient, nodeClaim)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing nodes for nodeclaim, %w", err)
+	}
+	for _, n := range nodes {
+		if !n.DeletionTimestamp.IsZero() || n.Labels[karpv1.NodeRegisteredLabelKey] != "true" || n.Labels[v1.LabelPlacementGroupPartition] == partition {
+			continue
+		}
+		stored := n.DeepCopy()
+		n.Labels[v1.LabelPlacementGroupPartition] = partition
+		if err := c.kubeClient.Patch(ctx, n, client.MergeFrom(stored)); client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, fmt.Errorf("patching node, %w", err)
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// resolvePartition returns the partition of the NodeClaim's instance, or an empty string if the instance wasn't
+// launched into a partition placement group
+func (c *Controller) resolvePartition(ctx context.Context, nodeClaim *karpv1.NodeClaim) (string, error) {
+	if nodeClaim.Spec.NodeClassRef == nil {
+		return "", nil
+	}
+	nodeClass := &v1.EC2NodeClass{}
+	if err := c.kubeClient.Get(ctx, client.ObjectKey{Name: nodeClaim.Spec.NodeClassRef.Name}, nodeClass); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	if pg := nodeClass.Status.PlacementGroup; pg == nil || pg.Strategy != v1.PlacementGroupStrategyPartition {
+		return "", nil
+	}
+	id, err := utils.ParseInstanceID(nodeClaim.Status.ProviderID)
+	if err != nil {
+		// We don't throw an error here since we don't want to retry until the ProviderID has been updated.
+		log.FromContext(ctx).WithValues("NodeClaim", klog.KObj(nodeClaim)).Error(err, "failed parsing instance id")
+		return "", nil
+	}
+	launched, err := c.instanceProvider.Get(ctx, id)
+	if err != nil {
+		return "", cloudprovider.IgnoreNodeClaimNotFoundError(fmt.Errorf("resolving placement group partition, %w", err))
+	}
+	if launched.PartitionNumber == 0 {
+		return "", nil
+	}
+	return fmt.Sprint(launched.PartitionNumber), nil
+}
+
+func (c *Controller) Register(_ context.Context, m manager.Manager) error {
+	return controllerruntime.NewControllerManagedBy(m).
+		Named("nodeclaim.placementgroup").
+		For(&karpv1.NodeClaim{}, builder.WithPredicates(nodeclaimutils.IsManagedPredicateFuncs(c.cloudProvider))).
+		WithEventFilter(predicate.NewPredicateFuncs(func(o client.Object) bool {
+			return o.(*karpv1.NodeClaim).Status.ProviderID != ""
+		})).
+		WithOptions(controller.Options{
+			RateLimiter:             reasonable.RateLimiter(),
+			MaxConcurrentReconciles: 10,
+		}).
+		Complete(reconcile.AsReconciler(m.GetClient(), c))
+}
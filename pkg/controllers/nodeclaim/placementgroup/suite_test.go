@@ -0,0 +1,148 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementgroup_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/awslabs/operatorpkg/object"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/events"
+	coretest "sigs.k8s.io/karpenter/pkg/test"
+	"sigs.k8s.io/karpenter/pkg/test/v1alpha1"
+
+	"github.com/aws/karpenter-provider-aws/pkg/apis"
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/cloudprovider"
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclaim/placementgroup"
+	"github.com/aws/karpenter-provider-aws/pkg/fake"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	coreoptions "sigs.k8s.io/karpenter/pkg/operator/options"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+	. "sigs.k8s.io/karpenter/pkg/utils/testing"
+)
+
+var ctx context.Context
+var awsEnv *test.Environment
+var env *coretest.Environment
+var placementGroupController *placementgroup.Controller
+
+func TestAPIs(t *testing.T) {
+	ctx = TestContextWithLogger(t)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PlacementGroupController")
+}
+
+var _ = BeforeSuite(func() {
+	env = coretest.NewEnvironment(coretest.WithCRDs(apis.CRDs...), coretest.WithCRDs(v1alpha1.CRDs...))
+	ctx = coreoptions.ToContext(ctx, coretest.Options(coretest.OptionsFields{FeatureGates: coretest.FeatureGates{ReservedCapacity: lo.ToPtr(true)}}))
+	ctx = options.ToContext(ctx, test.Options())
+	awsEnv = test.NewEnvironment(ctx, env)
+	cloudProvider := cloudprovider.New(awsEnv.InstanceTypesProvider, awsEnv.InstanceProvider, events.NewRecorder(&record.FakeRecorder{}),
+		env.Client, awsEnv.AMIProvider, awsEnv.SecurityGroupProvider, awsEnv.CapacityReservationProvider)
+	placementGroupController = placementgroup.NewController(env.Client, cloudProvider, awsEnv.InstanceProvider)
+})
+var _ = AfterSuite(func() {
+	Expect(env.Stop()).To(Succeed(), "Failed to stop environment")
+})
+
+var _ = BeforeEach(func() {
+	awsEnv.Reset()
+})
+
+var _ = AfterEach(func() {
+	ExpectCleanedUp(ctx, env.Client)
+})
+
+var _ = Describe("PlacementGroupController", func() {
+	var nodeClass *v1.EC2NodeClass
+	var nodeClaim *karpv1.NodeClaim
+	var node *corev1.Node
+	BeforeEach(func() {
+		nodeClass = test.EC2NodeClass(v1.EC2NodeClass{
+			Status: v1.EC2NodeClassStatus{
+				PlacementGroup: &v1.PlacementGroup{ID: "pg-test1", Name: "placementGroup-test1", Strategy: v1.PlacementGroupStrategyPartition, PartitionCount: 3},
+			},
+		})
+		instanceID := fake.InstanceID()
+		awsEnv.EC2API.Instances.Store(instanceID, ec2types.Instance{
+			InstanceId:   aws.String(instanceID),
+			InstanceType: "m5.large",
+			State:        &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning},
+			Placement: &ec2types.Placement{
+				AvailabilityZone: aws.String(fake.DefaultRegion),
+				GroupId:          aws.String("pg-test1"),
+				PartitionNumber:  lo.ToPtr[int32](2),
+			},
+		})
+		nodeClaim = coretest.NodeClaim(karpv1.NodeClaim{
+			Spec: karpv1.NodeClaimSpec{
+				NodeClassRef: &karpv1.NodeClassReference{
+					Group: object.GVK(nodeClass).Group,
+					Kind:  object.GVK(nodeClass).Kind,
+					Name:  nodeClass.Name,
+				},
+			},
+			Status: karpv1.NodeClaimStatus{
+				ProviderID: fake.ProviderID(instanceID),
+			},
+		})
+		node = coretest.NodeClaimLinkedNode(nodeClaim)
+		node.Labels[karpv1.NodeRegisteredLabelKey] = "true"
+	})
+	It("should label the NodeClaim and its Node with the partition", func() {
+		ExpectApplied(ctx, env.Client, nodeClass, nodeClaim, node)
+		ExpectObjectReconciled(ctx, env.Client, placementGroupController, nodeClaim)
+		nodeClaim = ExpectExists(ctx, env.Client, nodeClaim)
+		Expect(nodeClaim.Labels).To(HaveKeyWithValue(v1.LabelPlacementGroupPartition, "2"))
+		node = ExpectExists(ctx, env.Client, node)
+		Expect(node.Labels).To(HaveKeyWithValue(v1.LabelPlacementGroupPartition, "2"))
+	})
+	It("should label a registered Node from an already labeled NodeClaim", func() {
+		nodeClaim.Labels[v1.LabelPlacementGroupPartition] = "3"
+		ExpectApplied(ctx, env.Client, nodeClass, nodeClaim, node)
+		ExpectObjectReconciled(ctx, env.Client, placementGroupController, nodeClaim)
+		node = ExpectExists(ctx, env.Client, node)
+		Expect(node.Labels).To(HaveKeyWithValue(v1.LabelPlacementGroupPartition, "3"))
+	})
+	It("should not label a Node which hasn't registered", func() {
+		delete(node.Labels, karpv1.NodeRegisteredLabelKey)
+		ExpectApplied(ctx, env.Client, nodeClass, nodeClaim, node)
+		ExpectObjectReconciled(ctx, env.Client, placementGroupController, nodeClaim)
+		nodeClaim = ExpectExists(ctx, env.Client, nodeClaim)
+		Expect(nodeClaim.Labels).To(HaveKeyWithValue(v1.LabelPlacementGroupPartition, "2"))
+		node = ExpectExists(ctx, env.Client, node)
+		Expect(node.Labels).ToNot(HaveKey(v1.LabelPlacementGroupPartition))
+	})
+	It("should not label NodeClaims for an EC2NodeClass without a partition placement group", func() {
+		nodeClass.Status.PlacementGroup.Strategy = v1.PlacementGroupStrategyCluster
+		ExpectApplied(ctx, env.Client, nodeClass, nodeClaim, node)
+		ExpectObjectReconciled(ctx, env.Client, placementGroupController, nodeClaim)
+		nodeClaim = ExpectExists(ctx, env.Client, nodeClaim)
+		Expect(nodeClaim.Labels).ToNot(HaveKey(v1.LabelPlacementGroupPartition))
+		Expect(awsEnv.EC2API.DescribeInstancesBehavior.Calls()).To(Equal(0))
+	})
+})
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/klog/v2"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -73,6 +74,9 @@ func (c *Controller) Reconcile(ctx context.Context, nodeClaim *karpv1.NodeClaim)
 		log.FromContext(ctx).Error(err, "failed parsing instance id")
 		return reconcile.Result{}, nil
 	}
+	if err = c.releaseWarmPoolNode(ctx, nodeClaim); err != nil {
+		return reconcile.Result{}, err
+	}
 	if err = c.tagInstance(ctx, nodeClaim, id); err != nil {
 		return reconcile.Result{}, cloudprovider.IgnoreNodeClaimNotFoundError(err)
 	}
@@ -114,6 +118,12 @@ func (c *Controller) tagInstance(ctx context.Context, nc *karpv1.NodeClaim, id s
 	if err != nil {
 		return fmt.Errorf("tagging nodeclaim, %w", err)
 	}
+	// The warm pool tag is removed when an instance is claimed from the warm pool, but that removal is best-effort
+	if instance.WarmPool != "" {
+		if err := c.instanceProvider.DeleteTags(ctx, id, []string{v1.WarmPoolTagKey}); err != nil {
+			return fmt.Errorf("untagging nodeclaim, %w", err)
+		}
+	}
 	tags = lo.OmitByKeys(tags, lo.Keys(instance.Tags))
 	if len(tags) == 0 {
 		return nil
@@ -128,6 +138,25 @@ func (c *Controller) tagInstance(ctx context.Context, nc *karpv1.NodeClaim, id s
 	return nil
 }
 
+// releaseWarmPoolNode uncordons and removes the warm pool taint from the Node of a hibernated warm pool member that was
+// claimed by the NodeClaim, since the member's Node is kept while it's hibernated
+func (c *Controller) releaseWarmPoolNode(ctx context.Context, nc *karpv1.NodeClaim) error {
+	node := &corev1.Node{}
+	if err := c.kubeClient.Get(ctx, client.ObjectKey{Name: nc.Status.NodeName}, node); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !lo.ContainsBy(node.Spec.Taints, func(t corev1.Taint) bool { return t.MatchTaint(&v1.WarmPoolNoScheduleTaint) }) {
+		return nil
+	}
+	stored := node.DeepCopy()
+	node.Spec.Unschedulable = false
+	node.Spec.Taints = lo.Reject(node.Spec.Taints, func(t corev1.Taint, _ int) bool { return t.MatchTaint(&v1.WarmPoolNoScheduleTaint) })
+	if err := c.kubeClient.Patch(ctx, node, client.MergeFrom(stored)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("releasing warm pool node, %w", err)
+	}
+	return nil
+}
+
 func isTaggable(nc *karpv1.NodeClaim) bool {
 	// Instance has already been tagged
 	instanceTagged := nc.Annotations[v1.AnnotationInstanceTagged]
@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/samber/lo"
+	k8sv1 "k8s.io/api/core/v1"
 	corev1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
@@ -190,6 +191,26 @@ var _ = Describe("TaggingController", func() {
 		})).To(BeFalse())
 	})
 
+	It("should release the node of a claimed warm pool instance", func() {
+		node := coretest.Node(coretest.NodeOptions{
+			ProviderID: fake.ProviderID(*ec2Instance.InstanceId),
+			Taints:     []k8sv1.Taint{v1.WarmPoolNoScheduleTaint},
+		})
+		node.Spec.Unschedulable = true
+		nodeClaim := coretest.NodeClaim(karpv1.NodeClaim{
+			Status: karpv1.NodeClaimStatus{
+				ProviderID: fake.ProviderID(*ec2Instance.InstanceId),
+				NodeName:   node.Name,
+			},
+		})
+
+		ExpectApplied(ctx, env.Client, node, nodeClaim)
+		ExpectObjectReconciled(ctx, env.Client, taggingController, nodeClaim)
+		node = ExpectExists(ctx, env.Client, node)
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(node.Spec.Taints).ToNot(ContainElement(v1.WarmPoolNoScheduleTaint))
+	})
+
 	DescribeTable(
 		"should tag taggable instances",
 		func(customTags ...string) {
@@ -20,27 +20,46 @@ import (
 	"sort"
 	"time"
 
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/events"
+	nodeclaimutils "sigs.k8s.io/karpenter/pkg/utils/nodeclaim"
 	"sigs.k8s.io/karpenter/pkg/utils/pretty"
 
 	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	sdk "github.com/aws/karpenter-provider-aws/pkg/aws"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/amifamily"
 )
 
 type AMI struct {
-	amiProvider amifamily.Provider
-	cm          *pretty.ChangeMonitor
+	clk           clock.Clock
+	kubeClient    client.Client
+	recorder      events.Recorder
+	amiProvider   amifamily.Provider
+	healthTracker *amifamily.HealthTracker
+	ec2api        sdk.EC2API
+	cm            *pretty.ChangeMonitor
 }
 
-func NewAMIReconciler(provider amifamily.Provider) *AMI {
+func NewAMIReconciler(clk clock.Clock, kubeClient client.Client, recorder events.Recorder, provider amifamily.Provider, healthTracker *amifamily.HealthTracker, ec2api sdk.EC2API) *AMI {
 	return &AMI{
-		amiProvider: provider,
-		cm:          pretty.NewChangeMonitor(),
+		clk:           clk,
+		kubeClient:    kubeClient,
+		recorder:      recorder,
+		amiProvider:   provider,
+		healthTracker: healthTracker,
+		ec2api:        ec2api,
+		cm:            pretty.NewChangeMonitor(),
 	}
 }
 
@@ -56,13 +75,28 @@ func (a *AMI) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass) (reconc
 		// Returning 'ok' in this case means that the nodeclass will remain in an unready state until the component is restarted.
 		return reconcile.Result{RequeueAfter: time.Minute}, nil
 	}
+	// AMIs discovered in another region are replaced with their local copies, starting the copies if needed. Copies are
+	// listed while replication is configured, and afterwards until they've all been cleaned up.
+	sources := amis
+	var copies []ec2types.Image
+	if lo.ContainsBy(nodeClass.Spec.AMISelectorTerms, func(term v1.AMISelectorTerm) bool { return term.Replication != nil }) ||
+		len(nodeClass.Status.AMICopies) != 0 {
+		if copies, err = a.listCopies(ctx, nodeClass); err != nil {
+			return reconcile.Result{}, fmt.Errorf("listing ami copies, %w", err)
+		}
+	}
+	amis, copies, pending, err := a.replicate(ctx, nodeClass, amis, copies)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("replicating amis, %w", err)
+	}
+	nodeClass.Status.AMICopies = copyIDs(copies)
 	if uniqueAMIs := lo.Uniq(lo.Map(amis, func(a amifamily.AMI, _ int) string {
 		return a.AmiID
 	})); a.cm.HasChanged(fmt.Sprintf("amis/%s", nodeClass.Name), uniqueAMIs) {
 		log.FromContext(ctx).WithValues("ids", uniqueAMIs).V(1).Info("discovered amis")
 	}
 
-	nodeClass.Status.AMIs = lo.Map(amis, func(ami amifamily.AMI, _ int) v1.AMI {
+	resolved := lo.Map(amis, func(ami amifamily.AMI, _ int) v1.AMI {
 		reqs := lo.Map(ami.Requirements.NodeSelectorRequirements(), func(item karpv1.NodeSelectorRequirementWithMinValues, _ int) corev1.NodeSelectorRequirement {
 			return item.NodeSelectorRequirement
 		})
@@ -80,7 +114,197 @@ func (a *AMI) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass) (reconc
 			Requirements: reqs,
 		}
 	})
+	if pending {
+		// Keep the previously resolved AMIs while copies are pending so that launches can continue to use them
+		for _, ami := range nodeClass.Status.AMIs {
+			if !lo.ContainsBy(resolved, func(r v1.AMI) bool { return equality.Semantic.DeepEqual(r.Requirements, ami.Requirements) }) {
+				resolved = append(resolved, v1.AMI{Name: ami.Name, ID: ami.ID, Deprecated: ami.Deprecated, Requirements: ami.Requirements})
+			}
+		}
+		if len(resolved) == 0 {
+			nodeClass.Status.AMIs = nil
+			nodeClass.StatusConditions().SetFalse(v1.ConditionTypeAMIsReady, "AMIReplicationPending", "Waiting for AMIs to be copied from their source region")
+			return reconcile.Result{RequeueAfter: time.Minute}, nil
+		}
+	}
+	if resolved = a.excludeUnhealthy(nodeClass, resolved); len(resolved) == 0 {
+		nodeClass.Status.AMIs = nil
+		nodeClass.StatusConditions().SetFalse(v1.ConditionTypeAMIsReady, "AMIsUnhealthy", "All AMIs selected by AMISelector are unhealthy")
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
 
+	if nodeClass.Spec.AMIRolloutPolicy == nil {
+		nodeClass.Status.AMIs = resolved
+	} else if nodeClass.Status.AMIs, err = a.rollout(ctx, nodeClass, resolved); err != nil {
+		return reconcile.Result{}, fmt.Errorf("rolling out amis, %w", err)
+	}
 	nodeClass.StatusConditions().SetTrue(v1.ConditionTypeAMIsReady)
+	err = a.deleteObsoleteCopies(ctx, nodeClass, copies, sources)
+	nodeClass.Status.AMICopies = copyIDs(copies)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("deleting obsolete ami copies, %w", err)
+	}
+	// Canary failures need to be observed before the NodeClaims are deleted, so we requeue more frequently while an AMI
+	// is being rolled out. Pending copies are also checked more frequently so that they're used once available.
+	if pending || lo.ContainsBy(nodeClass.Status.AMIs, func(ami v1.AMI) bool { return ami.RolloutPhase == v1.AMIRolloutPhaseCanary }) {
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
 	return reconcile.Result{RequeueAfter: 5 * time.Minute}, nil
 }
+
+// excludeUnhealthy replaces each resolved AMI whose NodeClaims are failing to become ready with the known good AMI
+// that has the same requirements, if that AMI is healthy. If there's no such AMI, the unhealthy AMI is dropped.
+// Exclusions and known good AMIs are persisted in the status, since the outcomes of NodeClaims are only tracked in
+// memory and the previously resolved AMIs are replaced once a new AMI is resolved.
+// nolint:gocyclo
+func (a *AMI) excludeUnhealthy(nodeClass *v1.EC2NodeClass, resolved []v1.AMI) []v1.AMI {
+	if nodeClass.Spec.AMIHealthPolicy == nil {
+		nodeClass.Status.AMIHealth = nil
+		return resolved
+	}
+	health := lo.FromPtr(nodeClass.Status.AMIHealth)
+	health.Excluded = lo.Filter(health.Excluded, func(e v1.ExcludedAMI, _ int) bool {
+		return a.clk.Since(e.ExclusionTime.Time) < amifamily.HealthTrackerTTL
+	})
+	excluded := func(id string) bool {
+		return lo.ContainsBy(health.Excluded, func(e v1.ExcludedAMI) bool { return e.ID == id })
+	}
+	healthy := func(id string) bool { return !excluded(id) && nodeClass.IsAMIHealthy(a.healthTracker.Outcomes(id)) }
+	for _, ami := range resolved {
+		if excluded(ami.ID) || healthy(ami.ID) {
+			continue
+		}
+		total, failed := a.healthTracker.Outcomes(ami.ID)
+		a.recorder.Publish(AMIExcludedEvent(nodeClass, ami.ID, failed, total))
+		health.Excluded = append(health.Excluded, v1.ExcludedAMI{ID: ami.ID, ExclusionTime: metav1.NewTime(a.clk.Now())})
+	}
+
+	sameRequirements := func(x, y v1.AMI) bool { return equality.Semantic.DeepEqual(x.Requirements, y.Requirements) }
+	var out []v1.AMI
+	for _, ami := range resolved {
+		if healthy(ami.ID) {
+			out = append(out, ami)
+			continue
+		}
+		// The previously resolved AMIs are also considered so that AMIs resolved before the known good AMIs were
+		// recorded can be rolled back to
+		fallback, ok := lo.Find(lo.Flatten([][]v1.AMI{health.KnownGood, nodeClass.Status.AMIs}), func(prev v1.AMI) bool {
+			return prev.ID != ami.ID && healthy(prev.ID) && sameRequirements(prev, ami)
+		})
+		if ok && !lo.ContainsBy(out, func(o v1.AMI) bool { return o.ID == fallback.ID }) {
+			out = append(out, v1.AMI{Name: fallback.Name, ID: fallback.ID, Deprecated: fallback.Deprecated, Requirements: fallback.Requirements})
+		}
+	}
+
+	// An AMI becomes known good when there's no known good AMI for its requirements yet, or once a NodeClaim launched
+	// from it has become ready. Known good AMIs for requirements that are no longer resolved are dropped.
+	var knownGood []v1.AMI
+	for _, ami := range out {
+		known, ok := lo.Find(health.KnownGood, func(k v1.AMI) bool { return sameRequirements(k, ami) })
+		total, failed := a.healthTracker.Outcomes(ami.ID)
+		if ok && known.ID != ami.ID && total == failed && healthy(known.ID) {
+			ami = known
+		}
+		if !lo.ContainsBy(knownGood, func(k v1.AMI) bool { return sameRequirements(k, ami) }) {
+			knownGood = append(knownGood, v1.AMI{Name: ami.Name, ID: ami.ID, Deprecated: ami.Deprecated, Requirements: ami.Requirements})
+		}
+	}
+	// The known good AMIs are retained when every AMI is unhealthy, so that they can be used once they recover
+	if len(knownGood) != 0 {
+		health.KnownGood = knownGood
+	}
+	nodeClass.Status.AMIHealth = &health
+	return out
+}
+
+// rollout assigns rollout phases to the resolved AMIs. AMIs that weren't previously resolved are canaries until
+// they've baked, or until too many canary NodeClaims have failed, at which point the rollout is halted. The
+// previously stable AMIs are kept until the rollout completes so they can continue to be used by other launches.
+func (a *AMI) rollout(ctx context.Context, nodeClass *v1.EC2NodeClass, resolved []v1.AMI) ([]v1.AMI, error) {
+	previous := lo.SliceToMap(nodeClass.Status.AMIs, func(ami v1.AMI) (string, v1.AMI) { return ami.ID, ami })
+	for i := range resolved {
+		prev, ok := previous[resolved[i].ID]
+		switch {
+		// There's nothing to roll out from when AMIs are first resolved
+		case len(previous) == 0:
+			resolved[i].RolloutPhase = v1.AMIRolloutPhaseStable
+		case !ok:
+			resolved[i].RolloutPhase = v1.AMIRolloutPhaseCanary
+			resolved[i].RolloutStartTime = lo.ToPtr(metav1.NewTime(a.clk.Now()))
+		// AMIs that were resolved before the rollout policy was configured don't have a phase
+		case prev.RolloutPhase == "":
+			resolved[i].RolloutPhase = v1.AMIRolloutPhaseStable
+		default:
+			resolved[i].RolloutPhase = prev.RolloutPhase
+			resolved[i].RolloutStartTime = prev.RolloutStartTime
+			resolved[i].RolloutFailures = prev.RolloutFailures
+			resolved[i].RolloutFailuresObservedTime = prev.RolloutFailuresObservedTime
+		}
+	}
+	if lo.ContainsBy(resolved, func(ami v1.AMI) bool { return ami.RolloutPhase == v1.AMIRolloutPhaseCanary }) {
+		if err := a.countCanaryFailures(ctx, nodeClass, resolved); err != nil {
+			return nil, err
+		}
+	}
+	for i := range resolved {
+		if resolved[i].RolloutPhase != v1.AMIRolloutPhaseCanary {
+			continue
+		}
+		switch {
+		case int(resolved[i].RolloutFailures) >= nodeClass.AMIRolloutFailureThreshold():
+			resolved[i].RolloutPhase = v1.AMIRolloutPhaseHalted
+			log.FromContext(ctx).WithValues("id", resolved[i].ID, "failures", resolved[i].RolloutFailures).Info("halted ami rollout")
+		case a.clk.Since(resolved[i].RolloutStartTime.Time) >= nodeClass.Spec.AMIRolloutPolicy.BakeTime.Duration:
+			resolved[i].RolloutPhase = v1.AMIRolloutPhaseStable
+			resolved[i].RolloutStartTime = nil
+			resolved[i].RolloutFailures = 0
+			resolved[i].RolloutFailuresObservedTime = nil
+			log.FromContext(ctx).WithValues("id", resolved[i].ID).Info("promoted ami")
+		}
+	}
+	if !lo.ContainsBy(resolved, func(ami v1.AMI) bool { return ami.RolloutPhase != v1.AMIRolloutPhaseStable }) {
+		return resolved, nil
+	}
+	// Keep the previously stable AMIs that are no longer resolved, since they're still used for non-canary launches
+	resolvedIDs := sets.New(lo.Map(resolved, func(ami v1.AMI, _ int) string { return ami.ID })...)
+	for _, ami := range nodeClass.Status.AMIs {
+		if resolvedIDs.Has(ami.ID) || (ami.RolloutPhase != v1.AMIRolloutPhaseStable && ami.RolloutPhase != "") {
+			continue
+		}
+		ami.RolloutPhase = v1.AMIRolloutPhaseStable
+		resolved = append(resolved, ami)
+	}
+	return resolved, nil
+}
+
+// countCanaryFailures adds the canary NodeClaims that have failed to launch or to become ready since the failures were
+// last counted to the failures of the canary AMIs they were launched from. The failures are persisted in the status
+// since the NodeClaims are deleted once their registration TTL expires. NodeClaims are judged the same way as by the
+// AMI health tracker, so a failed launch is attributed to the canary AMIs its launch templates were resolved with.
+func (a *AMI) countCanaryFailures(ctx context.Context, nodeClass *v1.EC2NodeClass, amis []v1.AMI) error {
+	nodeClaims := &karpv1.NodeClaimList{}
+	if err := a.kubeClient.List(ctx, nodeClaims, nodeclaimutils.ForNodeClass(nodeClass)); err != nil {
+		return fmt.Errorf("listing nodeclaims, %w", err)
+	}
+	now := metav1.NewTime(a.clk.Now())
+	for i := range amis {
+		if amis[i].RolloutPhase != v1.AMIRolloutPhaseCanary {
+			continue
+		}
+		observed := lo.FromPtrOr(amis[i].RolloutFailuresObservedTime, *amis[i].RolloutStartTime)
+		failures := lo.CountBy(nodeClaims.Items, func(nodeClaim karpv1.NodeClaim) bool {
+			if nodeClaim.CreationTimestamp.Before(amis[i].RolloutStartTime) {
+				return false
+			}
+			// A NodeClaim is only counted once, by the first reconcile after it has failed
+			outcome, ok := amifamily.Outcome(nodeClass, &nodeClaim, now.Time)
+			return ok && outcome.Decided && !outcome.Healthy && lo.Contains(outcome.AMIs, amis[i].ID) && outcome.Time.After(observed.Time)
+		})
+		// The observed time is only advanced when failures are counted, since status updates requeue the EC2NodeClass
+		if failures != 0 {
+			amis[i].RolloutFailures += int32(failures)
+			amis[i].RolloutFailuresObservedTime = &now
+		}
+	}
+	return nil
+}
@@ -21,11 +21,16 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/awslabs/operatorpkg/object"
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	coretest "sigs.k8s.io/karpenter/pkg/test"
 
 	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/amifamily"
 	"github.com/aws/karpenter-provider-aws/pkg/test"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -176,6 +181,11 @@ var _ = Describe("NodeClass AMI Status Controller", func() {
 							Key:      v1.LabelInstanceGPUCount,
 							Operator: corev1.NodeSelectorOpExists,
 						},
+						{
+							Key:      v1.LabelInstanceGPUManufacturer,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"nvidia"},
+						},
 					},
 				},
 				{
@@ -225,6 +235,11 @@ var _ = Describe("NodeClass AMI Status Controller", func() {
 							Key:      v1.LabelInstanceGPUCount,
 							Operator: corev1.NodeSelectorOpExists,
 						},
+						{
+							Key:      v1.LabelInstanceGPUManufacturer,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"nvidia"},
+						},
 					},
 				},
 			}))
@@ -275,6 +290,11 @@ var _ = Describe("NodeClass AMI Status Controller", func() {
 							Key:      v1.LabelInstanceGPUCount,
 							Operator: corev1.NodeSelectorOpExists,
 						},
+						{
+							Key:      v1.LabelInstanceGPUManufacturer,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"nvidia"},
+						},
 					},
 				},
 				// Note: AL2 uses the same AMI for nvidia and neuron, we use the nvidia AMI here
@@ -396,6 +416,11 @@ var _ = Describe("NodeClass AMI Status Controller", func() {
 							Key:      v1.LabelInstanceGPUCount,
 							Operator: corev1.NodeSelectorOpExists,
 						},
+						{
+							Key:      v1.LabelInstanceGPUManufacturer,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"nvidia"},
+						},
 					},
 				},
 				{
@@ -411,6 +436,11 @@ var _ = Describe("NodeClass AMI Status Controller", func() {
 							Key:      v1.LabelInstanceGPUCount,
 							Operator: corev1.NodeSelectorOpExists,
 						},
+						{
+							Key:      v1.LabelInstanceGPUManufacturer,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"nvidia"},
+						},
 					},
 				},
 			}))
@@ -486,6 +516,41 @@ var _ = Describe("NodeClass AMI Status Controller", func() {
 			}))
 			Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeAMIsReady)).To(BeTrue())
 		})
+		It("Should resolve all AMIs with correct requirements for Windows2025", func() {
+			awsEnv.SSMAPI.Parameters = map[string]string{
+				fmt.Sprintf("/aws/service/ami-windows-latest/Windows_Server-2025-English-Core-EKS_Optimized-%s/image_id", k8sVersion): "ami-amd64-standard",
+			}
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "windows2025@latest"}}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+
+			Expect(len(nodeClass.Status.AMIs)).To(Equal(1))
+			Expect(nodeClass.Status.AMIs).To(ContainElements([]v1.AMI{
+				{
+					Name: "amd64-standard",
+					ID:   "ami-amd64-standard",
+					Requirements: []corev1.NodeSelectorRequirement{
+						{
+							Key:      corev1.LabelOSStable,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{string(corev1.Windows)},
+						},
+						{
+							Key:      corev1.LabelArchStable,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{karpv1.ArchitectureAmd64},
+						},
+						{
+							Key:      corev1.LabelWindowsBuild,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{v1.Windows2025Build},
+						},
+					},
+				},
+			}))
+			Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeAMIsReady)).To(BeTrue())
+		})
 	})
 	It("should resolve amiSelector AMIs and requirements into status when all SSM parameters don't resolve", func() {
 		// This parameter set doesn't include any of the Nvidia AMIs
@@ -781,4 +846,471 @@ var _ = Describe("NodeClass AMI Status Controller", func() {
 			Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeAMIsReady)).To(BeTrue())
 		})
 	})
+	Context("AMI Rollout", func() {
+		image := func(name, id string) ec2types.Image {
+			return ec2types.Image{
+				Name:         aws.String(name),
+				ImageId:      aws.String(id),
+				CreationDate: aws.String("2021-08-31T00:12:42.000Z"),
+				Architecture: "x86_64",
+				Tags:         []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("rollout")}},
+				State:        ec2types.ImageStateAvailable,
+			}
+		}
+		canaryNodeClaim := func() *karpv1.NodeClaim {
+			return coretest.NodeClaim(karpv1.NodeClaim{
+				Spec: karpv1.NodeClaimSpec{
+					NodeClassRef: &karpv1.NodeClassReference{
+						Group: object.GVK(nodeClass).Group,
+						Kind:  object.GVK(nodeClass).Kind,
+						Name:  nodeClass.Name,
+					},
+				},
+			})
+		}
+		BeforeEach(func() {
+			// NodeClaim creation timestamps are truncated to the second, so start the rollout in the past to ensure
+			// the NodeClaims created by the tests are attributed to it
+			awsEnv.Clock.SetTime(time.Now().Add(-time.Minute))
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Tags: map[string]string{"Name": "rollout"}}}
+			nodeClass.Spec.AMIRolloutPolicy = &v1.AMIRolloutPolicy{
+				CanaryPercentage: 100,
+				BakeTime:         metav1.Duration{Duration: time.Hour},
+			}
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{image("previous", "ami-previous")}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+
+			// The SSM parameter or AMI selector now resolves to a new AMI
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{image("new", "ami-new")}})
+			awsEnv.EC2Cache.Flush()
+		})
+		It("should mark the first resolved AMIs as stable", func() {
+			Expect(nodeClass.Status.AMIs).To(HaveLen(1))
+			Expect(nodeClass.Status.AMIs[0].ID).To(Equal("ami-previous"))
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseStable))
+			Expect(nodeClass.Status.AMIs[0].RolloutStartTime).To(BeNil())
+		})
+		It("should keep the previous AMI while the new AMI is a canary", func() {
+			res := ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			Expect(res.RequeueAfter).To(Equal(time.Minute))
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-new", "ami-previous"}))
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseCanary))
+			Expect(nodeClass.Status.AMIs[0].RolloutStartTime).ToNot(BeNil())
+			Expect(nodeClass.Status.AMIs[1].RolloutPhase).To(Equal(v1.AMIRolloutPhaseStable))
+		})
+		It("should promote the new AMI after the bake time", func() {
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			awsEnv.Clock.Step(time.Hour)
+			res := ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			Expect(res.RequeueAfter).To(Equal(5 * time.Minute))
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs).To(HaveLen(1))
+			Expect(nodeClass.Status.AMIs[0].ID).To(Equal("ami-new"))
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseStable))
+			Expect(nodeClass.Status.AMIs[0].RolloutStartTime).To(BeNil())
+		})
+		It("should halt the rollout when a canary NodeClaim fails to launch", func() {
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			nodeClaim := canaryNodeClaim()
+			nodeClaim.StatusConditions().SetUnknownWithReason(karpv1.ConditionTypeLaunched, "LaunchFailed", "invalid block device mapping")
+			ExpectApplied(ctx, env.Client, nodeClaim)
+
+			// The launch may still succeed within the ready timeout
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseCanary))
+
+			awsEnv.Clock.Step(15 * time.Minute)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseHalted))
+			Expect(nodeClass.Status.AMIs[0].RolloutFailures).To(BeNumerically("==", 1))
+			Expect(nodeClass.Status.AMIs[1].RolloutPhase).To(Equal(v1.AMIRolloutPhaseStable))
+		})
+		It("should not count canary NodeClaims that are awaiting their launch", func() {
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClaim := canaryNodeClaim()
+			nodeClaim.StatusConditions().SetUnknown(karpv1.ConditionTypeLaunched)
+			ExpectApplied(ctx, env.Client, nodeClaim)
+
+			awsEnv.Clock.Step(15 * time.Minute)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseCanary))
+			Expect(nodeClass.Status.AMIs[0].RolloutFailures).To(BeZero())
+		})
+		It("should halt the rollout when a canary NodeClaim fails to become ready", func() {
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClaim := canaryNodeClaim()
+			nodeClaim.Status.ImageID = "ami-new"
+			nodeClaim.StatusConditions().SetTrue(karpv1.ConditionTypeLaunched)
+			ExpectApplied(ctx, env.Client, nodeClaim)
+
+			// The NodeClaim is still within its ready timeout
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseCanary))
+
+			awsEnv.Clock.Step(15 * time.Minute)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseHalted))
+			Expect(nodeClass.Status.AMIs[0].RolloutFailures).To(BeNumerically("==", 1))
+			Expect(nodeClass.Status.AMIs[1].RolloutPhase).To(Equal(v1.AMIRolloutPhaseStable))
+		})
+		It("should not count NodeClaims launched from other AMIs", func() {
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClaim := canaryNodeClaim()
+			nodeClaim.Status.ImageID = "ami-previous"
+			nodeClaim.StatusConditions().SetTrue(karpv1.ConditionTypeLaunched)
+			ExpectApplied(ctx, env.Client, nodeClaim)
+
+			awsEnv.Clock.Step(15 * time.Minute)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseCanary))
+			Expect(nodeClass.Status.AMIs[0].RolloutFailures).To(BeZero())
+		})
+		It("should keep counting failures after the failed NodeClaims are deleted", func() {
+			nodeClass.Spec.AMIRolloutPolicy.FailureThreshold = lo.ToPtr[int32](2)
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClaim := canaryNodeClaim()
+			nodeClaim.Status.ImageID = "ami-new"
+			nodeClaim.StatusConditions().SetTrue(karpv1.ConditionTypeLaunched)
+			ExpectApplied(ctx, env.Client, nodeClaim)
+
+			awsEnv.Clock.Step(15 * time.Minute)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			// The failed NodeClaim isn't counted again
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseCanary))
+			Expect(nodeClass.Status.AMIs[0].RolloutFailures).To(BeNumerically("==", 1))
+
+			// The failure is persisted once the NodeClaim is deleted after its registration TTL
+			ExpectDeleted(ctx, env.Client, nodeClaim)
+			nodeClaim = canaryNodeClaim()
+			nodeClaim.Status.ImageID = "ami-new"
+			nodeClaim.StatusConditions().SetTrue(karpv1.ConditionTypeLaunched)
+			for i := range nodeClaim.Status.Conditions {
+				nodeClaim.Status.Conditions[i].LastTransitionTime = metav1.NewTime(awsEnv.Clock.Now())
+			}
+			ExpectApplied(ctx, env.Client, nodeClaim)
+			awsEnv.Clock.Step(15 * time.Minute)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(Equal(v1.AMIRolloutPhaseHalted))
+			Expect(nodeClass.Status.AMIs[0].RolloutFailures).To(BeNumerically("==", 2))
+		})
+		It("should not assign rollout phases without a rollout policy", func() {
+			nodeClass.Spec.AMIRolloutPolicy = nil
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs).To(HaveLen(1))
+			Expect(nodeClass.Status.AMIs[0].ID).To(Equal("ami-new"))
+			Expect(nodeClass.Status.AMIs[0].RolloutPhase).To(BeEmpty())
+		})
+	})
+	Context("AMI Health", func() {
+		image := func(name, id string) ec2types.Image {
+			return ec2types.Image{
+				Name:         aws.String(name),
+				ImageId:      aws.String(id),
+				CreationDate: aws.String("2021-08-31T00:12:42.000Z"),
+				Architecture: "x86_64",
+				Tags:         []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("health")}},
+				State:        ec2types.ImageStateAvailable,
+			}
+		}
+		record := func(amiID string, healthy, failed int) {
+			for i := 0; i < healthy; i++ {
+				awsEnv.AMIHealthTracker.Record(amiID, types.UID(fmt.Sprintf("healthy-%d", i)), true)
+			}
+			for i := 0; i < failed; i++ {
+				awsEnv.AMIHealthTracker.Record(amiID, types.UID(fmt.Sprintf("failed-%d", i)), false)
+			}
+		}
+		BeforeEach(func() {
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Tags: map[string]string{"Name": "health"}}}
+			nodeClass.Spec.AMIHealthPolicy = &v1.AMIHealthPolicy{}
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{image("previous", "ami-previous")}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{image("new", "ami-new")}})
+			awsEnv.EC2Cache.Flush()
+		})
+		It("should keep a healthy AMI", func() {
+			record("ami-new", 3, 2)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-new"}))
+			Expect(lo.Map(nodeClass.Status.AMIHealth.KnownGood, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-new"}))
+		})
+		It("should roll back to the known good AMI when the new AMI becomes unhealthy after it was resolved", func() {
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-new"}))
+			// The new AMI isn't known good until a NodeClaim launched from it becomes ready
+			Expect(lo.Map(nodeClass.Status.AMIHealth.KnownGood, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-previous"}))
+
+			record("ami-new", 0, 3)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-previous"}))
+			Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeAMIsReady)).To(BeTrue())
+		})
+		It("should keep excluding an AMI after its outcomes are lost", func() {
+			record("ami-new", 1, 3)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIHealth.Excluded, func(ami v1.ExcludedAMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-new"}))
+
+			// Outcomes are only tracked in memory, so they're lost when the controller restarts
+			awsEnv.AMIHealthTracker.Reset()
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-previous"}))
+
+			// The exclusion expires with the outcomes it was based on
+			awsEnv.Clock.Step(amifamily.HealthTrackerTTL)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-new"}))
+			Expect(nodeClass.Status.AMIHealth.Excluded).To(BeEmpty())
+		})
+		It("should roll back to the previous AMI when the new AMI is unhealthy", func() {
+			record("ami-new", 1, 3)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-previous"}))
+			Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeAMIsReady)).To(BeTrue())
+
+			// The rollback is retained on subsequent reconciles
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-previous"}))
+		})
+		It("should respect the configured failure thresholds", func() {
+			nodeClass.Spec.AMIHealthPolicy = &v1.AMIHealthPolicy{MinFailures: lo.ToPtr[int32](5), MaxFailurePercentage: lo.ToPtr[int32](10)}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			record("ami-new", 0, 4)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-new"}))
+
+			record("ami-new", 0, 5)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-previous"}))
+		})
+		It("should not be ready when there's no healthy AMI to roll back to", func() {
+			record("ami-new", 0, 3)
+			record("ami-previous", 0, 3)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs).To(BeEmpty())
+			Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeAMIsReady).IsFalse()).To(BeTrue())
+			Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeAMIsReady).Reason).To(Equal("AMIsUnhealthy"))
+		})
+		It("should not exclude unhealthy AMIs without a health policy", func() {
+			nodeClass.Spec.AMIHealthPolicy = nil
+			ExpectApplied(ctx, env.Client, nodeClass)
+			record("ami-new", 0, 3)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-new"}))
+		})
+	})
+	Context("AMI Replication", func() {
+		source := func(id string, created time.Time) ec2types.Image {
+			return ec2types.Image{
+				Name:         aws.String(id),
+				ImageId:      aws.String(id),
+				CreationDate: aws.String(created.Format(time.RFC3339)),
+				Architecture: "x86_64",
+				Tags:         []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("replicated")}},
+				State:        ec2types.ImageStateAvailable,
+			}
+		}
+		replica := func(id, sourceID string, state ec2types.ImageState) ec2types.Image {
+			return ec2types.Image{
+				Name:         aws.String(id),
+				ImageId:      aws.String(id),
+				CreationDate: aws.String(time.Now().Format(time.RFC3339)),
+				Architecture: "x86_64",
+				Tags: []ec2types.Tag{
+					{Key: aws.String(v1.NodeClassTagKey), Value: aws.String(nodeClass.Name)},
+					{Key: aws.String(v1.EKSClusterNameTagKey), Value: aws.String("test-cluster")},
+					{Key: aws.String(v1.AMISourceIDTagKey), Value: aws.String(sourceID)},
+					{Key: aws.String(v1.AMISourceRegionTagKey), Value: aws.String("us-east-1")},
+				},
+				BlockDeviceMappings: []ec2types.BlockDeviceMapping{{DeviceName: aws.String("/dev/xvda"), Ebs: &ec2types.EbsBlockDevice{SnapshotId: aws.String("snap-" + id)}}},
+				State:               state,
+			}
+		}
+		BeforeEach(func() {
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{
+				Tags:        map[string]string{"Name": "replicated"},
+				Replication: &v1.AMIReplication{SourceRegion: "us-east-1"},
+			}}
+		})
+		It("should copy an AMI from the source region", func() {
+			nodeClass.Spec.Tags = map[string]string{"team": "platform"}
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{source("ami-source", time.Now())}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMIs).To(BeEmpty())
+			Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeAMIsReady).IsFalse()).To(BeTrue())
+			Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeAMIsReady).Reason).To(Equal("AMIReplicationPending"))
+
+			Expect(awsEnv.EC2API.CopyImageBehavior.Calls()).To(Equal(1))
+			input := awsEnv.EC2API.CopyImageBehavior.CalledWithInput.Pop()
+			Expect(aws.ToString(input.SourceImageId)).To(Equal("ami-source"))
+			Expect(aws.ToString(input.SourceRegion)).To(Equal("us-east-1"))
+			Expect(input.ClientToken).ToNot(BeNil())
+			Expect(input.Encrypted).To(BeNil())
+			Expect(input.TagSpecifications).To(HaveLen(2))
+			for _, spec := range input.TagSpecifications {
+				Expect(spec.Tags).To(ContainElements(
+					ec2types.Tag{Key: aws.String("team"), Value: aws.String("platform")},
+					ec2types.Tag{Key: aws.String(v1.NodeClassTagKey), Value: aws.String(nodeClass.Name)},
+					ec2types.Tag{Key: aws.String(v1.EKSClusterNameTagKey), Value: aws.String("test-cluster")},
+					ec2types.Tag{Key: aws.String(v1.AMISourceIDTagKey), Value: aws.String("ami-source")},
+					ec2types.Tag{Key: aws.String(v1.AMISourceRegionTagKey), Value: aws.String("us-east-1")},
+				))
+			}
+		})
+		It("should encrypt the copy with the volume encryption KMS key", func() {
+			nodeClass.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{KMSKeyID: "arn:aws:kms:us-west-2:111122223333:key/test"}
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{source("ami-source", time.Now())}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+
+			input := awsEnv.EC2API.CopyImageBehavior.CalledWithInput.Pop()
+			Expect(aws.ToBool(input.Encrypted)).To(BeTrue())
+			Expect(aws.ToString(input.KmsKeyId)).To(Equal("arn:aws:kms:us-west-2:111122223333:key/test"))
+		})
+		It("should publish the copy once it's available", func() {
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{
+				source("ami-source", time.Now()),
+				replica("ami-copy", "ami-source", ec2types.ImageStateAvailable),
+			}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-copy"}))
+			Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeAMIsReady)).To(BeTrue())
+			Expect(awsEnv.EC2API.CopyImageBehavior.Calls()).To(Equal(0))
+			Expect(awsEnv.EC2API.DeregisterImageBehavior.Calls()).To(Equal(0))
+		})
+		It("should keep the previous AMI while a copy is pending", func() {
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{
+				source("ami-source", time.Now()),
+				replica("ami-copy", "ami-source", ec2types.ImageStateAvailable),
+			}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{
+				source("ami-source", time.Now()),
+				source("ami-source-new", time.Now().Add(time.Minute)),
+				replica("ami-copy", "ami-source", ec2types.ImageStateAvailable),
+				replica("ami-copy-new", "ami-source-new", ec2types.ImageStatePending),
+			}})
+			awsEnv.EC2Cache.Flush()
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-copy"}))
+			Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeAMIsReady)).To(BeTrue())
+			Expect(awsEnv.EC2API.CopyImageBehavior.Calls()).To(Equal(0))
+			Expect(awsEnv.EC2API.DeregisterImageBehavior.Calls()).To(Equal(0))
+		})
+		It("should retry a failed copy with a new client token", func() {
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{source("ami-source", time.Now())}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			Expect(awsEnv.EC2API.CopyImageBehavior.Calls()).To(Equal(1))
+			token := aws.ToString(awsEnv.EC2API.CopyImageBehavior.CalledWithInput.Pop().ClientToken)
+
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{
+				source("ami-source", time.Now()),
+				replica("ami-copy", "ami-source", ec2types.ImageStateFailed),
+			}})
+			awsEnv.EC2Cache.Flush()
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeAMIsReady).Reason).To(Equal("AMIReplicationPending"))
+			Expect(awsEnv.EC2API.DeregisterImageBehavior.Calls()).To(Equal(1))
+			Expect(aws.ToString(awsEnv.EC2API.DeregisterImageBehavior.CalledWithInput.Pop().ImageId)).To(Equal("ami-copy"))
+			Expect(aws.ToString(awsEnv.EC2API.DeleteSnapshotBehavior.CalledWithInput.Pop().SnapshotId)).To(Equal("snap-ami-copy"))
+			Expect(awsEnv.EC2API.CopyImageBehavior.Calls()).To(Equal(2))
+			retry := awsEnv.EC2API.CopyImageBehavior.CalledWithInput.Pop()
+			Expect(aws.ToString(retry.SourceImageId)).To(Equal("ami-source"))
+			Expect(aws.ToString(retry.ClientToken)).ToNot(Equal(token))
+			Expect(nodeClass.Status.AMICopies).To(HaveLen(1))
+			Expect(nodeClass.Status.AMICopies).ToNot(ContainElement("ami-copy"))
+		})
+		It("should delete copies that are no longer used", func() {
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{
+				source("ami-source", time.Now()),
+				replica("ami-copy", "ami-source", ec2types.ImageStateAvailable),
+				replica("ami-copy-old", "ami-source-old", ec2types.ImageStateAvailable),
+			}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-copy"}))
+			Expect(awsEnv.EC2API.DeregisterImageBehavior.Calls()).To(Equal(1))
+			Expect(aws.ToString(awsEnv.EC2API.DeregisterImageBehavior.CalledWithInput.Pop().ImageId)).To(Equal("ami-copy-old"))
+			Expect(aws.ToString(awsEnv.EC2API.DeleteSnapshotBehavior.CalledWithInput.Pop().SnapshotId)).To(Equal("snap-ami-copy-old"))
+		})
+		It("should delete copies once replication is removed", func() {
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{
+				source("ami-source", time.Now()),
+				replica("ami-copy", "ami-source", ec2types.ImageStateAvailable),
+			}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.AMICopies).To(ConsistOf("ami-copy"))
+
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Tags: map[string]string{"Name": "replicated"}}}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			awsEnv.EC2Cache.Flush()
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })).To(Equal([]string{"ami-source"}))
+			Expect(aws.ToString(awsEnv.EC2API.DeregisterImageBehavior.CalledWithInput.Pop().ImageId)).To(Equal("ami-copy"))
+			Expect(nodeClass.Status.AMICopies).To(BeEmpty())
+
+			// Copies aren't listed once they've all been deleted
+			awsEnv.EC2API.CalledWithDescribeImagesInput.Reset()
+			awsEnv.EC2Cache.Flush()
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			awsEnv.EC2API.CalledWithDescribeImagesInput.ForEach(func(input *ec2.DescribeImagesInput) {
+				Expect(lo.Map(input.Filters, func(f ec2types.Filter, _ int) string { return aws.ToString(f.Name) })).ToNot(ContainElement("tag:" + v1.NodeClassTagKey))
+			})
+		})
+		It("should not list copies when replication isn't configured", func() {
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Tags: map[string]string{"Name": "replicated"}}}
+			awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{source("ami-source", time.Now())}})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			Expect(awsEnv.EC2API.CalledWithDescribeImagesInput.Len()).To(BeNumerically(">", 0))
+			awsEnv.EC2API.CalledWithDescribeImagesInput.ForEach(func(input *ec2.DescribeImagesInput) {
+				Expect(lo.Map(input.Filters, func(f ec2types.Filter, _ int) string { return aws.ToString(f.Name) })).ToNot(ContainElement("tag:" + v1.NodeClassTagKey))
+			})
+		})
+	})
 })
@@ -0,0 +1,171 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amideprecation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/awslabs/operatorpkg/singleton"
+	"github.com/samber/lo"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/karpenter/pkg/operator/injection"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	sdk "github.com/aws/karpenter-provider-aws/pkg/aws"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/instance"
+)
+
+// maxImageIDsPerFilter is the number of image IDs described by each DescribeImages request, since EC2 limits the
+// number of values in a filter
+const maxImageIDsPerFilter = 200
+
+// Controller reports the deprecation of the AMIs used by each EC2NodeClass's instances. Deprecated AMIs are no longer
+// selected for new launches, but instances that were launched from them keep running until they're replaced, so this
+// surfaces the nodes that are running, or will soon be running, on deprecated AMIs.
+type Controller struct {
+	clk              clock.Clock
+	kubeClient       client.Client
+	instanceProvider instance.Provider
+	ec2api           sdk.EC2API
+}
+
+func NewController(clk clock.Clock, kubeClient client.Client, instanceProvider instance.Provider, ec2api sdk.EC2API) *Controller {
+	return &Controller{
+		clk:              clk,
+		kubeClient:       kubeClient,
+		instanceProvider: instanceProvider,
+		ec2api:           ec2api,
+	}
+}
+
+func (c *Controller) Reconcile(ctx context.Context) (reconcile.Result, error) {
+	ctx = injection.WithControllerName(ctx, "nodeclass.amideprecation")
+
+	instances, err := c.instanceProvider.List(ctx)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing instances, %w", err)
+	}
+	// Warm pool members aren't nodes until they've been claimed
+	instances = lo.Filter(instances, func(i *instance.Instance, _ int) bool {
+		return i.WarmPool == "" && i.Tags[v1.NodeClassTagKey] != ""
+	})
+	deprecationTimes, err := c.resolveDeprecationTimes(ctx, lo.Uniq(lo.Map(instances, func(i *instance.Instance, _ int) string { return i.ImageID })))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	instancesByNodeClass := lo.GroupBy(instances, func(i *instance.Instance) string { return i.Tags[v1.NodeClassTagKey] })
+
+	nodeClassList := &v1.EC2NodeClassList{}
+	if err := c.kubeClient.List(ctx, nodeClassList); err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing nodeclasses, %w", err)
+	}
+	AMIDeprecatedNodes.Reset()
+	AMIEarliestDeprecationTimestamp.Reset()
+	var errs []error
+	for i := range nodeClassList.Items {
+		nodeClass := &nodeClassList.Items[i]
+		deprecation := c.deprecation(instancesByNodeClass[nodeClass.Name], deprecationTimes)
+		AMIDeprecatedNodes.Set(float64(lo.FromPtr(deprecation).DeprecatedNodes), map[string]string{nodeClassLabel: nodeClass.Name})
+		if deprecation != nil && deprecation.EarliestDeprecationTime != nil {
+			AMIEarliestDeprecationTimestamp.Set(float64(deprecation.EarliestDeprecationTime.Unix()), map[string]string{nodeClassLabel: nodeClass.Name})
+		}
+		if equality.Semantic.DeepEqual(nodeClass.Status.AMIDeprecation, deprecation) {
+			continue
+		}
+		stored := nodeClass.DeepCopy()
+		nodeClass.Status.AMIDeprecation = deprecation
+		if err := c.kubeClient.Status().Patch(ctx, nodeClass, client.MergeFrom(stored)); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("patching nodeclass status, %w", err))
+		}
+	}
+	if err := multierr.Combine(errs...); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: 5 * time.Minute}, nil
+}
+
+// resolveDeprecationTimes returns the deprecation time of each AMI that has one. The AMIs are described by a single
+// request, and AMIs that have been deregistered can't be resolved, and are omitted.
+func (c *Controller) resolveDeprecationTimes(ctx context.Context, ids []string) (map[string]time.Time, error) {
+	deprecationTimes := map[string]time.Time{}
+	if len(ids) == 0 {
+		return deprecationTimes, nil
+	}
+	for _, chunk := range lo.Chunk(ids, maxImageIDsPerFilter) {
+		paginator := ec2.NewDescribeImagesPaginator(c.ec2api, &ec2.DescribeImagesInput{
+			Filters:           []ec2types.Filter{{Name: aws.String("image-id"), Values: chunk}},
+			IncludeDeprecated: aws.Bool(true),
+			MaxResults:        aws.Int32(1000),
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("describing images, %w", err)
+			}
+			for _, image := range page.Images {
+				if image.DeprecationTime == nil {
+					continue
+				}
+				deprecationTime, err := time.Parse(time.RFC3339, *image.DeprecationTime)
+				if err != nil {
+					continue
+				}
+				deprecationTimes[lo.FromPtr(image.ImageId)] = deprecationTime
+			}
+		}
+	}
+	return deprecationTimes, nil
+}
+
+// deprecation summarizes the deprecation of the AMIs used by the instances, or returns nil if none of the AMIs have a
+// deprecation time
+func (c *Controller) deprecation(instances []*instance.Instance, deprecationTimes map[string]time.Time) *v1.AMIDeprecation {
+	deprecation := &v1.AMIDeprecation{}
+	for _, i := range instances {
+		deprecationTime, ok := deprecationTimes[i.ImageID]
+		if !ok {
+			continue
+		}
+		if !deprecationTime.After(c.clk.Now()) {
+			deprecation.DeprecatedNodes++
+		}
+		if deprecation.EarliestDeprecationTime == nil || deprecationTime.Before(deprecation.EarliestDeprecationTime.Time) {
+			deprecation.EarliestDeprecationTime = lo.ToPtr(metav1.NewTime(deprecationTime))
+		}
+	}
+	if deprecation.EarliestDeprecationTime == nil {
+		return nil
+	}
+	return deprecation
+}
+
+func (c *Controller) Register(_ context.Context, m manager.Manager) error {
+	return controllerruntime.NewControllerManagedBy(m).
+		Named("nodeclass.amideprecation").
+		WatchesRawSource(singleton.Source()).
+		Complete(singleton.AsReconciler(c))
+}
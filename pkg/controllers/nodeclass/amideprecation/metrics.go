@@ -0,0 +1,54 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amideprecation
+
+import (
+	opmetrics "github.com/awslabs/operatorpkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/karpenter/pkg/metrics"
+)
+
+const (
+	amiSubsystem   = "ami"
+	nodeClassLabel = "ec2nodeclass"
+)
+
+var (
+	AMIDeprecatedNodes = opmetrics.NewPrometheusGauge(
+		crmetrics.Registry,
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: amiSubsystem,
+			Name:      "deprecated_nodes",
+			Help:      "Number of instances using an AMI that has passed its deprecation time, based on ec2nodeclass.",
+		},
+		[]string{
+			nodeClassLabel,
+		},
+	)
+	AMIEarliestDeprecationTimestamp = opmetrics.NewPrometheusGauge(
+		crmetrics.Registry,
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: amiSubsystem,
+			Name:      "earliest_deprecation_timestamp_seconds",
+			Help:      "Earliest deprecation time of the AMIs used by instances, including AMIs that aren't deprecated yet, based on ec2nodeclass. Only reported when at least one AMI has a deprecation time.",
+		},
+		[]string{
+			nodeClassLabel,
+		},
+	)
+)
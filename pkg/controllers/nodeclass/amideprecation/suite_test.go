@@ -0,0 +1,224 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amideprecation_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/samber/lo"
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	coreoptions "sigs.k8s.io/karpenter/pkg/operator/options"
+	coretest "sigs.k8s.io/karpenter/pkg/test"
+	"sigs.k8s.io/karpenter/pkg/test/v1alpha1"
+
+	"github.com/aws/karpenter-provider-aws/pkg/apis"
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass/amideprecation"
+	"github.com/aws/karpenter-provider-aws/pkg/fake"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+	. "sigs.k8s.io/karpenter/pkg/utils/testing"
+)
+
+var ctx context.Context
+var env *coretest.Environment
+var awsEnv *test.Environment
+var controller *amideprecation.Controller
+
+func TestAPIs(t *testing.T) {
+	ctx = TestContextWithLogger(t)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AMIDeprecation")
+}
+
+var _ = BeforeSuite(func() {
+	env = coretest.NewEnvironment(coretest.WithCRDs(apis.CRDs...), coretest.WithCRDs(v1alpha1.CRDs...))
+	ctx = coreoptions.ToContext(ctx, coretest.Options(coretest.OptionsFields{FeatureGates: coretest.FeatureGates{ReservedCapacity: lo.ToPtr(true)}}))
+	ctx = options.ToContext(ctx, test.Options())
+	awsEnv = test.NewEnvironment(ctx, env)
+	controller = amideprecation.NewController(awsEnv.Clock, env.Client, awsEnv.InstanceProvider, awsEnv.EC2API)
+})
+
+var _ = AfterSuite(func() {
+	Expect(env.Stop()).To(Succeed(), "Failed to stop environment")
+})
+
+var _ = BeforeEach(func() {
+	awsEnv.Reset()
+	awsEnv.Clock.SetTime(time.Now())
+})
+
+var _ = AfterEach(func() {
+	ExpectCleanedUp(ctx, env.Client)
+})
+
+var _ = Describe("AMIDeprecation", func() {
+	var nodeClass *v1.EC2NodeClass
+	var storeInstance func(amiID string, tags map[string]string)
+
+	BeforeEach(func() {
+		nodeClass = test.EC2NodeClass()
+		awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{
+			Images: []ec2types.Image{
+				{
+					Name:            aws.String("deprecated"),
+					ImageId:         aws.String("ami-deprecated"),
+					CreationDate:    aws.String("2021-08-31T00:12:42.000Z"),
+					DeprecationTime: aws.String(awsEnv.Clock.Now().Add(-time.Hour).Format(time.RFC3339)),
+					Architecture:    "x86_64",
+					State:           ec2types.ImageStateAvailable,
+				},
+				{
+					Name:            aws.String("deprecating"),
+					ImageId:         aws.String("ami-deprecating"),
+					CreationDate:    aws.String("2021-08-31T00:12:42.000Z"),
+					DeprecationTime: aws.String(awsEnv.Clock.Now().Add(7 * 24 * time.Hour).Format(time.RFC3339)),
+					Architecture:    "x86_64",
+					State:           ec2types.ImageStateAvailable,
+				},
+				{
+					Name:         aws.String("current"),
+					ImageId:      aws.String("ami-current"),
+					CreationDate: aws.String("2021-08-31T00:12:42.000Z"),
+					Architecture: "x86_64",
+					State:        ec2types.ImageStateAvailable,
+				},
+			},
+		})
+		storeInstance = func(amiID string, tags map[string]string) {
+			id := fake.InstanceID()
+			awsEnv.EC2API.Instances.Store(id, ec2types.Instance{
+				InstanceId:   aws.String(id),
+				ImageId:      aws.String(amiID),
+				InstanceType: "m5.large",
+				State:        &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning},
+				Placement:    &ec2types.Placement{AvailabilityZone: aws.String(fake.DefaultRegion)},
+				Tags: lo.MapToSlice(lo.Assign(map[string]string{
+					fmt.Sprintf("kubernetes.io/cluster/%s", options.FromContext(ctx).ClusterName): "owned",
+					v1.EKSClusterNameTagKey: options.FromContext(ctx).ClusterName,
+					karpv1.NodePoolLabelKey: "default",
+					v1.NodeClassTagKey:      nodeClass.Name,
+				}, tags), func(k, v string) ec2types.Tag { return ec2types.Tag{Key: aws.String(k), Value: aws.String(v)} }),
+			})
+		}
+	})
+	It("should report the nodes using deprecated AMIs", func() {
+		storeInstance("ami-deprecated", nil)
+		storeInstance("ami-deprecated", nil)
+		storeInstance("ami-deprecating", nil)
+		storeInstance("ami-current", nil)
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectSingletonReconciled(ctx, controller)
+
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.AMIDeprecation).ToNot(BeNil())
+		Expect(nodeClass.Status.AMIDeprecation.DeprecatedNodes).To(BeNumerically("==", 2))
+		Expect(nodeClass.Status.AMIDeprecation.EarliestDeprecationTime.Unix()).To(Equal(awsEnv.Clock.Now().Add(-time.Hour).Unix()))
+		ExpectMetricGaugeValue(amideprecation.AMIDeprecatedNodes, 2, map[string]string{"ec2nodeclass": nodeClass.Name})
+		ExpectMetricGaugeValue(amideprecation.AMIEarliestDeprecationTimestamp, float64(awsEnv.Clock.Now().Add(-time.Hour).Unix()), map[string]string{"ec2nodeclass": nodeClass.Name})
+	})
+	It("should describe all of the AMIs with a single request", func() {
+		storeInstance("ami-deprecated", nil)
+		storeInstance("ami-deprecating", nil)
+		storeInstance("ami-current", nil)
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectSingletonReconciled(ctx, controller)
+
+		Expect(awsEnv.EC2API.CalledWithDescribeImagesInput.Len()).To(Equal(1))
+		input := awsEnv.EC2API.CalledWithDescribeImagesInput.Pop()
+		Expect(input.Filters).To(HaveLen(1))
+		Expect(lo.FromPtr(input.Filters[0].Name)).To(Equal("image-id"))
+		Expect(input.Filters[0].Values).To(ConsistOf("ami-deprecated", "ami-deprecating", "ami-current"))
+	})
+	It("should split the AMIs across requests when there are too many for a single filter", func() {
+		for i := range 250 {
+			storeInstance(fmt.Sprintf("ami-%d", i), nil)
+		}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectSingletonReconciled(ctx, controller)
+
+		Expect(awsEnv.EC2API.CalledWithDescribeImagesInput.Len()).To(Equal(2))
+		var ids []string
+		for awsEnv.EC2API.CalledWithDescribeImagesInput.Len() > 0 {
+			input := awsEnv.EC2API.CalledWithDescribeImagesInput.Pop()
+			Expect(len(input.Filters[0].Values)).To(BeNumerically("<=", 200))
+			ids = append(ids, input.Filters[0].Values...)
+		}
+		Expect(ids).To(HaveLen(250))
+	})
+	It("should report the earliest deprecation time of AMIs that aren't deprecated yet", func() {
+		storeInstance("ami-deprecating", nil)
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectSingletonReconciled(ctx, controller)
+
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.AMIDeprecation).ToNot(BeNil())
+		Expect(nodeClass.Status.AMIDeprecation.DeprecatedNodes).To(BeNumerically("==", 0))
+		Expect(nodeClass.Status.AMIDeprecation.EarliestDeprecationTime.Unix()).To(Equal(awsEnv.Clock.Now().Add(7 * 24 * time.Hour).Unix()))
+
+		// The AMI is deprecated once its deprecation time passes
+		awsEnv.Clock.Step(8 * 24 * time.Hour)
+		ExpectSingletonReconciled(ctx, controller)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.AMIDeprecation.DeprecatedNodes).To(BeNumerically("==", 1))
+	})
+	It("should not report deprecation when no AMIs have a deprecation time", func() {
+		storeInstance("ami-current", nil)
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectSingletonReconciled(ctx, controller)
+
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.AMIDeprecation).To(BeNil())
+		ExpectMetricGaugeValue(amideprecation.AMIDeprecatedNodes, 0, map[string]string{"ec2nodeclass": nodeClass.Name})
+	})
+	It("should ignore warm pool members", func() {
+		storeInstance("ami-deprecated", map[string]string{v1.WarmPoolTagKey: "default"})
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectSingletonReconciled(ctx, controller)
+
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.AMIDeprecation).To(BeNil())
+	})
+	It("should ignore instances belonging to other EC2NodeClasses", func() {
+		storeInstance("ami-deprecated", map[string]string{v1.NodeClassTagKey: "other"})
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectSingletonReconciled(ctx, controller)
+
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.AMIDeprecation).To(BeNil())
+	})
+	It("should clear the status once no nodes use deprecated AMIs", func() {
+		storeInstance("ami-deprecated", nil)
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectSingletonReconciled(ctx, controller)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.AMIDeprecation).ToNot(BeNil())
+
+		awsEnv.EC2API.Instances.Clear()
+		ExpectSingletonReconciled(ctx, controller)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.AMIDeprecation).To(BeNil())
+	})
+})
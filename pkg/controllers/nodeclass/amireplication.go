@@ -0,0 +1,225 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeclass
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/samber/lo"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	awserrors "github.com/aws/karpenter-provider-aws/pkg/errors"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/amifamily"
+	"github.com/aws/karpenter-provider-aws/pkg/utils"
+)
+
+// maxCopyNameLength is the maximum length of an AMI name, including the suffix that identifies the copy
+const maxCopyNameLength = 128
+
+// replicate substitutes AMIs discovered in a source region with their copies in the local region. Copies are started
+// for AMIs that haven't been copied yet, and pending is returned while any copy isn't available. AMIs that are still
+// being copied are omitted from the result. The copies are returned with the copies that were started, and with failed
+// copies marked as deregistered once they've been deleted.
+func (a *AMI) replicate(ctx context.Context, nodeClass *v1.EC2NodeClass, amis amifamily.AMIs, copies []ec2types.Image) (amifamily.AMIs, []ec2types.Image, bool, error) {
+	var out amifamily.AMIs
+	var pending bool
+	for _, ami := range amis {
+		if ami.SourceRegion == "" {
+			out = append(out, ami)
+			continue
+		}
+		_, i, ok := lo.FindIndexOf(copies, func(image ec2types.Image) bool {
+			tags := imageTags(image)
+			return image.State != ec2types.ImageStateDeregistered &&
+				tags[v1.AMISourceIDTagKey] == ami.AmiID && tags[v1.AMISourceRegionTagKey] == ami.SourceRegion
+		})
+		if !ok {
+			started, err := a.copy(ctx, nodeClass, ami, "")
+			if err != nil {
+				return nil, nil, false, fmt.Errorf("copying ami %s from %s, %w", ami.AmiID, ami.SourceRegion, err)
+			}
+			copies = append(copies, started)
+			pending = true
+			continue
+		}
+		switch image := copies[i]; image.State {
+		case ec2types.ImageStateAvailable:
+			ami.AmiID = lo.FromPtr(image.ImageId)
+			ami.Name = lo.FromPtr(image.Name)
+			ami.SourceRegion = ""
+			out = append(out, ami)
+		case ec2types.ImageStatePending:
+			pending = true
+		default:
+			// Failed copies are removed and the copy is retried with a new client token, since reusing the token of the
+			// failed copy would return the failed copy rather than starting a new one
+			log.FromContext(ctx).WithValues("id", lo.FromPtr(image.ImageId), "state", image.State, "source-id", ami.AmiID, "source-region", ami.SourceRegion).Info("ami copy failed, retrying")
+			if err := a.deleteCopy(ctx, image); err != nil {
+				return nil, nil, false, err
+			}
+			copies[i].State = ec2types.ImageStateDeregistered
+			started, err := a.copy(ctx, nodeClass, ami, lo.FromPtr(image.ImageId))
+			if err != nil {
+				return nil, nil, false, fmt.Errorf("copying ami %s from %s, %w", ami.AmiID, ami.SourceRegion, err)
+			}
+			copies = append(copies, started)
+			pending = true
+		}
+	}
+	return out, copies, pending, nil
+}
+
+// copy starts copying the AMI from its source region and returns the pending copy. When the copy replaces a failed
+// copy, the failed copy's ID is included in the client token.
+func (a *AMI) copy(ctx context.Context, nodeClass *v1.EC2NodeClass, ami amifamily.AMI, failedID string) (ec2types.Image, error) {
+	// The client token makes the copy idempotent, so a copy isn't duplicated if it isn't visible to DescribeImages yet
+	key := fmt.Sprintf("%s/%s/%s", nodeClass.UID, ami.SourceRegion, ami.AmiID)
+	if failedID != "" {
+		key += "/" + failedID
+	}
+	hash := sha256.Sum256([]byte(key))
+	token := hex.EncodeToString(hash[:])
+	suffix := "-" + token[:16]
+	name := ami.Name
+	if len(name)+len(suffix) > maxCopyNameLength {
+		name = name[:maxCopyNameLength-len(suffix)]
+	}
+	tags := utils.EC2MergeTags(nodeClass.Spec.Tags, map[string]string{
+		v1.NodeClassTagKey:       nodeClass.Name,
+		v1.EKSClusterNameTagKey:  options.FromContext(ctx).ClusterName,
+		v1.AMISourceIDTagKey:     ami.AmiID,
+		v1.AMISourceRegionTagKey: ami.SourceRegion,
+	})
+	input := &ec2.CopyImageInput{
+		Name:          aws.String(name + suffix),
+		Description:   aws.String(fmt.Sprintf("Copy of %s (%s) from %s", ami.Name, ami.AmiID, ami.SourceRegion)),
+		SourceImageId: aws.String(ami.AmiID),
+		SourceRegion:  aws.String(ami.SourceRegion),
+		ClientToken:   aws.String(token),
+		TagSpecifications: []ec2types.TagSpecification{
+			{ResourceType: ec2types.ResourceTypeImage, Tags: tags},
+			{ResourceType: ec2types.ResourceTypeSnapshot, Tags: tags},
+		},
+	}
+	if nodeClass.Spec.VolumeEncryptionPolicy != nil {
+		input.Encrypted = aws.Bool(true)
+		input.KmsKeyId = aws.String(nodeClass.Spec.VolumeEncryptionPolicy.KMSKeyID)
+	}
+	out, err := a.ec2api.CopyImage(ctx, input)
+	if err != nil {
+		return ec2types.Image{}, err
+	}
+	log.FromContext(ctx).WithValues("id", lo.FromPtr(out.ImageId), "source-id", ami.AmiID, "source-region", ami.SourceRegion).Info("copying ami")
+	return ec2types.Image{
+		ImageId: out.ImageId,
+		Name:    input.Name,
+		State:   ec2types.ImageStatePending,
+		Tags:    tags,
+	}, nil
+}
+
+// listCopies returns the AMIs that have been copied for the EC2NodeClass
+func (a *AMI) listCopies(ctx context.Context, nodeClass *v1.EC2NodeClass) ([]ec2types.Image, error) {
+	var images []ec2types.Image
+	paginator := ec2.NewDescribeImagesPaginator(a.ec2api, &ec2.DescribeImagesInput{
+		Owners: []string{"self"},
+		Filters: []ec2types.Filter{
+			{Name: aws.String(fmt.Sprintf("tag:%s", v1.NodeClassTagKey)), Values: []string{nodeClass.Name}},
+			{Name: aws.String(fmt.Sprintf("tag:%s", v1.EKSClusterNameTagKey)), Values: []string{options.FromContext(ctx).ClusterName}},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing ami copies, %w", err)
+		}
+		images = append(images, page.Images...)
+	}
+	return images, nil
+}
+
+// deleteObsoleteCopies deletes the copies that are no longer used by the EC2NodeClass. Copies that are still being
+// rolled out from, or that belong to an AMI which is still selected, are kept. Deleted copies are marked as deregistered.
+func (a *AMI) deleteObsoleteCopies(ctx context.Context, nodeClass *v1.EC2NodeClass, copies []ec2types.Image, sources amifamily.AMIs) error {
+	inUse := sets.New(lo.Map(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) string { return ami.ID })...)
+	selected := sets.New(lo.FilterMap(sources, func(ami amifamily.AMI, _ int) (string, bool) {
+		return ami.SourceRegion + "/" + ami.AmiID, ami.SourceRegion != ""
+	})...)
+	var errs error
+	for i, image := range copies {
+		tags := imageTags(image)
+		if inUse.Has(lo.FromPtr(image.ImageId)) || image.State == ec2types.ImageStatePending || image.State == ec2types.ImageStateDeregistered ||
+			selected.Has(tags[v1.AMISourceRegionTagKey]+"/"+tags[v1.AMISourceIDTagKey]) {
+			continue
+		}
+		if err := a.deleteCopy(ctx, image); err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		copies[i].State = ec2types.ImageStateDeregistered
+		log.FromContext(ctx).WithValues("id", lo.FromPtr(image.ImageId)).Info("deleted obsolete ami copy")
+	}
+	return errs
+}
+
+// copyIDs returns the IDs of the copies that haven't been deleted
+func copyIDs(copies []ec2types.Image) []string {
+	return lo.FilterMap(copies, func(image ec2types.Image, _ int) (string, bool) {
+		return lo.FromPtr(image.ImageId), image.State != ec2types.ImageStateDeregistered
+	})
+}
+
+// deleteAllCopies deletes all of the AMIs that have been copied for the EC2NodeClass
+func (a *AMI) deleteAllCopies(ctx context.Context, nodeClass *v1.EC2NodeClass) error {
+	copies, err := a.listCopies(ctx, nodeClass)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, image := range copies {
+		errs = multierr.Append(errs, a.deleteCopy(ctx, image))
+	}
+	return errs
+}
+
+// deleteCopy deregisters the copied AMI and deletes its snapshots, which aren't deleted along with the AMI
+func (a *AMI) deleteCopy(ctx context.Context, image ec2types.Image) error {
+	if _, err := a.ec2api.DeregisterImage(ctx, &ec2.DeregisterImageInput{ImageId: image.ImageId}); awserrors.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deregistering ami %s, %w", lo.FromPtr(image.ImageId), err)
+	}
+	for _, mapping := range image.BlockDeviceMappings {
+		if mapping.Ebs == nil || mapping.Ebs.SnapshotId == nil {
+			continue
+		}
+		if _, err := a.ec2api.DeleteSnapshot(ctx, &ec2.DeleteSnapshotInput{SnapshotId: mapping.Ebs.SnapshotId}); awserrors.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting snapshot %s, %w", lo.FromPtr(mapping.Ebs.SnapshotId), err)
+		}
+	}
+	return nil
+}
+
+func imageTags(image ec2types.Image) map[string]string {
+	return lo.SliceToMap(image.Tags, func(t ec2types.Tag) (string, string) { return lo.FromPtr(t.Key), lo.FromPtr(t.Value) })
+}
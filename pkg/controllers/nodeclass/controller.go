@@ -49,12 +49,14 @@ import (
 
 	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
 	sdk "github.com/aws/karpenter-provider-aws/pkg/aws"
+	awserrors "github.com/aws/karpenter-provider-aws/pkg/errors"
 	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/amifamily"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/capacityreservation"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instanceprofile"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instancetype"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/launchtemplate"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/placementgroup"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/securitygroup"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/subnet"
 )
@@ -65,6 +67,8 @@ type Controller struct {
 	region                  string
 	launchTemplateProvider  launchtemplate.Provider
 	instanceProfileProvider instanceprofile.Provider
+	ami                     *AMI
+	subnet                  *Subnet
 	validation              *Validation
 	reconcilers             []reconcile.TypedReconciler[*v1.EC2NodeClass]
 }
@@ -82,23 +86,33 @@ func NewController(
 	instanceTypeProvider instancetype.Provider,
 	launchTemplateProvider launchtemplate.Provider,
 	capacityReservationProvider capacityreservation.Provider,
+	placementGroupProvider placementgroup.Provider,
 	ec2api sdk.EC2API,
 	validationCache *cache.Cache,
 	amiResolver amifamily.Resolver,
+	amiHealthTracker *amifamily.HealthTracker,
 ) *Controller {
 	validation := NewValidationReconciler(kubeClient, cloudProvider, ec2api, amiResolver, instanceTypeProvider, launchTemplateProvider, validationCache)
+	ami := NewAMIReconciler(clk, kubeClient, recorder, amiProvider, amiHealthTracker, ec2api)
+	subnetReconciler := NewSubnetReconciler(kubeClient, subnetProvider, recorder)
 	return &Controller{
 		kubeClient:              kubeClient,
 		recorder:                recorder,
 		region:                  region,
 		launchTemplateProvider:  launchTemplateProvider,
 		instanceProfileProvider: instanceProfileProvider,
+		ami:                     ami,
+		subnet:                  subnetReconciler,
 		validation:              validation,
 		reconcilers: []reconcile.TypedReconciler[*v1.EC2NodeClass]{
-			NewAMIReconciler(amiProvider),
+			ami,
 			NewCapacityReservationReconciler(clk, capacityReservationProvider),
-			NewSubnetReconciler(subnetProvider),
+			subnetReconciler,
 			NewSecurityGroupReconciler(securityGroupProvider),
+			NewSecurityGroupRulesReconciler(ec2api),
+			NewNetworkInterfaceReconciler(subnetProvider, securityGroupProvider),
+			NewPlacementGroupReconciler(placementGroupProvider),
+			NewVolumeEncryptionReconciler(),
 			NewInstanceProfileReconciler(instanceProfileProvider, region),
 			validation,
 			NewReadinessReconciler(launchTemplateProvider),
@@ -176,13 +190,19 @@ func (c *Controller) finalize(ctx context.Context, nodeClass *v1.EC2NodeClass) (
 		return reconcile.Result{RequeueAfter: time.Minute * 10}, nil // periodically fire the event
 	}
 	if nodeClass.Spec.Role != "" {
-		if err := c.instanceProfileProvider.Delete(ctx, nodeClass.InstanceProfileName(options.FromContext(ctx).ClusterName, c.region)); err != nil {
+		if err := c.deleteInstanceProfile(ctx, nodeClass); err != nil {
 			return reconcile.Result{}, fmt.Errorf("deleting instance profile, %w", err)
 		}
 	}
 	if err := c.launchTemplateProvider.DeleteAll(ctx, nodeClass); err != nil {
 		return reconcile.Result{}, fmt.Errorf("deleting launch templates, %w", err)
 	}
+	if err := c.ami.deleteAllCopies(ctx, nodeClass); err != nil {
+		return reconcile.Result{}, fmt.Errorf("deleting ami copies, %w", err)
+	}
+	if err := c.subnet.deleteMetrics(ctx, nodeClass, lo.Map(nodeClass.Status.Subnets, func(s v1.Subnet, _ int) string { return s.ID })); err != nil {
+		return reconcile.Result{}, fmt.Errorf("deleting subnet metrics, %w", err)
+	}
 	controllerutil.RemoveFinalizer(nodeClass, v1.TerminationFinalizer)
 	if !equality.Semantic.DeepEqual(stored, nodeClass) {
 		// We use client.MergeFromWithOptimisticLock because patching a list with a JSON merge patch
@@ -200,6 +220,22 @@ func (c *Controller) finalize(ctx context.Context, nodeClass *v1.EC2NodeClass) (
 	return reconcile.Result{}, nil
 }
 
+// deleteInstanceProfile deletes the instance profile for the EC2NodeClass's role. Instance profiles that aren't owned by
+// the EC2NodeClass are left in place when they were adopted with the IfOwned policy.
+func (c *Controller) deleteInstanceProfile(ctx context.Context, nodeClass *v1.EC2NodeClass) error {
+	profileName := nodeClass.InstanceProfileName(options.FromContext(ctx).ClusterName, c.region)
+	if nodeClass.InstanceProfileAdoptionPolicy() == v1.InstanceProfileAdoptionPolicyIfOwned {
+		instanceProfile, err := c.instanceProfileProvider.Get(ctx, profileName)
+		if err != nil {
+			return awserrors.IgnoreNotFound(err)
+		}
+		if !instanceprofile.IsOwned(instanceProfile, nodeClass.InstanceProfileTags(options.FromContext(ctx).ClusterName, c.region)) {
+			return nil
+		}
+	}
+	return c.instanceProfileProvider.Delete(ctx, profileName)
+}
+
 func (c *Controller) Register(_ context.Context, m manager.Manager) error {
 	return controllerruntime.NewControllerManagedBy(m).
 		Named(c.Name()).
@@ -34,3 +34,23 @@ func WaitingOnNodeClaimTerminationEvent(nodeClass *v1.EC2NodeClass, names []stri
 		DedupeValues:   []string{string(nodeClass.UID)},
 	}
 }
+
+func SubnetsExhaustedEvent(nodeClass *v1.EC2NodeClass, zones []string, subnetIDs []string) events.Event {
+	return events.Event{
+		InvolvedObject: nodeClass,
+		Type:           corev1.EventTypeWarning,
+		Reason:         "SubnetsExhausted",
+		Message:        fmt.Sprintf("Subnets %s have insufficient available IP addresses in zones %s", utils.PrettySlice(subnetIDs, 5), utils.PrettySlice(zones, 5)),
+		DedupeValues:   append([]string{string(nodeClass.UID)}, subnetIDs...),
+	}
+}
+
+func AMIExcludedEvent(nodeClass *v1.EC2NodeClass, amiID string, failed, total int) events.Event {
+	return events.Event{
+		InvolvedObject: nodeClass,
+		Type:           corev1.EventTypeWarning,
+		Reason:         "AMIExcluded",
+		Message:        fmt.Sprintf("Excluded AMI %s, %d of %d NodeClaims failed to become ready", amiID, failed, total),
+		DedupeValues:   []string{string(nodeClass.UID), amiID},
+	}
+}
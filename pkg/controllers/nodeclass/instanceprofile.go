@@ -17,15 +17,23 @@ package nodeclass
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/samber/lo"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	awserrors "github.com/aws/karpenter-provider-aws/pkg/errors"
 	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instanceprofile"
 )
 
+const (
+	ConditionReasonInstanceProfileNotOwned     = "InstanceProfileNotOwned"
+	ConditionReasonInstanceProfileRoleConflict = "InstanceProfileRoleConflict"
+)
+
 type InstanceProfile struct {
 	instanceProfileProvider instanceprofile.Provider
 	region                  string
@@ -41,11 +49,36 @@ func NewInstanceProfileReconciler(instanceProfileProvider instanceprofile.Provid
 func (ip *InstanceProfile) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass) (reconcile.Result, error) {
 	if nodeClass.Spec.Role != "" {
 		profileName := nodeClass.InstanceProfileName(options.FromContext(ctx).ClusterName, ip.region)
+		tags := nodeClass.InstanceProfileTags(options.FromContext(ctx).ClusterName, ip.region)
+		if nodeClass.InstanceProfileAdoptionPolicy() == v1.InstanceProfileAdoptionPolicyIfOwned {
+			instanceProfile, err := ip.instanceProfileProvider.Get(ctx, profileName)
+			if err != nil && !awserrors.IsNotFound(err) {
+				return reconcile.Result{}, fmt.Errorf("getting instance profile, %w", err)
+			}
+			if err == nil && !instanceprofile.IsOwned(instanceProfile, tags) {
+				// Instance profiles can only have a single role assigned to them so this profile either has 1 or 0 roles
+				if role, ok := lo.First(instanceProfile.Roles); ok && lo.FromPtr(role.RoleName) != lo.LastOr(strings.Split(nodeClass.InstanceProfileRole(), "/"), "") {
+					nodeClass.StatusConditions().SetFalse(
+						v1.ConditionTypeInstanceProfileReady,
+						ConditionReasonInstanceProfileRoleConflict,
+						fmt.Sprintf("Instance profile %s isn't owned by the EC2NodeClass and adopting it requires removing role %s", profileName, lo.FromPtr(role.RoleName)),
+					)
+				} else {
+					nodeClass.StatusConditions().SetFalse(
+						v1.ConditionTypeInstanceProfileReady,
+						ConditionReasonInstanceProfileNotOwned,
+						fmt.Sprintf("Instance profile %s isn't owned by the EC2NodeClass", profileName),
+					)
+				}
+				return reconcile.Result{RequeueAfter: time.Minute}, nil
+			}
+		}
 		if err := ip.instanceProfileProvider.Create(
 			ctx,
 			profileName,
+			nodeClass.InstanceProfilePath(),
 			nodeClass.InstanceProfileRole(),
-			nodeClass.InstanceProfileTags(options.FromContext(ctx).ClusterName, ip.region),
+			tags,
 		); err != nil {
 			return reconcile.Result{}, fmt.Errorf("creating instance profile, %w", err)
 		}
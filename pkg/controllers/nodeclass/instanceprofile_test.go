@@ -23,6 +23,7 @@ import (
 
 	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
 
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass"
 	"github.com/aws/karpenter-provider-aws/pkg/fake"
 	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 
@@ -164,4 +165,131 @@ var _ = Describe("NodeClass InstanceProfile Status Controller", func() {
 		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
 		Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeInstanceProfileReady)).To(BeTrue())
 	})
+	It("should create the instance profile with the configured path and tags", func() {
+		nodeClass.Spec.Role = "test-role"
+		nodeClass.Spec.InstanceProfileOptions = &v1.InstanceProfileOptions{
+			Path: lo.ToPtr("/karpenter/"),
+			Tags: map[string]string{"team": "platform"},
+		}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+
+		Expect(awsEnv.IAMAPI.InstanceProfiles).To(HaveLen(1))
+		Expect(lo.FromPtr(awsEnv.IAMAPI.InstanceProfiles[profileName].Path)).To(Equal("/karpenter/"))
+		Expect(awsEnv.IAMAPI.InstanceProfiles[profileName].Tags).To(ContainElements(
+			iamtypes.Tag{Key: lo.ToPtr("team"), Value: lo.ToPtr("platform")},
+			iamtypes.Tag{Key: lo.ToPtr(v1.LabelNodeClass), Value: lo.ToPtr(nodeClass.Name)},
+		))
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeInstanceProfileReady)).To(BeTrue())
+	})
+	It("should tag an owned instance profile when its tags are out of date", func() {
+		nodeClass.Spec.Role = "test-role"
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		Expect(awsEnv.IAMAPI.TagInstanceProfileBehavior.Calls()).To(BeZero())
+
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		nodeClass.Spec.InstanceProfileOptions = &v1.InstanceProfileOptions{
+			Tags: map[string]string{"team": "platform"},
+		}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		Expect(awsEnv.IAMAPI.TagInstanceProfileBehavior.Calls()).To(Equal(1))
+		Expect(awsEnv.IAMAPI.InstanceProfiles[profileName].Tags).To(ContainElement(
+			iamtypes.Tag{Key: lo.ToPtr("team"), Value: lo.ToPtr("platform")},
+		))
+	})
+	Context("Adoption Policy", func() {
+		BeforeEach(func() {
+			nodeClass.Spec.Role = "test-role"
+			nodeClass.Spec.InstanceProfileOptions = &v1.InstanceProfileOptions{
+				AdoptionPolicy: lo.ToPtr(v1.InstanceProfileAdoptionPolicyIfOwned),
+			}
+		})
+		It("should create the instance profile when it doesn't exist", func() {
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+
+			Expect(awsEnv.IAMAPI.InstanceProfiles).To(HaveLen(1))
+			Expect(*awsEnv.IAMAPI.InstanceProfiles[profileName].Roles[0].RoleName).To(Equal("test-role"))
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.InstanceProfile).To(Equal(profileName))
+			Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeInstanceProfileReady)).To(BeTrue())
+		})
+		It("should update the role of an existing instance profile that's owned by the nodeclass", func() {
+			awsEnv.IAMAPI.InstanceProfiles = map[string]*iamtypes.InstanceProfile{
+				profileName: {
+					InstanceProfileId:   aws.String(fake.InstanceProfileID()),
+					InstanceProfileName: aws.String(profileName),
+					Roles:               []iamtypes.Role{{RoleName: aws.String("other-role")}},
+					Tags: []iamtypes.Tag{
+						{Key: lo.ToPtr(fmt.Sprintf("kubernetes.io/cluster/%s", options.FromContext(ctx).ClusterName)), Value: lo.ToPtr("owned")},
+						{Key: lo.ToPtr(v1.LabelNodeClass), Value: lo.ToPtr(nodeClass.Name)},
+					},
+				},
+			}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+
+			Expect(*awsEnv.IAMAPI.InstanceProfiles[profileName].Roles[0].RoleName).To(Equal("test-role"))
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeInstanceProfileReady)).To(BeTrue())
+		})
+		It("should not modify an existing instance profile that isn't owned by the nodeclass", func() {
+			awsEnv.IAMAPI.InstanceProfiles = map[string]*iamtypes.InstanceProfile{
+				profileName: {
+					InstanceProfileId:   aws.String(fake.InstanceProfileID()),
+					InstanceProfileName: aws.String(profileName),
+				},
+			}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+
+			Expect(awsEnv.IAMAPI.InstanceProfiles[profileName].Roles).To(BeEmpty())
+			Expect(awsEnv.IAMAPI.AddRoleToInstanceProfileBehavior.Calls()).To(BeZero())
+			Expect(awsEnv.IAMAPI.TagInstanceProfileBehavior.Calls()).To(BeZero())
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.Status.InstanceProfile).To(BeEmpty())
+			condition := nodeClass.StatusConditions().Get(v1.ConditionTypeInstanceProfileReady)
+			Expect(condition.IsFalse()).To(BeTrue())
+			Expect(condition.Reason).To(Equal(nodeclass.ConditionReasonInstanceProfileNotOwned))
+		})
+		It("should report a role conflict when adopting an instance profile that isn't owned would remove its role", func() {
+			awsEnv.IAMAPI.InstanceProfiles = map[string]*iamtypes.InstanceProfile{
+				profileName: {
+					InstanceProfileId:   aws.String(fake.InstanceProfileID()),
+					InstanceProfileName: aws.String(profileName),
+					Roles:               []iamtypes.Role{{RoleName: aws.String("other-role")}},
+				},
+			}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+
+			Expect(*awsEnv.IAMAPI.InstanceProfiles[profileName].Roles[0].RoleName).To(Equal("other-role"))
+			Expect(awsEnv.IAMAPI.RemoveRoleFromInstanceProfileBehavior.Calls()).To(BeZero())
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			condition := nodeClass.StatusConditions().Get(v1.ConditionTypeInstanceProfileReady)
+			Expect(condition.IsFalse()).To(BeTrue())
+			Expect(condition.Reason).To(Equal(nodeclass.ConditionReasonInstanceProfileRoleConflict))
+			Expect(condition.Message).To(ContainSubstring("other-role"))
+		})
+		It("should not delete an instance profile that isn't owned by the nodeclass when the nodeclass is deleted", func() {
+			awsEnv.IAMAPI.InstanceProfiles = map[string]*iamtypes.InstanceProfile{
+				profileName: {
+					InstanceProfileId:   aws.String(fake.InstanceProfileID()),
+					InstanceProfileName: aws.String(profileName),
+					Roles:               []iamtypes.Role{{RoleName: aws.String("other-role")}},
+				},
+			}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+
+			Expect(env.Client.Delete(ctx, nodeClass)).To(Succeed())
+			ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+			Expect(awsEnv.IAMAPI.InstanceProfiles).To(HaveKey(profileName))
+			Expect(awsEnv.IAMAPI.RemoveRoleFromInstanceProfileBehavior.Calls()).To(BeZero())
+			Expect(awsEnv.IAMAPI.DeleteInstanceProfileBehavior.Calls()).To(BeZero())
+		})
+	})
 })
@@ -0,0 +1,118 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeclass
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/securitygroup"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/subnet"
+)
+
+type NetworkInterface struct {
+	subnetProvider        subnet.Provider
+	securityGroupProvider securitygroup.Provider
+}
+
+func NewNetworkInterfaceReconciler(subnetProvider subnet.Provider, securityGroupProvider securitygroup.Provider) *NetworkInterface {
+	return &NetworkInterface{
+		subnetProvider:        subnetProvider,
+		securityGroupProvider: securityGroupProvider,
+	}
+}
+
+func (n *NetworkInterface) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass) (reconcile.Result, error) {
+	if len(nodeClass.Spec.NetworkInterfaces) == 0 {
+		nodeClass.Status.NetworkInterfaces = nil
+		if err := nodeClass.StatusConditions().Clear(v1.ConditionTypeNetworkInterfacesReady); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+	networkInterfaces := lo.Map(nodeClass.Spec.NetworkInterfaces, func(ni v1.NetworkInterface, _ int) v1.NetworkInterfaceStatus {
+		return v1.NetworkInterfaceStatus{DeviceIndex: ni.DeviceIndex}
+	})
+	for i, ni := range nodeClass.Spec.NetworkInterfaces {
+		// The providers resolve selector terms from an EC2NodeClass, so we construct one for each network interface
+		selector := &v1.EC2NodeClass{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s/%d", nodeClass.Name, ni.DeviceIndex)},
+			Spec: v1.EC2NodeClassSpec{
+				SubnetSelectorTerms:        ni.SubnetSelectorTerms,
+				SecurityGroupSelectorTerms: ni.SecurityGroupSelectorTerms,
+			},
+		}
+		if len(ni.SubnetSelectorTerms) != 0 {
+			subnets, err := n.subnetProvider.List(ctx, selector)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("getting subnets for network interface %d, %w", ni.DeviceIndex, err)
+			}
+			if len(subnets) == 0 {
+				nodeClass.Status.NetworkInterfaces = nil
+				nodeClass.StatusConditions().SetFalse(v1.ConditionTypeNetworkInterfacesReady, "SubnetsNotFound", fmt.Sprintf("SubnetSelector for network interface %d did not match any Subnets", ni.DeviceIndex))
+				return reconcile.Result{RequeueAfter: time.Minute}, nil
+			}
+			// Subnets are ordered by available IP addresses so that launch templates use the subnet with the most
+			// available IP addresses in each zone
+			sort.Slice(subnets, func(i, j int) bool {
+				if lo.FromPtr(subnets[i].AvailableIpAddressCount) != lo.FromPtr(subnets[j].AvailableIpAddressCount) {
+					return lo.FromPtr(subnets[i].AvailableIpAddressCount) > lo.FromPtr(subnets[j].AvailableIpAddressCount)
+				}
+				return lo.FromPtr(subnets[i].SubnetId) < lo.FromPtr(subnets[j].SubnetId)
+			})
+			networkInterfaces[i].Subnets = lo.Map(subnets, func(ec2subnet ec2types.Subnet, _ int) v1.Subnet {
+				return v1.Subnet{
+					ID:     lo.FromPtr(ec2subnet.SubnetId),
+					Zone:   lo.FromPtr(ec2subnet.AvailabilityZone),
+					ZoneID: lo.FromPtr(ec2subnet.AvailabilityZoneId),
+				}
+			})
+		}
+		if len(ni.SecurityGroupSelectorTerms) != 0 {
+			securityGroups, err := n.securityGroupProvider.List(ctx, selector)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("getting security groups for network interface %d, %w", ni.DeviceIndex, err)
+			}
+			if len(securityGroups) == 0 {
+				nodeClass.Status.NetworkInterfaces = nil
+				nodeClass.StatusConditions().SetFalse(v1.ConditionTypeNetworkInterfacesReady, "SecurityGroupsNotFound", fmt.Sprintf("SecurityGroupSelector for network interface %d did not match any SecurityGroups", ni.DeviceIndex))
+				return reconcile.Result{RequeueAfter: time.Minute}, nil
+			}
+			sort.Slice(securityGroups, func(i, j int) bool {
+				return lo.FromPtr(securityGroups[i].GroupId) < lo.FromPtr(securityGroups[j].GroupId)
+			})
+			networkInterfaces[i].SecurityGroups = lo.Map(securityGroups, func(securityGroup ec2types.SecurityGroup, _ int) v1.SecurityGroup {
+				return v1.SecurityGroup{
+					ID:   lo.FromPtr(securityGroup.GroupId),
+					Name: lo.FromPtr(securityGroup.GroupName),
+				}
+			})
+		}
+	}
+	sort.Slice(networkInterfaces, func(i, j int) bool {
+		return networkInterfaces[i].DeviceIndex < networkInterfaces[j].DeviceIndex
+	})
+	nodeClass.Status.NetworkInterfaces = networkInterfaces
+	nodeClass.StatusConditions().SetTrue(v1.ConditionTypeNetworkInterfacesReady)
+	return reconcile.Result{RequeueAfter: time.Minute}, nil
+}
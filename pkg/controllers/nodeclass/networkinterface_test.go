@@ -0,0 +1,102 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeclass_test
+
+import (
+	"github.com/samber/lo"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+)
+
+var _ = Describe("NodeClass Network Interface Status Controller", func() {
+	BeforeEach(func() {
+		nodeClass = test.EC2NodeClass(v1.EC2NodeClass{
+			Spec: v1.EC2NodeClassSpec{
+				SubnetSelectorTerms: []v1.SubnetSelectorTerm{
+					{
+						Tags: map[string]string{"*": "*"},
+					},
+				},
+				SecurityGroupSelectorTerms: []v1.SecurityGroupSelectorTerm{
+					{
+						Tags: map[string]string{"*": "*"},
+					},
+				},
+				AMIFamily: lo.ToPtr(v1.AMIFamilyCustom),
+				AMISelectorTerms: []v1.AMISelectorTerm{
+					{
+						Tags: map[string]string{"*": "*"},
+					},
+				},
+			},
+		})
+	})
+	It("should not set the network interface status when no network interfaces are configured", func() {
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.NetworkInterfaces).To(BeNil())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeNetworkInterfacesReady)).To(BeNil())
+	})
+	It("should resolve the subnets and security groups of each network interface", func() {
+		nodeClass.Spec.NetworkInterfaces = []v1.NetworkInterface{
+			{
+				DeviceIndex:                0,
+				SecurityGroupSelectorTerms: []v1.SecurityGroupSelectorTerm{{ID: "sg-test1"}},
+			},
+			{
+				DeviceIndex:         1,
+				SubnetSelectorTerms: []v1.SubnetSelectorTerm{{ID: "subnet-test2"}, {ID: "subnet-test3"}},
+			},
+		}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.NetworkInterfaces).To(Equal([]v1.NetworkInterfaceStatus{
+			{
+				DeviceIndex:    0,
+				SecurityGroups: []v1.SecurityGroup{{ID: "sg-test1", Name: "securityGroup-test1"}},
+			},
+			{
+				DeviceIndex: 1,
+				Subnets: []v1.Subnet{
+					{ID: "subnet-test2", Zone: "test-zone-1b", ZoneID: "tstz1-1b"},
+					{ID: "subnet-test3", Zone: "test-zone-1c", ZoneID: "tstz1-1c"},
+				},
+			},
+		}))
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeNetworkInterfacesReady).IsTrue()).To(BeTrue())
+	})
+	It("should set the condition to false when a network interface's subnets can't be resolved", func() {
+		nodeClass.Spec.NetworkInterfaces = []v1.NetworkInterface{
+			{
+				DeviceIndex:         1,
+				SubnetSelectorTerms: []v1.SubnetSelectorTerm{{Tags: map[string]string{"purpose": "storage"}}},
+			},
+		}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.NetworkInterfaces).To(BeNil())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeNetworkInterfacesReady).IsFalse()).To(BeTrue())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeNetworkInterfacesReady).Reason).To(Equal("SubnetsNotFound"))
+		Expect(nodeClass.StatusConditions().Root().IsFalse()).To(BeTrue())
+	})
+})
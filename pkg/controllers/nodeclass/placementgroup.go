@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeclass
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/samber/lo"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/placementgroup"
+)
+
+const (
+	ConditionReasonPlacementGroupNotFound  = "PlacementGroupNotFound"
+	ConditionReasonPlacementGroupAmbiguous = "PlacementGroupAmbiguous"
+)
+
+type PlacementGroup struct {
+	placementGroupProvider placementgroup.Provider
+}
+
+func NewPlacementGroupReconciler(placementGroupProvider placementgroup.Provider) *PlacementGroup {
+	return &PlacementGroup{
+		placementGroupProvider: placementGroupProvider,
+	}
+}
+
+func (p *PlacementGroup) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass) (reconcile.Result, error) {
+	if nodeClass.Spec.PlacementGroup == nil {
+		nodeClass.Status.PlacementGroup = nil
+		if err := nodeClass.StatusConditions().Clear(v1.ConditionTypePlacementGroupReady); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+	placementGroups, err := p.placementGroupProvider.List(ctx, nodeClass)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("getting placement groups, %w", err)
+	}
+	switch len(placementGroups) {
+	case 0:
+		nodeClass.Status.PlacementGroup = nil
+		nodeClass.StatusConditions().SetFalse(v1.ConditionTypePlacementGroupReady, ConditionReasonPlacementGroupNotFound, "PlacementGroupSelector did not match any available PlacementGroups")
+		// The placement group may be created after the EC2NodeClass, so we need to keep checking for it
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	case 1:
+	default:
+		// Instances must be launched into a single placement group, so rather than picking one arbitrarily we require
+		// the user to narrow down their selector
+		nodeClass.Status.PlacementGroup = nil
+		nodeClass.StatusConditions().SetFalse(v1.ConditionTypePlacementGroupReady, ConditionReasonPlacementGroupAmbiguous, fmt.Sprintf(
+			"PlacementGroupSelector matched multiple PlacementGroups (%s), expected exactly one",
+			strings.Join(lo.Map(placementGroups, func(pg ec2types.PlacementGroup, _ int) string { return lo.FromPtr(pg.GroupId) }), ", "),
+		))
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+	nodeClass.Status.PlacementGroup = &v1.PlacementGroup{
+		ID:             lo.FromPtr(placementGroups[0].GroupId),
+		Name:           lo.FromPtr(placementGroups[0].GroupName),
+		Strategy:       v1.PlacementGroupStrategy(placementGroups[0].Strategy),
+		PartitionCount: lo.FromPtr(placementGroups[0].PartitionCount),
+		SpreadLevel:    string(placementGroups[0].SpreadLevel),
+	}
+	nodeClass.StatusConditions().SetTrue(v1.ConditionTypePlacementGroupReady)
+	return reconcile.Result{RequeueAfter: 5 * time.Minute}, nil
+}
@@ -0,0 +1,177 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeclass_test
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/samber/lo"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+)
+
+var _ = Describe("NodeClass Placement Group Status Controller", func() {
+	BeforeEach(func() {
+		nodeClass = test.EC2NodeClass(v1.EC2NodeClass{
+			Spec: v1.EC2NodeClassSpec{
+				SubnetSelectorTerms: []v1.SubnetSelectorTerm{
+					{
+						Tags: map[string]string{"*": "*"},
+					},
+				},
+				SecurityGroupSelectorTerms: []v1.SecurityGroupSelectorTerm{
+					{
+						Tags: map[string]string{"*": "*"},
+					},
+				},
+				AMIFamily: lo.ToPtr(v1.AMIFamilyCustom),
+				AMISelectorTerms: []v1.AMISelectorTerm{
+					{
+						Tags: map[string]string{"*": "*"},
+					},
+				},
+			},
+		})
+		awsEnv.EC2API.DescribePlacementGroupsOutput.Set(&ec2.DescribePlacementGroupsOutput{
+			PlacementGroups: []ec2types.PlacementGroup{
+				{
+					GroupId:   lo.ToPtr("pg-test1"),
+					GroupName: lo.ToPtr("placementGroup-test1"),
+					State:     ec2types.PlacementGroupStateAvailable,
+					Strategy:  ec2types.PlacementStrategyCluster,
+					Tags:      []ec2types.Tag{{Key: lo.ToPtr("workload"), Value: lo.ToPtr("hpc")}},
+				},
+				{
+					GroupId:        lo.ToPtr("pg-test2"),
+					GroupName:      lo.ToPtr("placementGroup-test2"),
+					State:          ec2types.PlacementGroupStateAvailable,
+					Strategy:       ec2types.PlacementStrategyPartition,
+					PartitionCount: lo.ToPtr[int32](3),
+					Tags:           []ec2types.Tag{{Key: lo.ToPtr("workload"), Value: lo.ToPtr("cassandra")}},
+				},
+				{
+					GroupId:     lo.ToPtr("pg-test3"),
+					GroupName:   lo.ToPtr("placementGroup-test3"),
+					State:       ec2types.PlacementGroupStateAvailable,
+					Strategy:    ec2types.PlacementStrategySpread,
+					SpreadLevel: ec2types.SpreadLevelRack,
+					Tags:        []ec2types.Tag{{Key: lo.ToPtr("workload"), Value: lo.ToPtr("cassandra")}},
+				},
+				{
+					GroupId:   lo.ToPtr("pg-test4"),
+					GroupName: lo.ToPtr("placementGroup-test4"),
+					State:     ec2types.PlacementGroupStateDeleted,
+					Strategy:  ec2types.PlacementStrategyCluster,
+				},
+			},
+		})
+	})
+	It("should not set the placement group status when no placement group is selected", func() {
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.PlacementGroup).To(BeNil())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady)).To(BeNil())
+	})
+	It("should resolve a placement group by id", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-test1"}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.PlacementGroup).To(Equal(&v1.PlacementGroup{
+			ID:       "pg-test1",
+			Name:     "placementGroup-test1",
+			Strategy: v1.PlacementGroupStrategyCluster,
+		}))
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady).IsTrue()).To(BeTrue())
+	})
+	It("should resolve a placement group by name", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{Name: "placementGroup-test2"}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.PlacementGroup).To(Equal(&v1.PlacementGroup{
+			ID:             "pg-test2",
+			Name:           "placementGroup-test2",
+			Strategy:       v1.PlacementGroupStrategyPartition,
+			PartitionCount: 3,
+		}))
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady).IsTrue()).To(BeTrue())
+	})
+	It("should resolve a placement group by tags and strategy", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{
+			Tags:     map[string]string{"workload": "cassandra"},
+			Strategy: lo.ToPtr(v1.PlacementGroupStrategySpread),
+		}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.PlacementGroup).To(Equal(&v1.PlacementGroup{
+			ID:          "pg-test3",
+			Name:        "placementGroup-test3",
+			Strategy:    v1.PlacementGroupStrategySpread,
+			SpreadLevel: "rack",
+		}))
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady).IsTrue()).To(BeTrue())
+	})
+	It("should set the condition to false when the selector matches multiple placement groups", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{Tags: map[string]string{"workload": "cassandra"}}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.PlacementGroup).To(BeNil())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady).IsFalse()).To(BeTrue())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady).Reason).To(Equal(nodeclass.ConditionReasonPlacementGroupAmbiguous))
+		Expect(nodeClass.StatusConditions().Root().IsFalse()).To(BeTrue())
+	})
+	It("should set the condition to false when the placement group doesn't exist", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-test5"}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.PlacementGroup).To(BeNil())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady).IsFalse()).To(BeTrue())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady).Reason).To(Equal(nodeclass.ConditionReasonPlacementGroupNotFound))
+		Expect(nodeClass.StatusConditions().Root().IsFalse()).To(BeTrue())
+	})
+	It("should not select placement groups that aren't available", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{Name: "placementGroup-test4"}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.PlacementGroup).To(BeNil())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady).IsFalse()).To(BeTrue())
+	})
+	It("should clear the placement group status when the selector is removed", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-test1"}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.PlacementGroup).ToNot(BeNil())
+
+		nodeClass.Spec.PlacementGroup = nil
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.Status.PlacementGroup).To(BeNil())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypePlacementGroupReady)).To(BeNil())
+	})
+})
@@ -0,0 +1,291 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeclass
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	sdk "github.com/aws/karpenter-provider-aws/pkg/aws"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+)
+
+const (
+	ConditionReasonSecurityGroupRulesMissing = "SecurityGroupRulesMissing"
+
+	// clusterSecurityGroupTagKey is applied by EKS to the security group it creates for the control plane's network interfaces
+	clusterSecurityGroupTagKey = "aws:eks:cluster-name"
+)
+
+// securityGroupRequirement is ingress traffic that nodes must accept to join and operate in the cluster
+type securityGroupRequirement struct {
+	Name     string
+	Protocol string
+	FromPort int32
+	ToPort   int32
+	// FromControlPlane is true when the traffic originates from the cluster security group rather than other nodes
+	FromControlPlane bool
+}
+
+var securityGroupRequirements = []securityGroupRequirement{
+	{Name: "kubelet", Protocol: "tcp", FromPort: 10250, ToPort: 10250, FromControlPlane: true},
+	{Name: "dns", Protocol: "tcp", FromPort: 53, ToPort: 53},
+	{Name: "dns", Protocol: "udp", FromPort: 53, ToPort: 53},
+	{Name: "ephemeral", Protocol: "tcp", FromPort: 1025, ToPort: 65535},
+}
+
+func (r securityGroupRequirement) String() string {
+	ports := fmt.Sprint(r.FromPort)
+	if r.FromPort != r.ToPort {
+		ports = fmt.Sprintf("%d-%d", r.FromPort, r.ToPort)
+	}
+	return fmt.Sprintf("%s (%s %s)", r.Name, r.Protocol, ports)
+}
+
+// SecurityGroupRules validates the ingress rules of the resolved security groups against the traffic that nodes need
+// to join the cluster. Validation is opt-in through the validate-security-group-rules option.
+type SecurityGroupRules struct {
+	ec2api sdk.EC2API
+}
+
+func NewSecurityGroupRulesReconciler(ec2api sdk.EC2API) *SecurityGroupRules {
+	return &SecurityGroupRules{
+		ec2api: ec2api,
+	}
+}
+
+func (s *SecurityGroupRules) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass) (reconcile.Result, error) {
+	if !options.FromContext(ctx).ValidateSecurityGroupRules || len(nodeClass.Status.SecurityGroups) == 0 {
+		_ = nodeClass.StatusConditions().Clear(v1.ConditionTypeSecurityGroupRulesValid)
+		return reconcile.Result{}, nil
+	}
+	nodeSecurityGroups := sets.New(lo.Map(nodeClass.Status.SecurityGroups, func(sg v1.SecurityGroup, _ int) string { return sg.ID })...)
+	clusterSecurityGroup, err := s.clusterSecurityGroup(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	rules, err := s.ingressRules(ctx, sets.List(nodeSecurityGroups))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	vpcCIDRs, err := s.vpcCIDRs(ctx, nodeClass)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	prefixLists, err := s.prefixListCIDRs(ctx, rules)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	missing := lo.Filter(securityGroupRequirements, func(req securityGroupRequirement, _ int) bool {
+		sources := nodeSecurityGroups
+		// If the cluster security group can't be discovered (e.g. the control plane isn't managed by EKS), we expect
+		// the control plane to share a security group with the nodes.
+		if req.FromControlPlane && clusterSecurityGroup != "" {
+			sources = sets.New(clusterSecurityGroup)
+		}
+		matching := lo.Filter(rules, func(rule ec2types.SecurityGroupRule, _ int) bool { return allowsTraffic(rule, req) })
+		if lo.ContainsBy(matching, func(rule ec2types.SecurityGroupRule) bool {
+			return rule.ReferencedGroupInfo != nil && sources.Has(lo.FromPtr(rule.ReferencedGroupInfo.GroupId))
+		}) {
+			return false
+		}
+		// Both the nodes and the control plane's network interfaces are addressed from the VPC, so address based rules
+		// only admit the traffic if they span the VPC's address ranges
+		return !coversCIDRs(lo.FlatMap(matching, func(rule ec2types.SecurityGroupRule, _ int) []*net.IPNet {
+			return ruleCIDRs(rule, prefixLists)
+		}), vpcCIDRs)
+	})
+	if len(missing) > 0 {
+		nodeClass.StatusConditions().SetFalse(
+			v1.ConditionTypeSecurityGroupRulesValid,
+			ConditionReasonSecurityGroupRulesMissing,
+			fmt.Sprintf("SecurityGroups %s don't allow ingress for %s", strings.Join(sets.List(nodeSecurityGroups), ", "), strings.Join(lo.Map(missing, func(req securityGroupRequirement, _ int) string {
+				return req.String()
+			}), ", ")),
+		)
+	} else {
+		nodeClass.StatusConditions().SetTrue(v1.ConditionTypeSecurityGroupRulesValid)
+	}
+	return reconcile.Result{RequeueAfter: 5 * time.Minute}, nil
+}
+
+// clusterSecurityGroup returns the ID of the security group EKS attaches to the control plane's network interfaces,
+// or an empty string if one can't be found
+func (s *SecurityGroupRules) clusterSecurityGroup(ctx context.Context) (string, error) {
+	out, err := s.ec2api.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String(fmt.Sprintf("tag:%s", clusterSecurityGroupTagKey)),
+			Values: []string{options.FromContext(ctx).ClusterName},
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("describing cluster security group, %w", err)
+	}
+	if len(out.SecurityGroups) == 0 {
+		return "", nil
+	}
+	return lo.FromPtr(out.SecurityGroups[0].GroupId), nil
+}
+
+func (s *SecurityGroupRules) ingressRules(ctx context.Context, securityGroupIDs []string) ([]ec2types.SecurityGroupRule, error) {
+	var rules []ec2types.SecurityGroupRule
+	paginator := ec2.NewDescribeSecurityGroupRulesPaginator(s.ec2api, &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("group-id"),
+			Values: securityGroupIDs,
+		}},
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing security group rules, %w", err)
+		}
+		rules = append(rules, lo.Reject(out.SecurityGroupRules, func(rule ec2types.SecurityGroupRule, _ int) bool {
+			return lo.FromPtr(rule.IsEgress)
+		})...)
+	}
+	return rules, nil
+}
+
+// vpcCIDRs returns the associated address ranges of the VPC that the EC2NodeClass' subnets belong to
+func (s *SecurityGroupRules) vpcCIDRs(ctx context.Context, nodeClass *v1.EC2NodeClass) ([]*net.IPNet, error) {
+	if len(nodeClass.Status.Subnets) == 0 {
+		return nil, nil
+	}
+	subnets, err := s.ec2api.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("subnet-id"),
+			Values: lo.Map(nodeClass.Status.Subnets, func(subnet v1.Subnet, _ int) string { return subnet.ID }),
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing subnets, %w", err)
+	}
+	vpcIDs := lo.Uniq(lo.FilterMap(subnets.Subnets, func(subnet ec2types.Subnet, _ int) (string, bool) {
+		return lo.FromPtr(subnet.VpcId), subnet.VpcId != nil
+	}))
+	if len(vpcIDs) == 0 {
+		return nil, nil
+	}
+	vpcs, err := s.ec2api.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{VpcIds: vpcIDs})
+	if err != nil {
+		return nil, fmt.Errorf("describing vpcs, %w", err)
+	}
+	var cidrs []string
+	for _, vpc := range vpcs.Vpcs {
+		for _, association := range vpc.CidrBlockAssociationSet {
+			if association.CidrBlockState != nil && association.CidrBlockState.State == ec2types.VpcCidrBlockStateCodeAssociated {
+				cidrs = append(cidrs, lo.FromPtr(association.CidrBlock))
+			}
+		}
+		for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+			if association.Ipv6CidrBlockState != nil && association.Ipv6CidrBlockState.State == ec2types.VpcCidrBlockStateCodeAssociated {
+				cidrs = append(cidrs, lo.FromPtr(association.Ipv6CidrBlock))
+			}
+		}
+	}
+	return parseCIDRs(cidrs), nil
+}
+
+// prefixListCIDRs returns the address ranges of the managed prefix lists referenced by the rules, keyed by prefix list ID
+func (s *SecurityGroupRules) prefixListCIDRs(ctx context.Context, rules []ec2types.SecurityGroupRule) (map[string][]*net.IPNet, error) {
+	prefixLists := map[string][]*net.IPNet{}
+	for _, id := range lo.Uniq(lo.FilterMap(rules, func(rule ec2types.SecurityGroupRule, _ int) (string, bool) {
+		return lo.FromPtr(rule.PrefixListId), rule.PrefixListId != nil
+	})) {
+		var cidrs []string
+		paginator := ec2.NewGetManagedPrefixListEntriesPaginator(s.ec2api, &ec2.GetManagedPrefixListEntriesInput{
+			PrefixListId: aws.String(id),
+		})
+		for paginator.HasMorePages() {
+			out, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("getting managed prefix list entries, %w", err)
+			}
+			cidrs = append(cidrs, lo.FilterMap(out.Entries, func(entry ec2types.PrefixListEntry, _ int) (string, bool) {
+				return lo.FromPtr(entry.Cidr), entry.Cidr != nil
+			})...)
+		}
+		prefixLists[id] = parseCIDRs(cidrs)
+	}
+	return prefixLists, nil
+}
+
+// protocolNames maps the protocol numbers that EC2 may return for a rule to the names used by the requirements
+var protocolNames = map[string]string{
+	"6":  "tcp",
+	"17": "udp",
+}
+
+// allowsTraffic returns true if the rule admits the requirement's protocol across its whole port range, regardless of
+// the traffic's source
+func allowsTraffic(rule ec2types.SecurityGroupRule, req securityGroupRequirement) bool {
+	protocol := lo.FromPtr(rule.IpProtocol)
+	if protocol == "-1" {
+		return true
+	}
+	if name, ok := protocolNames[protocol]; ok {
+		protocol = name
+	}
+	return protocol == req.Protocol && lo.FromPtr(rule.FromPort) <= req.FromPort && lo.FromPtr(rule.ToPort) >= req.ToPort
+}
+
+// ruleCIDRs returns the address ranges that a rule admits traffic from
+func ruleCIDRs(rule ec2types.SecurityGroupRule, prefixLists map[string][]*net.IPNet) []*net.IPNet {
+	switch {
+	case rule.CidrIpv4 != nil:
+		return parseCIDRs([]string{lo.FromPtr(rule.CidrIpv4)})
+	case rule.CidrIpv6 != nil:
+		return parseCIDRs([]string{lo.FromPtr(rule.CidrIpv6)})
+	case rule.PrefixListId != nil:
+		return prefixLists[lo.FromPtr(rule.PrefixListId)]
+	default:
+		return nil
+	}
+}
+
+// coversCIDRs returns true if every IPv4 range, or every IPv6 range, of targets is contained by one of the sources
+func coversCIDRs(sources []*net.IPNet, targets []*net.IPNet) bool {
+	covered := func(target *net.IPNet) bool {
+		return lo.ContainsBy(sources, func(source *net.IPNet) bool { return containsCIDR(source, target) })
+	}
+	ipv4, ipv6 := lo.FilterReject(targets, func(target *net.IPNet, _ int) bool { return target.IP.To4() != nil })
+	return (len(ipv4) > 0 && lo.EveryBy(ipv4, covered)) || (len(ipv6) > 0 && lo.EveryBy(ipv6, covered))
+}
+
+// containsCIDR returns true if every address of inner is also an address of outer
+func containsCIDR(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	return outerBits == innerBits && outerOnes <= innerOnes && outer.Contains(inner.IP)
+}
+
+func parseCIDRs(cidrs []string) []*net.IPNet {
+	return lo.FilterMap(cidrs, func(cidr string, _ int) (*net.IPNet, bool) {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		return ipNet, err == nil
+	})
+}
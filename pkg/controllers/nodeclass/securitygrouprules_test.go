@@ -0,0 +1,194 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeclass_test
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/samber/lo"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+)
+
+var _ = Describe("NodeClass Security Group Rules Status Controller", func() {
+	BeforeEach(func() {
+		nodeClass = test.EC2NodeClass(v1.EC2NodeClass{
+			Spec: v1.EC2NodeClassSpec{
+				SecurityGroupSelectorTerms: []v1.SecurityGroupSelectorTerm{
+					{
+						ID: "sg-test1",
+					},
+				},
+			},
+		})
+		ctx = options.ToContext(ctx, test.Options(test.OptionsFields{ValidateSecurityGroupRules: lo.ToPtr(true)}))
+	})
+	It("should not set the condition when security group rule validation is disabled", func() {
+		ctx = options.ToContext(ctx, test.Options())
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeSecurityGroupRulesValid)).To(BeNil())
+	})
+	It("should set the condition to true when a self-referencing rule allows all traffic", func() {
+		awsEnv.EC2API.DescribeSecurityGroupRulesBehavior.Output.Set(&ec2.DescribeSecurityGroupRulesOutput{
+			SecurityGroupRules: []ec2types.SecurityGroupRule{
+				{
+					GroupId:             aws.String("sg-test1"),
+					IsEgress:            aws.Bool(false),
+					IpProtocol:          aws.String("-1"),
+					FromPort:            aws.Int32(-1),
+					ToPort:              aws.Int32(-1),
+					ReferencedGroupInfo: &ec2types.ReferencedSecurityGroup{GroupId: aws.String("sg-test1")},
+				},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeSecurityGroupRulesValid).IsTrue()).To(BeTrue())
+	})
+	It("should report the missing rules when the security groups don't allow the required traffic", func() {
+		awsEnv.EC2API.DescribeSecurityGroupRulesBehavior.Output.Set(&ec2.DescribeSecurityGroupRulesOutput{
+			SecurityGroupRules: []ec2types.SecurityGroupRule{
+				{
+					GroupId:    aws.String("sg-test1"),
+					IsEgress:   aws.Bool(false),
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int32(10250),
+					ToPort:     aws.Int32(10250),
+					CidrIpv4:   aws.String("10.0.0.0/16"),
+				},
+				{
+					GroupId:             aws.String("sg-test1"),
+					IsEgress:            aws.Bool(false),
+					IpProtocol:          aws.String("tcp"),
+					FromPort:            aws.Int32(53),
+					ToPort:              aws.Int32(53),
+					ReferencedGroupInfo: &ec2types.ReferencedSecurityGroup{GroupId: aws.String("sg-test1")},
+				},
+				// Egress rules don't allow traffic to reach the node
+				{
+					GroupId:    aws.String("sg-test1"),
+					IsEgress:   aws.Bool(true),
+					IpProtocol: aws.String("-1"),
+					FromPort:   aws.Int32(-1),
+					ToPort:     aws.Int32(-1),
+					CidrIpv4:   aws.String("0.0.0.0/0"),
+				},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		condition := nodeClass.StatusConditions().Get(v1.ConditionTypeSecurityGroupRulesValid)
+		Expect(condition.IsFalse()).To(BeTrue())
+		Expect(condition.Reason).To(Equal(nodeclass.ConditionReasonSecurityGroupRulesMissing))
+		Expect(condition.Message).To(Equal("SecurityGroups sg-test1 don't allow ingress for dns (udp 53), ephemeral (tcp 1025-65535)"))
+	})
+	It("should not accept rules that reference a security group other than the nodes' security groups", func() {
+		awsEnv.EC2API.DescribeSecurityGroupRulesBehavior.Output.Set(&ec2.DescribeSecurityGroupRulesOutput{
+			SecurityGroupRules: []ec2types.SecurityGroupRule{
+				{
+					GroupId:             aws.String("sg-test1"),
+					IsEgress:            aws.Bool(false),
+					IpProtocol:          aws.String("-1"),
+					FromPort:            aws.Int32(-1),
+					ToPort:              aws.Int32(-1),
+					ReferencedGroupInfo: &ec2types.ReferencedSecurityGroup{GroupId: aws.String("sg-other")},
+				},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeSecurityGroupRulesValid).IsFalse()).To(BeTrue())
+	})
+	It("should not accept address based rules that don't allow the required ports", func() {
+		awsEnv.EC2API.DescribeSecurityGroupRulesBehavior.Output.Set(&ec2.DescribeSecurityGroupRulesOutput{
+			SecurityGroupRules: []ec2types.SecurityGroupRule{
+				{
+					GroupId:    aws.String("sg-test1"),
+					IsEgress:   aws.Bool(false),
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int32(443),
+					ToPort:     aws.Int32(443),
+					CidrIpv4:   aws.String("0.0.0.0/0"),
+				},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		condition := nodeClass.StatusConditions().Get(v1.ConditionTypeSecurityGroupRulesValid)
+		Expect(condition.IsFalse()).To(BeTrue())
+		Expect(condition.Message).To(Equal("SecurityGroups sg-test1 don't allow ingress for kubelet (tcp 10250), dns (tcp 53), dns (udp 53), ephemeral (tcp 1025-65535)"))
+	})
+	It("should not accept address based rules that don't span the VPC's CIDR", func() {
+		awsEnv.EC2API.DescribeSecurityGroupRulesBehavior.Output.Set(&ec2.DescribeSecurityGroupRulesOutput{
+			SecurityGroupRules: []ec2types.SecurityGroupRule{
+				{
+					GroupId:    aws.String("sg-test1"),
+					IsEgress:   aws.Bool(false),
+					IpProtocol: aws.String("-1"),
+					FromPort:   aws.Int32(-1),
+					ToPort:     aws.Int32(-1),
+					CidrIpv4:   aws.String("10.0.1.0/24"),
+				},
+			},
+		})
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeSecurityGroupRulesValid).IsFalse()).To(BeTrue())
+	})
+	It("should accept address based rules that span the VPC's CIDR and the required ports", func() {
+		awsEnv.EC2API.DescribeSecurityGroupRulesBehavior.Output.Set(&ec2.DescribeSecurityGroupRulesOutput{
+			SecurityGroupRules: []ec2types.SecurityGroupRule{
+				{
+					GroupId:    aws.String("sg-test1"),
+					IsEgress:   aws.Bool(false),
+					IpProtocol: aws.String("6"),
+					FromPort:   aws.Int32(0),
+					ToPort:     aws.Int32(65535),
+					CidrIpv4:   aws.String("10.0.0.0/8"),
+				},
+				{
+					GroupId:      aws.String("sg-test1"),
+					IsEgress:     aws.Bool(false),
+					IpProtocol:   aws.String("udp"),
+					FromPort:     aws.Int32(53),
+					ToPort:       aws.Int32(53),
+					PrefixListId: aws.String("pl-test1"),
+				},
+			},
+		})
+		awsEnv.EC2API.GetManagedPrefixListEntriesBehavior.Output.Set(&ec2.GetManagedPrefixListEntriesOutput{
+			Entries: []ec2types.PrefixListEntry{{Cidr: aws.String("10.0.0.0/16")}},
+		})
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeSecurityGroupRulesValid).IsTrue()).To(BeTrue())
+	})
+})
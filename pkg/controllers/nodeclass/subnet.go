@@ -22,19 +22,27 @@ import (
 
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/karpenter/pkg/events"
 
 	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/subnet"
 )
 
 type Subnet struct {
+	kubeClient     client.Client
 	subnetProvider subnet.Provider
+	recorder       events.Recorder
 }
 
-func NewSubnetReconciler(subnetProvider subnet.Provider) *Subnet {
+func NewSubnetReconciler(kubeClient client.Client, subnetProvider subnet.Provider, recorder events.Recorder) *Subnet {
 	return &Subnet{
+		kubeClient:     kubeClient,
 		subnetProvider: subnetProvider,
+		recorder:       recorder,
 	}
 }
 
@@ -43,9 +51,18 @@ func (s *Subnet) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass) (rec
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("getting subnets, %w", err)
 	}
+	previous := sets.New(lo.Map(nodeClass.Status.Subnets, func(subnet v1.Subnet, _ int) string { return subnet.ID })...)
+	if err := s.deleteMetrics(ctx, nodeClass, previous.Difference(sets.New(lo.Map(subnets, func(subnet ec2types.Subnet, _ int) string {
+		return lo.FromPtr(subnet.SubnetId)
+	})...)).UnsortedList()); err != nil {
+		return reconcile.Result{}, err
+	}
 	if len(subnets) == 0 {
 		nodeClass.Status.Subnets = nil
 		nodeClass.StatusConditions().SetFalse(v1.ConditionTypeSubnetsReady, "SubnetsNotFound", "SubnetSelector did not match any Subnets")
+		if err := nodeClass.StatusConditions().Clear(v1.ConditionTypeSubnetsHaveCapacity); err != nil {
+			return reconcile.Result{}, err
+		}
 		// If users have omitted the necessary tags from their Subnets and later add them, we need to reprocess the information.
 		// Returning 'ok' in this case means that the nodeclass will remain in an unready state until the component is restarted.
 		return reconcile.Result{RequeueAfter: time.Minute}, nil
@@ -64,5 +81,56 @@ func (s *Subnet) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass) (rec
 		}
 	})
 	nodeClass.StatusConditions().SetTrue(v1.ConditionTypeSubnetsReady)
+	s.updateCapacityCondition(ctx, nodeClass)
 	return reconcile.Result{RequeueAfter: time.Minute}, nil
 }
+
+// deleteMetrics deletes the metrics of subnets that have left the EC2NodeClass's status, unless they're still
+// selected by another EC2NodeClass
+func (s *Subnet) deleteMetrics(ctx context.Context, nodeClass *v1.EC2NodeClass, subnetIDs []string) error {
+	if len(subnetIDs) == 0 {
+		return nil
+	}
+	nodeClassList := &v1.EC2NodeClassList{}
+	if err := s.kubeClient.List(ctx, nodeClassList); err != nil {
+		return fmt.Errorf("listing nodeclasses, %w", err)
+	}
+	selected := sets.New[string]()
+	for _, nc := range nodeClassList.Items {
+		if nc.Name == nodeClass.Name {
+			continue
+		}
+		selected.Insert(lo.Map(nc.Status.Subnets, func(subnet v1.Subnet, _ int) string { return subnet.ID })...)
+	}
+	s.subnetProvider.DeleteMetrics(lo.Reject(subnetIDs, func(id string, _ int) bool { return selected.Has(id) })...)
+	return nil
+}
+
+// updateCapacityCondition marks the subnets as lacking capacity when every subnet in a zone has available IP addresses
+// at or below the configured threshold, accounting for in-flight launches
+func (s *Subnet) updateCapacityCondition(ctx context.Context, nodeClass *v1.EC2NodeClass) {
+	// nolint:gosec
+	threshold := int32(options.FromContext(ctx).SubnetCapacityThreshold)
+	var exhaustedZones, exhaustedSubnets []string
+	for zone, subnets := range lo.GroupBy(nodeClass.Status.Subnets, func(subnet v1.Subnet) string { return subnet.Zone }) {
+		if lo.EveryBy(subnets, func(subnet v1.Subnet) bool {
+			ips, ok := s.subnetProvider.AvailableIPs(subnet.ID)
+			return ok && ips <= threshold
+		}) {
+			exhaustedZones = append(exhaustedZones, zone)
+			exhaustedSubnets = append(exhaustedSubnets, lo.Map(subnets, func(subnet v1.Subnet, _ int) string { return subnet.ID })...)
+		}
+	}
+	if len(exhaustedZones) == 0 {
+		nodeClass.StatusConditions().SetTrue(v1.ConditionTypeSubnetsHaveCapacity)
+		return
+	}
+	sort.Strings(exhaustedZones)
+	sort.Strings(exhaustedSubnets)
+	nodeClass.StatusConditions().SetFalse(
+		v1.ConditionTypeSubnetsHaveCapacity,
+		"SubnetsExhausted",
+		fmt.Sprintf("Subnets %v have %d or fewer available IP addresses in zones %v", exhaustedSubnets, threshold, exhaustedZones),
+	)
+	s.recorder.Publish(SubnetsExhaustedEvent(nodeClass, exhaustedZones, exhaustedSubnets))
+}
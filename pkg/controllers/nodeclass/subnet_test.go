@@ -21,6 +21,7 @@ import (
 	"github.com/samber/lo"
 
 	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 	"github.com/aws/karpenter-provider-aws/pkg/test"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -107,6 +108,32 @@ var _ = Describe("NodeClass Subnet Status Controller", func() {
 		}))
 		Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeSubnetsReady)).To(BeTrue())
 	})
+	It("Should mark subnets as having capacity when every zone has a subnet above the threshold", func() {
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeSubnetsHaveCapacity)).To(BeTrue())
+	})
+	It("Should mark subnets as lacking capacity when every subnet in a zone is at or below the threshold", func() {
+		ctx = options.ToContext(ctx, test.Options(test.OptionsFields{SubnetCapacityThreshold: lo.ToPtr(20)}))
+		awsEnv.EC2API.DescribeSubnetsBehavior.Output.Set(&ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{
+			{SubnetId: aws.String("subnet-test1"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(20)},
+			{SubnetId: aws.String("subnet-test2"), AvailabilityZone: aws.String("test-zone-1a"), AvailabilityZoneId: aws.String("tstz1-1a"), AvailableIpAddressCount: aws.Int32(5)},
+			{SubnetId: aws.String("subnet-test3"), AvailabilityZone: aws.String("test-zone-1b"), AvailabilityZoneId: aws.String("tstz1-1b"), AvailableIpAddressCount: aws.Int32(5)},
+			{SubnetId: aws.String("subnet-test4"), AvailabilityZone: aws.String("test-zone-1b"), AvailabilityZoneId: aws.String("tstz1-1b"), AvailableIpAddressCount: aws.Int32(100)},
+		}})
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeSubnetsReady)).To(BeTrue())
+		Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeSubnetsHaveCapacity)).To(BeFalse())
+		condition := nodeClass.StatusConditions().Get(v1.ConditionTypeSubnetsHaveCapacity)
+		Expect(condition.Reason).To(Equal("SubnetsExhausted"))
+		Expect(condition.Message).To(ContainSubstring("subnet-test1"))
+		Expect(condition.Message).To(ContainSubstring("subnet-test2"))
+		Expect(condition.Message).To(ContainSubstring("test-zone-1a"))
+		Expect(condition.Message).ToNot(ContainSubstring("test-zone-1b"))
+	})
 	It("Should resolve a valid selectors for Subnet by tags", func() {
 		nodeClass.Spec.SubnetSelectorTerms = []v1.SubnetSelectorTerm{
 			{
@@ -251,6 +278,29 @@ var _ = Describe("NodeClass Subnet Status Controller", func() {
 		}))
 		Expect(nodeClass.StatusConditions().IsTrue(v1.ConditionTypeSubnetsReady)).To(BeTrue())
 	})
+	It("Should delete the metrics of Subnets that are no longer selected", func() {
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		for _, name := range []string{"karpenter_cloudprovider_subnet_available_ip_addresses", "karpenter_cloudprovider_subnet_inflight_ip_addresses"} {
+			_, found := FindMetricWithLabelValues(name, map[string]string{"subnet_id": "subnet-test2", "zone": "test-zone-1b"})
+			Expect(found).To(BeTrue())
+		}
+
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		nodeClass.Spec.SubnetSelectorTerms = []v1.SubnetSelectorTerm{
+			{
+				ID: "subnet-test1",
+			},
+		}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		for _, name := range []string{"karpenter_cloudprovider_subnet_available_ip_addresses", "karpenter_cloudprovider_subnet_inflight_ip_addresses"} {
+			_, found := FindMetricWithLabelValues(name, map[string]string{"subnet_id": "subnet-test1", "zone": "test-zone-1a"})
+			Expect(found).To(BeTrue())
+			_, found = FindMetricWithLabelValues(name, map[string]string{"subnet_id": "subnet-test2"})
+			Expect(found).To(BeFalse())
+		}
+	})
 	It("Should not resolve a invalid selectors for Subnet", func() {
 		nodeClass.Spec.SubnetSelectorTerms = []v1.SubnetSelectorTerm{
 			{
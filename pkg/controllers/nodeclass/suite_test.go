@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/karpenter/pkg/test/v1alpha1"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 
@@ -88,9 +89,11 @@ var _ = BeforeSuite(func() {
 		awsEnv.InstanceTypesProvider,
 		awsEnv.LaunchTemplateProvider,
 		awsEnv.CapacityReservationProvider,
+		awsEnv.PlacementGroupProvider,
 		awsEnv.EC2API,
 		awsEnv.ValidationCache,
 		awsEnv.AMIResolver,
+		awsEnv.AMIHealthTracker,
 	)
 })
 
@@ -100,6 +103,7 @@ var _ = AfterSuite(func() {
 
 var _ = BeforeEach(func() {
 	ctx = coreoptions.ToContext(ctx, coretest.Options(coretest.OptionsFields{FeatureGates: coretest.FeatureGates{ReservedCapacity: lo.ToPtr(true)}}))
+	ctx = options.ToContext(ctx, test.Options())
 	nodeClass = test.EC2NodeClass()
 	awsEnv.Reset()
 	Expect(awsEnv.InstanceTypesProvider.UpdateInstanceTypes(ctx)).To(Succeed())
@@ -185,6 +189,27 @@ var _ = Describe("NodeClass Termination", func() {
 		Expect(ok).To(BeFalse())
 		ExpectNotFound(ctx, env.Client, nodeClass)
 	})
+	It("should delete the AMI copies for the nodeClass", func() {
+		awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{Images: []ec2types.Image{{
+			Name:         aws.String("ami-copy"),
+			ImageId:      aws.String("ami-copy"),
+			CreationDate: aws.String(time.Now().Format(time.RFC3339)),
+			Architecture: "x86_64",
+			Tags: []ec2types.Tag{
+				{Key: aws.String("eks:eks-cluster-name"), Value: aws.String("test-cluster")},
+				{Key: aws.String("karpenter.k8s.aws/ec2nodeclass"), Value: aws.String(nodeClass.Name)},
+			},
+			BlockDeviceMappings: []ec2types.BlockDeviceMapping{{Ebs: &ec2types.EbsBlockDevice{SnapshotId: aws.String("snap-copy")}}},
+			State:               ec2types.ImageStateAvailable,
+		}}})
+		controllerutil.AddFinalizer(nodeClass, v1.TerminationFinalizer)
+		ExpectApplied(ctx, env.Client, nodeClass)
+		Expect(env.Client.Delete(ctx, nodeClass)).To(Succeed())
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		Expect(aws.ToString(awsEnv.EC2API.DeregisterImageBehavior.CalledWithInput.Pop().ImageId)).To(Equal("ami-copy"))
+		Expect(aws.ToString(awsEnv.EC2API.DeleteSnapshotBehavior.CalledWithInput.Pop().SnapshotId)).To(Equal("snap-copy"))
+		ExpectNotFound(ctx, env.Client, nodeClass)
+	})
 	It("should succeed to delete the instance profile with no NodeClaims", func() {
 		awsEnv.IAMAPI.InstanceProfiles = map[string]*iamtypes.InstanceProfile{
 			profileName: {
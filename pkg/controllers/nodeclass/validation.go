@@ -41,6 +41,7 @@ import (
 	awserrors "github.com/aws/karpenter-provider-aws/pkg/errors"
 	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/amifamily"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/amifamily/bootstrap"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instance"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instancetype"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/launchtemplate"
@@ -53,6 +54,7 @@ const (
 	ConditionReasonRunInstancesAuthFailed         = "RunInstancesAuthCheckFailed"
 	ConditionReasonDependenciesNotReady           = "DependenciesNotReady"
 	ConditionReasonTagValidationFailed            = "TagValidationFailed"
+	ConditionReasonNodeConfigValidationFailed     = "NodeConfigValidationFailed"
 )
 
 var ValidationConditionMessages = map[string]string{
@@ -93,7 +95,7 @@ func NewValidationReconciler(
 
 // nolint:gocyclo
 func (v *Validation) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass) (reconcile.Result, error) {
-	if _, ok := lo.Find(v.requiredConditions(), func(cond string) bool {
+	if _, ok := lo.Find(v.requiredConditions(nodeClass), func(cond string) bool {
 		return nodeClass.StatusConditions().Get(cond).IsFalse()
 	}); ok {
 		// If any of the required status conditions are false, we know validation will fail regardless of the other values.
@@ -104,7 +106,7 @@ func (v *Validation) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass)
 		)
 		return reconcile.Result{}, nil
 	}
-	if _, ok := lo.Find(v.requiredConditions(), func(cond string) bool {
+	if _, ok := lo.Find(v.requiredConditions(nodeClass), func(cond string) bool {
 		return nodeClass.StatusConditions().Get(cond).IsUnknown()
 	}); ok {
 		// If none of the status conditions are false, but at least one is unknown, we should also consider the validation
@@ -129,6 +131,12 @@ func (v *Validation) Reconcile(ctx context.Context, nodeClass *v1.EC2NodeClass)
 		nodeClass.StatusConditions().SetFalse(v1.ConditionTypeValidationSucceeded, ConditionReasonTagValidationFailed, err.Error())
 		return reconcile.Result{}, reconcile.TerminalError(fmt.Errorf("validating tags, %w", err))
 	}
+	if c := lo.FromPtr(nodeClass.Spec.NodeConfig).Containerd; c != nil && c.Config != nil {
+		if err := bootstrap.ValidateContainerdConfig(*c.Config); err != nil {
+			nodeClass.StatusConditions().SetFalse(v1.ConditionTypeValidationSucceeded, ConditionReasonNodeConfigValidationFailed, err.Error())
+			return reconcile.Result{}, reconcile.TerminalError(fmt.Errorf("validating nodeConfig, %w", err))
+		}
+	}
 
 	if val, ok := v.cache.Get(v.cacheKey(nodeClass, tags)); ok {
 		// We still update the status condition even if it's cached since we may have had a conflict error previously
@@ -280,19 +288,28 @@ func (v *Validation) validateRunInstancesAuthorization(
 	return "", false, nil
 }
 
-func (*Validation) requiredConditions() []string {
-	return []string{
+func (*Validation) requiredConditions(nodeClass *v1.EC2NodeClass) []string {
+	conds := []string{
 		v1.ConditionTypeAMIsReady,
 		v1.ConditionTypeInstanceProfileReady,
 		v1.ConditionTypeSecurityGroupsReady,
 		v1.ConditionTypeSubnetsReady,
 	}
+	if len(nodeClass.Spec.NetworkInterfaces) != 0 {
+		conds = append(conds, v1.ConditionTypeNetworkInterfacesReady)
+	}
+	if nodeClass.Spec.PlacementGroup != nil {
+		conds = append(conds, v1.ConditionTypePlacementGroupReady)
+	}
+	return conds
 }
 
 func (*Validation) cacheKey(nodeClass *v1.EC2NodeClass, tags map[string]string) string {
 	hash := lo.Must(hashstructure.Hash([]interface{}{
 		nodeClass.Status.Subnets,
 		nodeClass.Status.SecurityGroups,
+		nodeClass.Status.NetworkInterfaces,
+		nodeClass.Status.PlacementGroup,
 		nodeClass.Status.AMIs,
 		nodeClass.Status.InstanceProfile,
 		nodeClass.Spec.MetadataOptions,
@@ -371,7 +388,7 @@ func (v *Validation) mockLaunchTemplateOptions(
 	if err != nil {
 		return nil, err
 	}
-	amiMap := amifamily.MapToInstanceTypes(instanceTypes, nodeClass.Status.AMIs)
+	amiMap := amifamily.MapToInstanceTypes(instanceTypes, nodeClass.Status.AMIs, false)
 	var selectedInstanceTypes []*cloudprovider.InstanceType
 	for _, ami := range nodeClass.Status.AMIs {
 		if len(amiMap[ami.ID]) == 0 {
@@ -397,6 +414,7 @@ func (v *Validation) mockLaunchTemplateOptions(
 	if len(selectedInstanceTypes) == 0 || lo.ContainsBy([]string{
 		v1.AMIFamilyWindows2019,
 		v1.AMIFamilyWindows2022,
+		v1.AMIFamilyWindows2025,
 	}, func(family string) bool {
 		return family == nodeClass.AMIFamily()
 	}) {
@@ -425,6 +443,9 @@ func (v *Validation) mockLaunchTemplateOptions(
 	if err != nil {
 		return nil, err
 	}
+	if len(opts) == 0 {
+		return nil, fmt.Errorf("no launch templates resolved for nodeclass")
+	}
 	return opts[0], nil
 }
 
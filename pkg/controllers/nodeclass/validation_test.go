@@ -137,6 +137,20 @@ var _ = Describe("NodeClass Validation Status Controller", func() {
 			Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeValidationSucceeded).IsTrue()).To(BeTrue())
 			Expect(nodeClass.StatusConditions().Get(status.ConditionReady).IsTrue()).To(BeTrue())
 		})
+		It("should update status condition on nodeClass as NotReady when the containerd config isn't valid TOML", func() {
+			nodeClass.Spec.Tags = map[string]string{}
+			nodeClass.Spec.AMIFamily = lo.ToPtr(v1.AMIFamilyAL2023)
+			nodeClass.Spec.NodeConfig = &v1.NodeConfigOverlay{
+				Containerd: &v1.NodeConfigContainerd{Config: lo.ToPtr("[plugins.\"io.containerd.grpc.v1.cri\"")},
+			}
+			ExpectApplied(ctx, env.Client, nodeClass)
+			err := ExpectObjectReconcileFailed(ctx, env.Client, controller, nodeClass)
+			Expect(err).To(HaveOccurred())
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeValidationSucceeded).IsFalse()).To(BeTrue())
+			Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeValidationSucceeded).Reason).To(Equal("NodeConfigValidationFailed"))
+			Expect(nodeClass.StatusConditions().Get(status.ConditionReady).IsFalse()).To(BeTrue())
+		})
 	})
 	Context("Authorization Validation", func() {
 		DescribeTable(
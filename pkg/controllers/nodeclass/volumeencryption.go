@@ -0,0 +1,63 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeclass
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/amifamily"
+)
+
+const (
+	ConditionReasonNoBlockDeviceMappings           = "NoBlockDeviceMappings"
+	ConditionReasonNonCompliantBlockDeviceMappings = "NonCompliantBlockDeviceMappings"
+)
+
+type VolumeEncryption struct{}
+
+func NewVolumeEncryptionReconciler() *VolumeEncryption {
+	return &VolumeEncryption{}
+}
+
+func (v *VolumeEncryption) Reconcile(_ context.Context, nodeClass *v1.EC2NodeClass) (reconcile.Result, error) {
+	if nodeClass.Spec.VolumeEncryptionPolicy == nil {
+		if err := nodeClass.StatusConditions().Clear(v1.ConditionTypeVolumeEncryptionCompliant); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+	// The compliance of the mappings only depends on the AMI family, so we don't need the launch options
+	bdms := amifamily.BlockDeviceMappings(nodeClass, amifamily.GetAMIFamily(nodeClass.AMIFamily(), &amifamily.Options{}))
+	if len(bdms) == 0 {
+		// Instances would be launched with the AMI's block device mappings, which Karpenter can't enforce encryption on
+		nodeClass.StatusConditions().SetFalse(v1.ConditionTypeVolumeEncryptionCompliant, ConditionReasonNoBlockDeviceMappings, "VolumeEncryptionPolicy requires blockDeviceMappings to be specified for the AMI family")
+		return reconcile.Result{}, nil
+	}
+	if devices := amifamily.NonCompliantBlockDeviceMappings(nodeClass.Spec.VolumeEncryptionPolicy, bdms); len(devices) != 0 {
+		nodeClass.StatusConditions().SetFalse(v1.ConditionTypeVolumeEncryptionCompliant, ConditionReasonNonCompliantBlockDeviceMappings, fmt.Sprintf(
+			"BlockDeviceMappings (%s) aren't encrypted with KMS key %q",
+			strings.Join(devices, ", "),
+			nodeClass.Spec.VolumeEncryptionPolicy.KMSKeyID,
+		))
+		return reconcile.Result{}, nil
+	}
+	nodeClass.StatusConditions().SetTrue(v1.ConditionTypeVolumeEncryptionCompliant)
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,103 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeclass_test
+
+import (
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+)
+
+var _ = Describe("NodeClass Volume Encryption Status Controller", func() {
+	const kmsKeyID = "arn:aws:kms:us-west-2:111122223333:key/test"
+	BeforeEach(func() {
+		nodeClass = test.EC2NodeClass(v1.EC2NodeClass{
+			Spec: v1.EC2NodeClassSpec{
+				AMISelectorTerms: []v1.AMISelectorTerm{{Alias: "al2023@latest"}},
+			},
+		})
+	})
+	It("should not set the condition when no policy is configured", func() {
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant)).To(BeNil())
+	})
+	It("should set the condition to true when every block device mapping is encrypted with the KMS key", func() {
+		nodeClass.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{KMSKeyID: kmsKeyID}
+		nodeClass.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{{
+			DeviceName: lo.ToPtr("/dev/xvda"),
+			EBS: &v1.BlockDevice{
+				VolumeSize: resource.NewScaledQuantity(20, resource.Giga),
+				Encrypted:  lo.ToPtr(true),
+				KMSKeyID:   lo.ToPtr(kmsKeyID),
+			},
+		}}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant).IsTrue()).To(BeTrue())
+	})
+	It("should set the condition to false when a block device mapping uses a different KMS key", func() {
+		nodeClass.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{KMSKeyID: kmsKeyID}
+		nodeClass.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{{
+			DeviceName: lo.ToPtr("/dev/xvda"),
+			EBS: &v1.BlockDevice{
+				VolumeSize: resource.NewScaledQuantity(20, resource.Giga),
+				Encrypted:  lo.ToPtr(true),
+				KMSKeyID:   lo.ToPtr("alias/other"),
+			},
+		}}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant).IsFalse()).To(BeTrue())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant).Reason).To(Equal(nodeclass.ConditionReasonNonCompliantBlockDeviceMappings))
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant).Message).To(ContainSubstring("/dev/xvda"))
+		Expect(nodeClass.StatusConditions().Root().IsFalse()).To(BeTrue())
+	})
+	It("should set the condition to false when the AMI family's default block device mappings aren't encrypted with the KMS key", func() {
+		nodeClass.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{KMSKeyID: kmsKeyID}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant).IsFalse()).To(BeTrue())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant).Reason).To(Equal(nodeclass.ConditionReasonNonCompliantBlockDeviceMappings))
+	})
+	It("should set the condition to true when the policy overrides the AMI family's default block device mappings", func() {
+		nodeClass.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{KMSKeyID: kmsKeyID, Mode: v1.VolumeEncryptionPolicyModeOverride}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant).IsTrue()).To(BeTrue())
+	})
+	It("should set the condition to false when there are no block device mappings to enforce the policy on", func() {
+		nodeClass.Spec.AMIFamily = lo.ToPtr(v1.AMIFamilyCustom)
+		nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Tags: map[string]string{"*": "*"}}}
+		nodeClass.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{KMSKeyID: kmsKeyID, Mode: v1.VolumeEncryptionPolicyModeOverride}
+		ExpectApplied(ctx, env.Client, nodeClass)
+		ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
+		nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant).IsFalse()).To(BeTrue())
+		Expect(nodeClass.StatusConditions().Get(v1.ConditionTypeVolumeEncryptionCompliant).Reason).To(Equal(nodeclass.ConditionReasonNoBlockDeviceMappings))
+	})
+})
@@ -0,0 +1,367 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warmpool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/awslabs/operatorpkg/singleton"
+	"github.com/samber/lo"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/operator/injection"
+	"sigs.k8s.io/karpenter/pkg/scheduling"
+	nodeclaimutils "sigs.k8s.io/karpenter/pkg/utils/nodeclaim"
+	nodepoolutils "sigs.k8s.io/karpenter/pkg/utils/nodepool"
+
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/instance"
+	"github.com/aws/karpenter-provider-aws/pkg/utils"
+)
+
+const (
+	// initializationTimeout is the maximum amount of time a warm pool member is left running waiting for its Node to
+	// register before it's stopped
+	initializationTimeout = 10 * time.Minute
+)
+
+// Controller maintains the warm pool of stopped instances for each NodePool whose EC2NodeClass configures one. Members
+// are launched like any other instance for the NodePool, stopped once they've initialized, and replaced if they no
+// longer match the EC2NodeClass or NodePool. Members are claimed by the instance provider when launching NodeClaims.
+type Controller struct {
+	kubeClient       client.Client
+	cloudProvider    cloudprovider.CloudProvider
+	instanceProvider instance.Provider
+	clk              clock.Clock
+}
+
+func NewController(clk clock.Clock, kubeClient client.Client, cloudProvider cloudprovider.CloudProvider, instanceProvider instance.Provider) *Controller {
+	return &Controller{
+		kubeClient:       kubeClient,
+		cloudProvider:    cloudProvider,
+		instanceProvider: instanceProvider,
+		clk:              clk,
+	}
+}
+
+func (c *Controller) Reconcile(ctx context.Context) (reconcile.Result, error) {
+	ctx = injection.WithControllerName(ctx, "nodeclass.warmpool")
+
+	// Most clusters don't configure a warm pool, so we only list warm pool members rather than all instances, NodeClaims
+	// and Nodes unless an EC2NodeClass configures one. Members still need to be terminated after a warm pool is removed.
+	nodeClassList := &v1.EC2NodeClassList{}
+	if err := c.kubeClient.List(ctx, nodeClassList); err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing ec2nodeclasses, %w", err)
+	}
+	if !lo.ContainsBy(nodeClassList.Items, func(nc v1.EC2NodeClass) bool { return nc.WarmPoolSize() != 0 }) {
+		warmPool, err := c.instanceProvider.ListWarmPool(ctx)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("listing warm pool instances, %w", err)
+		}
+		if len(warmPool) == 0 {
+			return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	}
+
+	instances, err := c.instanceProvider.List(ctx)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing instances, %w", err)
+	}
+	nodeClaims, err := nodeclaimutils.ListManaged(ctx, c.kubeClient, c.cloudProvider)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing nodeclaims, %w", err)
+	}
+	// An instance may still carry the warm pool tag after it's been claimed, so we never treat an instance that's
+	// backing a NodeClaim as a warm pool member
+	claimed := sets.New(lo.FilterMap(nodeClaims, func(nc *karpv1.NodeClaim, _ int) (string, bool) {
+		id, err := utils.ParseInstanceID(nc.Status.ProviderID)
+		return id, err == nil
+	})...)
+	members := lo.GroupBy(lo.Filter(instances, func(i *instance.Instance, _ int) bool {
+		return i.WarmPool != "" && !claimed.Has(i.ID)
+	}), func(i *instance.Instance) string { return i.WarmPool })
+	nodePools, err := nodepoolutils.ListManaged(ctx, c.kubeClient, c.cloudProvider)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing nodepools, %w", err)
+	}
+	nodeList := &corev1.NodeList{}
+	if err := c.kubeClient.List(ctx, nodeList); err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing nodes, %w", err)
+	}
+	nodes := lo.SliceToMap(nodeList.Items, func(n corev1.Node) (string, *corev1.Node) {
+		id, _ := utils.ParseInstanceID(n.Spec.ProviderID)
+		return id, &n
+	})
+
+	var errs []error
+	for _, nodePool := range nodePools {
+		errs = append(errs, c.reconcileWarmPool(ctx, nodePool.Name, nodePool, members[nodePool.Name], nodes))
+		delete(members, nodePool.Name)
+	}
+	// Any remaining members belong to NodePools that no longer exist
+	for nodePoolName, m := range members {
+		errs = append(errs, c.reconcileWarmPool(ctx, nodePoolName, nil, m, nodes))
+	}
+	if err := multierr.Combine(errs...); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+}
+
+//nolint:gocyclo
+func (c *Controller) reconcileWarmPool(ctx context.Context, nodePoolName string, nodePool *karpv1.NodePool, members []*instance.Instance, nodes map[string]*corev1.Node) error {
+	ctx = log.IntoContext(ctx, log.FromContext(ctx).WithValues("NodePool", klog.KRef("", nodePoolName)))
+	nodeClass, err := c.resolveNodeClass(ctx, nodePool)
+	if err != nil {
+		return err
+	}
+	if nodeClass == nil && len(members) == 0 {
+		WarmPoolInstances.DeletePartialMatch(map[string]string{nodePoolLabel: nodePoolName})
+		WarmPoolHourlyCostEstimate.Delete(map[string]string{nodePoolLabel: nodePoolName})
+		return nil
+	}
+
+	// Stopped members are preferred since they're ready to be claimed, followed by the oldest members
+	sort.SliceStable(members, func(i, j int) bool {
+		if iStopped, jStopped := members[i].State == ec2types.InstanceStateNameStopped, members[j].State == ec2types.InstanceStateNameStopped; iStopped != jStopped {
+			return iStopped
+		}
+		return members[i].LaunchTime.Before(members[j].LaunchTime)
+	})
+	current, stale := lo.FilterReject(members, func(m *instance.Instance, _ int) bool {
+		return nodeClass != nil && isCurrent(m, nodeClass, nodePool)
+	})
+	desired := lo.Ternary(nodeClass != nil, nodeClass.WarmPoolSize(), 0)
+	if len(current) > desired {
+		stale = append(stale, current[desired:]...)
+		current = current[:desired]
+	}
+
+	var errs []error
+	for _, m := range stale {
+		errs = append(errs, c.terminate(ctx, m, nodes[m.ID]))
+	}
+	for _, m := range current {
+		if m.State != ec2types.InstanceStateNameRunning {
+			continue
+		}
+		// Members are left running until they've registered so that they're fully initialized when claimed
+		if _, ok := nodes[m.ID]; !ok && c.clk.Since(m.LaunchTime) < initializationTimeout {
+			continue
+		}
+		errs = append(errs, c.stop(ctx, m, nodeClass, nodes[m.ID]))
+	}
+	var instanceTypes []*cloudprovider.InstanceType
+	if nodeClass != nil {
+		if instanceTypes, err = c.cloudProvider.GetInstanceTypes(ctx, nodePool); err != nil {
+			errs = append(errs, fmt.Errorf("getting instance types, %w", err))
+		}
+	}
+	if launches := desired - len(current); launches > 0 && len(instanceTypes) != 0 && nodeClass.StatusConditions().Root().IsTrue() && allowsOnDemand(nodePool) {
+		for range launches {
+			m, err := c.launch(ctx, nodeClass, nodePool, instanceTypes)
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			current = append(current, m)
+		}
+	}
+
+	WarmPoolInstances.DeletePartialMatch(map[string]string{nodePoolLabel: nodePoolName})
+	for state, count := range lo.CountValuesBy(current, func(m *instance.Instance) ec2types.InstanceStateName { return m.State }) {
+		WarmPoolInstances.Set(float64(count), map[string]string{
+			nodePoolLabel: nodePoolName,
+			stateLabel:    string(state),
+		})
+	}
+	WarmPoolHourlyCostEstimate.Set(hourlyCost(current, instanceTypes), map[string]string{nodePoolLabel: nodePoolName})
+	return multierr.Combine(errs...)
+}
+
+// resolveNodeClass returns the EC2NodeClass for the NodePool, or nil if the NodePool or EC2NodeClass is being deleted
+func (c *Controller) resolveNodeClass(ctx context.Context, nodePool *karpv1.NodePool) (*v1.EC2NodeClass, error) {
+	if nodePool == nil || !nodePool.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	nodeClass := &v1.EC2NodeClass{}
+	if err := c.kubeClient.Get(ctx, types.NamespacedName{Name: nodePool.Spec.Template.Spec.NodeClassRef.Name}, nodeClass); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if !nodeClass.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	return nodeClass, nil
+}
+
+// isCurrent returns true if the member was launched with the current EC2NodeClass and NodePool and one of the
+// EC2NodeClass's resolved AMIs. Members which aren't current could never be claimed and are replaced.
+func isCurrent(m *instance.Instance, nodeClass *v1.EC2NodeClass, nodePool *karpv1.NodePool) bool {
+	if m.Tags[v1.NodeClassTagKey] != nodeClass.Name ||
+		m.Tags[v1.AnnotationEC2NodeClassHash] != nodeClass.Hash() ||
+		m.Tags[karpv1.NodePoolHashAnnotationKey] != nodePool.Hash() {
+		return false
+	}
+	// We only consider AMIs once they've been resolved, otherwise we'd churn the warm pool on startup
+	return len(nodeClass.Status.AMIs) == 0 || lo.ContainsBy(nodeClass.Status.AMIs, func(ami v1.AMI) bool {
+		return ami.ID == m.ImageID
+	})
+}
+
+func (c *Controller) launch(ctx context.Context, nodeClass *v1.EC2NodeClass, nodePool *karpv1.NodePool, instanceTypes []*cloudprovider.InstanceType) (*instance.Instance, error) {
+	nodeClaim := nodePool.Spec.Template.ToNodeClaim()
+	nodeClaim.Labels = lo.Assign(nodeClaim.Labels, map[string]string{
+		karpv1.NodePoolLabelKey: nodePool.Name,
+		karpv1.NodeClassLabelKey(nodePool.Spec.Template.Spec.NodeClassRef.GroupKind()): nodeClass.Name,
+	})
+	nodeClaim.Annotations = lo.Assign(nodeClaim.Annotations, map[string]string{
+		karpv1.NodePoolHashAnnotationKey:        nodePool.Hash(),
+		karpv1.NodePoolHashVersionAnnotationKey: karpv1.NodePoolHashVersion,
+	})
+	// Warm pool members are always on-demand instances
+	nodeClaim.Spec.Requirements = append(nodeClaim.Spec.Requirements, karpv1.NodeSelectorRequirementWithMinValues{
+		NodeSelectorRequirement: corev1.NodeSelectorRequirement{
+			Key:      karpv1.CapacityTypeLabelKey,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{karpv1.CapacityTypeOnDemand},
+		},
+	})
+	tags, err := utils.GetTags(nodeClass, nodeClaim, options.FromContext(ctx).ClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("getting tags, %w", err)
+	}
+	tags = lo.Assign(tags, map[string]string{
+		v1.WarmPoolTagKey:                nodePool.Name,
+		v1.AnnotationEC2NodeClassHash:    nodeClass.Hash(),
+		karpv1.NodePoolHashAnnotationKey: nodePool.Hash(),
+	})
+	m, err := c.instanceProvider.Create(ctx, nodeClass, nodeClaim, tags, instanceTypes)
+	if err != nil {
+		return nil, fmt.Errorf("launching warm pool instance, %w", err)
+	}
+	log.FromContext(ctx).WithValues("instance", m.ID, "instance-type", m.Type, "zone", m.Zone).V(1).Info("launched warm pool instance")
+	m.WarmPool = nodePool.Name
+	return m, nil
+}
+
+// terminate terminates a warm pool member which is stale or exceeds the warm pool's size
+func (c *Controller) terminate(ctx context.Context, m *instance.Instance, node *corev1.Node) error {
+	if err := c.instanceProvider.Delete(ctx, m.ID); cloudprovider.IgnoreNodeClaimNotFoundError(err) != nil {
+		return fmt.Errorf("terminating warm pool instance, %w", err)
+	}
+	log.FromContext(ctx).WithValues("instance", m.ID).V(1).Info("terminated warm pool instance")
+	return c.deleteNode(ctx, node)
+}
+
+// stop stops or hibernates an initialized warm pool member so that it's ready to be claimed. A hibernated member resumes
+// with its kubelet still running, so its Node is kept and cordoned rather than deleted.
+func (c *Controller) stop(ctx context.Context, m *instance.Instance, nodeClass *v1.EC2NodeClass, node *corev1.Node) error {
+	hibernate := nodeClass.Spec.WarmPool.PoolState == v1.WarmPoolStateHibernated
+	if hibernate {
+		if err := c.cordonNode(ctx, node); err != nil {
+			return err
+		}
+	}
+	if err := c.instanceProvider.Stop(ctx, m.ID, hibernate); cloudprovider.IgnoreNodeClaimNotFoundError(err) != nil {
+		return fmt.Errorf("stopping warm pool instance, %w", err)
+	}
+	log.FromContext(ctx).WithValues("instance", m.ID, "hibernate", hibernate).V(1).Info("stopped warm pool instance")
+	m.State = ec2types.InstanceStateNameStopping
+	if hibernate {
+		return nil
+	}
+	// The Node is re-registered when the member is claimed and started
+	return c.deleteNode(ctx, node)
+}
+
+// cordonNode cordons and taints the Node of a member before it's hibernated so that nothing is scheduled to it until it's
+// claimed. The tagging controller removes the taint and uncordons the Node once it's registered for a NodeClaim.
+func (c *Controller) cordonNode(ctx context.Context, node *corev1.Node) error {
+	if node == nil {
+		return nil
+	}
+	stored := node.DeepCopy()
+	node.Spec.Unschedulable = true
+	node.Spec.Taints = scheduling.Taints(node.Spec.Taints).Merge([]corev1.Taint{v1.WarmPoolNoScheduleTaint})
+	if equality.Semantic.DeepEqual(node, stored) {
+		return nil
+	}
+	if err := c.kubeClient.Patch(ctx, node, client.MergeFrom(stored)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("cordoning node, %w", err)
+	}
+	return nil
+}
+
+func (c *Controller) deleteNode(ctx context.Context, node *corev1.Node) error {
+	if node == nil {
+		return nil
+	}
+	if err := c.kubeClient.Delete(ctx, node); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting node, %w", err)
+	}
+	return nil
+}
+
+// allowsOnDemand returns true if the NodePool's requirements allow on-demand capacity, since warm pool members are
+// always on-demand instances
+func allowsOnDemand(nodePool *karpv1.NodePool) bool {
+	return scheduling.NewNodeSelectorRequirementsWithMinValues(nodePool.Spec.Template.Spec.Requirements...).
+		Get(karpv1.CapacityTypeLabelKey).Has(karpv1.CapacityTypeOnDemand)
+}
+
+// hourlyCost estimates the hourly on-demand cost of the pending and running members
+func hourlyCost(members []*instance.Instance, instanceTypes []*cloudprovider.InstanceType) float64 {
+	return lo.SumBy(members, func(m *instance.Instance) float64 {
+		if m.State != ec2types.InstanceStateNamePending && m.State != ec2types.InstanceStateNameRunning {
+			return 0
+		}
+		it, ok := lo.Find(instanceTypes, func(it *cloudprovider.InstanceType) bool { return it.Name == string(m.Type) })
+		if !ok {
+			return 0
+		}
+		offering, ok := lo.Find(it.Offerings, func(o *cloudprovider.Offering) bool {
+			return o.CapacityType() == karpv1.CapacityTypeOnDemand && o.Zone() == m.Zone
+		})
+		if !ok {
+			return 0
+		}
+		return offering.Price
+	})
+}
+
+func (c *Controller) Register(_ context.Context, m manager.Manager) error {
+	return controllerruntime.NewControllerManagedBy(m).
+		Named("nodeclass.warmpool").
+		WatchesRawSource(singleton.Source()).
+		Complete(singleton.AsReconciler(c))
+}
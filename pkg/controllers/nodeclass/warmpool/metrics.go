@@ -0,0 +1,56 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warmpool
+
+import (
+	opmetrics "github.com/awslabs/operatorpkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/karpenter/pkg/metrics"
+)
+
+const (
+	warmPoolSubsystem = "warm_pool"
+	nodePoolLabel     = "nodepool"
+	stateLabel        = "state"
+)
+
+var (
+	WarmPoolInstances = opmetrics.NewPrometheusGauge(
+		crmetrics.Registry,
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: warmPoolSubsystem,
+			Name:      "instances",
+			Help:      "Number of instances in a NodePool's warm pool, based on nodepool and instance state.",
+		},
+		[]string{
+			nodePoolLabel,
+			stateLabel,
+		},
+	)
+	WarmPoolHourlyCostEstimate = opmetrics.NewPrometheusGauge(
+		crmetrics.Registry,
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: warmPoolSubsystem,
+			Name:      "hourly_cost_estimate",
+			Help:      "Estimated hourly on-demand cost of the running instances in a NodePool's warm pool, based on nodepool. Stopped and hibernated instances only incur the cost of their attached volumes, which is not included.",
+		},
+		[]string{
+			nodePoolLabel,
+		},
+	)
+)
@@ -0,0 +1,411 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warmpool_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/awslabs/operatorpkg/object"
+	"github.com/awslabs/operatorpkg/status"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clock "k8s.io/utils/clock/testing"
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	corecloudprovider "sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/events"
+	coreoptions "sigs.k8s.io/karpenter/pkg/operator/options"
+	coretest "sigs.k8s.io/karpenter/pkg/test"
+	"sigs.k8s.io/karpenter/pkg/test/v1alpha1"
+
+	"github.com/aws/karpenter-provider-aws/pkg/apis"
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/cloudprovider"
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclaim/tagging"
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/nodeclass/warmpool"
+	"github.com/aws/karpenter-provider-aws/pkg/fake"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+	. "sigs.k8s.io/karpenter/pkg/utils/testing"
+)
+
+var ctx context.Context
+var env *coretest.Environment
+var awsEnv *test.Environment
+var fakeClock *clock.FakeClock
+var cloudProvider *cloudprovider.CloudProvider
+var controller *warmpool.Controller
+
+func TestAPIs(t *testing.T) {
+	ctx = TestContextWithLogger(t)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "WarmPool")
+}
+
+var _ = BeforeSuite(func() {
+	env = coretest.NewEnvironment(coretest.WithCRDs(apis.CRDs...), coretest.WithCRDs(v1alpha1.CRDs...))
+	ctx = coreoptions.ToContext(ctx, coretest.Options(coretest.OptionsFields{FeatureGates: coretest.FeatureGates{ReservedCapacity: lo.ToPtr(true)}}))
+	ctx = options.ToContext(ctx, test.Options())
+	awsEnv = test.NewEnvironment(ctx, env)
+	fakeClock = clock.NewFakeClock(time.Now())
+	cloudProvider = cloudprovider.New(awsEnv.InstanceTypesProvider, awsEnv.InstanceProvider, events.NewRecorder(&record.FakeRecorder{}),
+		env.Client, awsEnv.AMIProvider, awsEnv.SecurityGroupProvider, awsEnv.CapacityReservationProvider)
+	controller = warmpool.NewController(fakeClock, env.Client, cloudProvider, awsEnv.InstanceProvider)
+})
+
+var _ = AfterSuite(func() {
+	Expect(env.Stop()).To(Succeed(), "Failed to stop environment")
+})
+
+var _ = BeforeEach(func() {
+	ctx = coreoptions.ToContext(ctx, coretest.Options(coretest.OptionsFields{FeatureGates: coretest.FeatureGates{ReservedCapacity: lo.ToPtr(true)}}))
+	ctx = options.ToContext(ctx, test.Options())
+	fakeClock.SetTime(time.Now())
+	awsEnv.Reset()
+})
+
+var _ = AfterEach(func() {
+	ExpectCleanedUp(ctx, env.Client)
+})
+
+var _ = Describe("WarmPool", func() {
+	var nodeClass *v1.EC2NodeClass
+	var nodePool *karpv1.NodePool
+	var storeMember func(state ec2types.InstanceStateName, tags map[string]string) string
+
+	BeforeEach(func() {
+		nodeClass = test.EC2NodeClass(v1.EC2NodeClass{
+			Spec: v1.EC2NodeClassSpec{
+				WarmPool: &v1.WarmPool{Size: 2, PoolState: v1.WarmPoolStateStopped},
+			},
+		})
+		nodeClass.StatusConditions().SetTrue(status.ConditionReady)
+		nodePool = coretest.NodePool(karpv1.NodePool{
+			Spec: karpv1.NodePoolSpec{
+				Template: karpv1.NodeClaimTemplate{
+					Spec: karpv1.NodeClaimTemplateSpec{
+						NodeClassRef: &karpv1.NodeClassReference{
+							Group: object.GVK(nodeClass).Group,
+							Kind:  object.GVK(nodeClass).Kind,
+							Name:  nodeClass.Name,
+						},
+					},
+				},
+			},
+		})
+		_, err := awsEnv.SubnetProvider.List(ctx, nodeClass) // Hydrate the subnet cache
+		Expect(err).To(BeNil())
+		Expect(awsEnv.InstanceTypesProvider.UpdateInstanceTypes(ctx)).To(Succeed())
+		Expect(awsEnv.InstanceTypesProvider.UpdateInstanceTypeOfferings(ctx)).To(Succeed())
+
+		storeMember = func(state ec2types.InstanceStateName, tags map[string]string) string {
+			instanceID := fake.InstanceID()
+			tags = lo.Assign(map[string]string{
+				fmt.Sprintf("kubernetes.io/cluster/%s", options.FromContext(ctx).ClusterName): "owned",
+				v1.EKSClusterNameTagKey:          options.FromContext(ctx).ClusterName,
+				karpv1.NodePoolLabelKey:          nodePool.Name,
+				v1.LabelNodeClass:                nodeClass.Name,
+				v1.WarmPoolTagKey:                nodePool.Name,
+				v1.AnnotationEC2NodeClassHash:    nodeClass.Hash(),
+				karpv1.NodePoolHashAnnotationKey: nodePool.Hash(),
+			}, tags)
+			awsEnv.EC2API.Instances.Store(instanceID, ec2types.Instance{
+				State:   &ec2types.InstanceState{Name: state},
+				ImageId: aws.String(nodeClass.Status.AMIs[0].ID),
+				Tags: lo.MapToSlice(tags, func(k, v string) ec2types.Tag {
+					return ec2types.Tag{Key: aws.String(k), Value: aws.String(v)}
+				}),
+				Placement:    &ec2types.Placement{AvailabilityZone: aws.String("test-zone-1a")},
+				LaunchTime:   aws.Time(fakeClock.Now()),
+				InstanceId:   lo.ToPtr(instanceID),
+				InstanceType: "m5.large",
+			})
+			return instanceID
+		}
+	})
+	Context("Launch", func() {
+		It("should launch instances until the warm pool is full", func() {
+			storeMember(ec2types.InstanceStateNameStopped, nil)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(1))
+
+			input := awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Pop()
+			Expect(input.TargetCapacitySpecification.DefaultTargetCapacityType).To(Equal(ec2types.DefaultTargetCapacityTypeOnDemand))
+			tagSpec, ok := lo.Find(input.TagSpecifications, func(t ec2types.TagSpecification) bool {
+				return t.ResourceType == ec2types.ResourceTypeInstance
+			})
+			Expect(ok).To(BeTrue())
+			tags := lo.SliceToMap(tagSpec.Tags, func(t ec2types.Tag) (string, string) { return aws.ToString(t.Key), aws.ToString(t.Value) })
+			Expect(tags).To(HaveKeyWithValue(v1.WarmPoolTagKey, nodePool.Name))
+			Expect(tags).To(HaveKeyWithValue(v1.AnnotationEC2NodeClassHash, nodeClass.Hash()))
+			Expect(tags).To(HaveKeyWithValue(karpv1.NodePoolHashAnnotationKey, nodePool.Hash()))
+		})
+		It("should not launch instances when the warm pool is full", func() {
+			storeMember(ec2types.InstanceStateNameStopped, nil)
+			storeMember(ec2types.InstanceStateNameStopped, nil)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(0))
+		})
+		It("should not launch instances when the EC2NodeClass isn't ready", func() {
+			nodeClass.StatusConditions().SetFalse(status.ConditionReady, "NotReady", "NotReady")
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(0))
+		})
+		It("should not launch instances when the NodePool doesn't allow on-demand capacity", func() {
+			nodePool.Spec.Template.Spec.Requirements = []karpv1.NodeSelectorRequirementWithMinValues{{
+				NodeSelectorRequirement: corev1.NodeSelectorRequirement{
+					Key:      karpv1.CapacityTypeLabelKey,
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{karpv1.CapacityTypeSpot},
+				},
+			}}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(0))
+		})
+		It("should not launch instances when the EC2NodeClass doesn't configure a warm pool", func() {
+			nodeClass.Spec.WarmPool = nil
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(0))
+		})
+		It("should only list warm pool instances when no EC2NodeClass configures a warm pool", func() {
+			nodeClass.Spec.WarmPool = nil
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.DescribeInstancesBehavior.Calls()).To(Equal(1))
+			Expect(awsEnv.EC2API.DescribeInstancesBehavior.CalledWithInput.Pop().Filters).To(ContainElement(ec2types.Filter{
+				Name:   aws.String("tag-key"),
+				Values: []string{v1.WarmPoolTagKey},
+			}))
+		})
+	})
+	Context("Stop", func() {
+		BeforeEach(func() {
+			nodeClass.Spec.WarmPool.Size = 1
+		})
+		It("should stop a running instance and delete its node once it has registered", func() {
+			instanceID := storeMember(ec2types.InstanceStateNameRunning, nil)
+			node := coretest.Node(coretest.NodeOptions{ProviderID: fake.ProviderID(instanceID)})
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass, node)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.StopInstancesBehavior.Calls()).To(Equal(1))
+			input := awsEnv.EC2API.StopInstancesBehavior.CalledWithInput.Pop()
+			Expect(input.InstanceIds).To(ConsistOf(instanceID))
+			Expect(aws.ToBool(input.Hibernate)).To(BeFalse())
+			ExpectNotFound(ctx, env.Client, node)
+		})
+		It("should hibernate a running instance and cordon its node when the pool state is hibernated", func() {
+			nodeClass.Spec.WarmPool.PoolState = v1.WarmPoolStateHibernated
+			nodeClass.Spec.HibernationOptions = &v1.HibernationOptions{Configured: lo.ToPtr(true)}
+			instanceID := storeMember(ec2types.InstanceStateNameRunning, nil)
+			node := coretest.Node(coretest.NodeOptions{ProviderID: fake.ProviderID(instanceID)})
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass, node)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.StopInstancesBehavior.Calls()).To(Equal(1))
+			Expect(aws.ToBool(awsEnv.EC2API.StopInstancesBehavior.CalledWithInput.Pop().Hibernate)).To(BeTrue())
+
+			// The kubelet resumes with the node when the instance is claimed, so it's kept rather than deleted
+			node = ExpectExists(ctx, env.Client, node)
+			Expect(node.Spec.Unschedulable).To(BeTrue())
+			Expect(node.Spec.Taints).To(ContainElement(v1.WarmPoolNoScheduleTaint))
+		})
+		It("should release the node of a hibernated instance once it's claimed", func() {
+			nodeClass.Spec.WarmPool.PoolState = v1.WarmPoolStateHibernated
+			nodeClass.Spec.HibernationOptions = &v1.HibernationOptions{Configured: lo.ToPtr(true)}
+			instanceID := storeMember(ec2types.InstanceStateNameRunning, nil)
+			node := coretest.Node(coretest.NodeOptions{ProviderID: fake.ProviderID(instanceID)})
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass, node)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.StopInstancesBehavior.Calls()).To(Equal(1))
+
+			nodeClaim := coretest.NodeClaim(karpv1.NodeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{karpv1.NodePoolLabelKey: nodePool.Name},
+					Annotations: map[string]string{karpv1.NodePoolHashAnnotationKey: nodePool.Hash()},
+				},
+				Spec: karpv1.NodeClaimSpec{
+					NodeClassRef: &karpv1.NodeClassReference{
+						Group: object.GVK(nodeClass).Group,
+						Kind:  object.GVK(nodeClass).Kind,
+						Name:  nodeClass.Name,
+					},
+				},
+			})
+			instanceTypes, err := cloudProvider.GetInstanceTypes(ctx, nodePool)
+			Expect(err).ToNot(HaveOccurred())
+			instanceTypes = lo.Filter(instanceTypes, func(it *corecloudprovider.InstanceType, _ int) bool { return it.Name == "m5.large" })
+			claimed, err := awsEnv.InstanceProvider.Create(ctx, nodeClass, nodeClaim, nil, instanceTypes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed.ID).To(Equal(instanceID))
+			Expect(awsEnv.EC2API.StartInstancesBehavior.Calls()).To(Equal(1))
+
+			// The node is registered for the NodeClaim when the kubelet resumes
+			nodeClaim.Status.ProviderID = fake.ProviderID(instanceID)
+			nodeClaim.Status.NodeName = node.Name
+			ExpectApplied(ctx, env.Client, nodeClaim)
+			ExpectObjectReconciled(ctx, env.Client, tagging.NewController(env.Client, cloudProvider, awsEnv.InstanceProvider), nodeClaim)
+			node = ExpectExists(ctx, env.Client, node)
+			Expect(node.Spec.Unschedulable).To(BeFalse())
+			Expect(node.Spec.Taints).ToNot(ContainElement(v1.WarmPoolNoScheduleTaint))
+
+			ExpectSingletonReconciled(ctx, controller)
+			ExpectExists(ctx, env.Client, node)
+			Expect(awsEnv.EC2API.StopInstancesBehavior.Calls()).To(Equal(1))
+			Expect(awsEnv.EC2API.TerminateInstancesBehavior.Calls()).To(Equal(0))
+		})
+		It("should not stop a running instance before its node has registered", func() {
+			storeMember(ec2types.InstanceStateNameRunning, nil)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.StopInstancesBehavior.Calls()).To(Equal(0))
+		})
+		It("should stop a running instance whose node never registers", func() {
+			storeMember(ec2types.InstanceStateNameRunning, nil)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			fakeClock.Step(15 * time.Minute)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.StopInstancesBehavior.Calls()).To(Equal(1))
+		})
+		It("should not stop an instance that has been claimed by a NodeClaim", func() {
+			instanceID := storeMember(ec2types.InstanceStateNameRunning, nil)
+			node := coretest.Node(coretest.NodeOptions{ProviderID: fake.ProviderID(instanceID)})
+			nodeClaim := coretest.NodeClaim(karpv1.NodeClaim{
+				Spec: karpv1.NodeClaimSpec{
+					NodeClassRef: &karpv1.NodeClassReference{
+						Group: object.GVK(nodeClass).Group,
+						Kind:  object.GVK(nodeClass).Kind,
+						Name:  nodeClass.Name,
+					},
+				},
+				Status: karpv1.NodeClaimStatus{ProviderID: fake.ProviderID(instanceID)},
+			})
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass, node, nodeClaim)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.StopInstancesBehavior.Calls()).To(Equal(0))
+			Expect(awsEnv.EC2API.TerminateInstancesBehavior.Calls()).To(Equal(0))
+			ExpectExists(ctx, env.Client, node)
+		})
+	})
+	Context("Replacement", func() {
+		BeforeEach(func() {
+			nodeClass.Spec.WarmPool.Size = 1
+		})
+		DescribeTable("should terminate and replace stale instances", func(tags map[string]string) {
+			instanceID := storeMember(ec2types.InstanceStateNameStopped, tags)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.TerminateInstancesBehavior.Calls()).To(Equal(1))
+			Expect(awsEnv.EC2API.TerminateInstancesBehavior.CalledWithInput.Pop().InstanceIds).To(ConsistOf(instanceID))
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(1))
+		},
+			Entry("stale nodeclass hash", map[string]string{v1.AnnotationEC2NodeClassHash: "stale"}),
+			Entry("stale nodepool hash", map[string]string{karpv1.NodePoolHashAnnotationKey: "stale"}),
+			Entry("different nodeclass", map[string]string{v1.LabelNodeClass: "other"}),
+		)
+		It("should terminate and replace instances whose AMI is no longer resolved", func() {
+			instanceID := storeMember(ec2types.InstanceStateNameStopped, nil)
+			raw, _ := awsEnv.EC2API.Instances.Load(instanceID)
+			member := raw.(ec2types.Instance)
+			member.ImageId = aws.String("ami-stale")
+			awsEnv.EC2API.Instances.Store(instanceID, member)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.TerminateInstancesBehavior.Calls()).To(Equal(1))
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(1))
+		})
+		It("should terminate instances which exceed the warm pool size, preferring running instances", func() {
+			stopped := storeMember(ec2types.InstanceStateNameStopped, nil)
+			running := storeMember(ec2types.InstanceStateNameRunning, nil)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.TerminateInstancesBehavior.Calls()).To(Equal(1))
+			Expect(awsEnv.EC2API.TerminateInstancesBehavior.CalledWithInput.Pop().InstanceIds).To(ConsistOf(running))
+			_, ok := awsEnv.EC2API.Instances.Load(stopped)
+			Expect(ok).To(BeTrue())
+		})
+		It("should terminate all instances when the warm pool is removed", func() {
+			storeMember(ec2types.InstanceStateNameStopped, nil)
+			nodeClass.Spec.WarmPool = nil
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.TerminateInstancesBehavior.Calls()).To(Equal(1))
+		})
+		It("should terminate instances belonging to a NodePool that no longer exists", func() {
+			storeMember(ec2types.InstanceStateNameStopped, map[string]string{
+				v1.WarmPoolTagKey:       "deleted",
+				karpv1.NodePoolLabelKey: "deleted",
+			})
+			ExpectApplied(ctx, env.Client, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+			Expect(awsEnv.EC2API.TerminateInstancesBehavior.Calls()).To(Equal(1))
+		})
+	})
+	Context("Metrics", func() {
+		It("should expose the number of instances by state", func() {
+			storeMember(ec2types.InstanceStateNameStopped, nil)
+			storeMember(ec2types.InstanceStateNameRunning, nil)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+
+			metric, ok := FindMetricWithLabelValues("karpenter_warm_pool_instances", map[string]string{
+				"nodepool": nodePool.Name,
+				"state":    string(ec2types.InstanceStateNameStopped),
+			})
+			Expect(ok).To(BeTrue())
+			Expect(metric.GetGauge().GetValue()).To(BeNumerically("==", 1))
+			metric, ok = FindMetricWithLabelValues("karpenter_warm_pool_instances", map[string]string{
+				"nodepool": nodePool.Name,
+				"state":    string(ec2types.InstanceStateNameRunning),
+			})
+			Expect(ok).To(BeTrue())
+			Expect(metric.GetGauge().GetValue()).To(BeNumerically("==", 1))
+		})
+		It("should expose the hourly cost of running instances", func() {
+			storeMember(ec2types.InstanceStateNameStopped, nil)
+			storeMember(ec2types.InstanceStateNameRunning, nil)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			ExpectSingletonReconciled(ctx, controller)
+
+			instanceTypes, err := cloudProvider.GetInstanceTypes(ctx, nodePool)
+			Expect(err).ToNot(HaveOccurred())
+			it, ok := lo.Find(instanceTypes, func(it *corecloudprovider.InstanceType) bool { return it.Name == "m5.large" })
+			Expect(ok).To(BeTrue())
+			offering, ok := lo.Find(it.Offerings, func(o *corecloudprovider.Offering) bool {
+				return o.CapacityType() == karpv1.CapacityTypeOnDemand && o.Zone() == "test-zone-1a"
+			})
+			Expect(ok).To(BeTrue())
+
+			metric, ok := FindMetricWithLabelValues("karpenter_warm_pool_hourly_cost_estimate", map[string]string{
+				"nodepool": nodePool.Name,
+			})
+			Expect(ok).To(BeTrue())
+			Expect(metric.GetGauge().GetValue()).To(BeNumerically("~", offering.Price))
+		})
+	})
+})
@@ -0,0 +1,119 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/awslabs/operatorpkg/singleton"
+	"github.com/samber/lo"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/karpenter/pkg/operator/injection"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/instance"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/instanceprofile"
+)
+
+// Controller garbage collects instance profiles that Karpenter created for EC2NodeClasses which no longer exist. Instance
+// profiles are normally deleted when the EC2NodeClass is finalized, but can be leaked if the finalizer is removed
+// without deleting them.
+type Controller struct {
+	clk                     clock.Clock
+	kubeClient              client.Client
+	instanceProfileProvider instanceprofile.Provider
+	instanceProvider        instance.Provider
+	region                  string
+}
+
+func NewController(clk clock.Clock, kubeClient client.Client, instanceProfileProvider instanceprofile.Provider, instanceProvider instance.Provider, region string) *Controller {
+	return &Controller{
+		clk:                     clk,
+		kubeClient:              kubeClient,
+		instanceProfileProvider: instanceProfileProvider,
+		instanceProvider:        instanceProvider,
+		region:                  region,
+	}
+}
+
+func (c *Controller) Reconcile(ctx context.Context) (reconcile.Result, error) {
+	ctx = injection.WithControllerName(ctx, "instanceprofile.garbagecollection")
+
+	// We LIST instance profiles BEFORE we LIST EC2NodeClasses so that an instance profile created for a new EC2NodeClass
+	// is never considered without the EC2NodeClass
+	instanceProfiles, err := c.instanceProfileProvider.ListClusterProfiles(ctx)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing instance profiles, %w", err)
+	}
+	nodeClassList := &v1.EC2NodeClassList{}
+	if err = c.kubeClient.List(ctx, nodeClassList); err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing nodeclasses, %w", err)
+	}
+	managedProfiles := sets.New(lo.FilterMap(nodeClassList.Items, func(nc v1.EC2NodeClass, _ int) (string, bool) {
+		return nc.InstanceProfileName(options.FromContext(ctx).ClusterName, c.region), nc.Spec.Role != ""
+	})...)
+	orphans := lo.Filter(instanceProfiles, func(instanceProfile *iamtypes.InstanceProfile, _ int) bool {
+		tags := lo.SliceToMap(instanceProfile.Tags, func(t iamtypes.Tag) (string, string) { return lo.FromPtr(t.Key), lo.FromPtr(t.Value) })
+		// Instance profiles are global, so we only consider the instance profiles created for this region
+		return tags[corev1.LabelTopologyRegion] == c.region &&
+			!managedProfiles.Has(lo.FromPtr(instanceProfile.InstanceProfileName)) &&
+			c.clk.Since(lo.FromPtr(instanceProfile.CreateDate)) > time.Minute
+	})
+	if len(orphans) == 0 {
+		return reconcile.Result{RequeueAfter: 30 * time.Minute}, nil
+	}
+	// Instances keep using their instance profile after the EC2NodeClass is deleted, e.g. if the NodeClaim's finalizer
+	// was removed before the instance was terminated, so we don't delete instance profiles that are still in use
+	instances, err := c.instanceProvider.List(ctx)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("listing instances, %w", err)
+	}
+	inUse := sets.New(lo.Map(instances, func(i *instance.Instance, _ int) string { return i.InstanceProfile })...)
+	var errs []error
+	for _, instanceProfile := range orphans {
+		name := lo.FromPtr(instanceProfile.InstanceProfileName)
+		if inUse.Has(name) {
+			continue
+		}
+		if err := c.instanceProfileProvider.Delete(ctx, name); err != nil {
+			errs = append(errs, fmt.Errorf("deleting instance profile, %w", err))
+			continue
+		}
+		log.FromContext(ctx).WithValues("instance-profile", name).V(1).Info("garbage collected instance profile")
+	}
+	if err = multierr.Combine(errs...); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: 30 * time.Minute}, nil
+}
+
+func (c *Controller) Register(_ context.Context, m manager.Manager) error {
+	return controllerruntime.NewControllerManagedBy(m).
+		Named("instanceprofile.garbagecollection").
+		WatchesRawSource(singleton.Source()).
+		Complete(singleton.AsReconciler(c))
+}
@@ -0,0 +1,167 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollection_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/test/v1alpha1"
+
+	coreoptions "sigs.k8s.io/karpenter/pkg/operator/options"
+	coretest "sigs.k8s.io/karpenter/pkg/test"
+
+	"github.com/aws/karpenter-provider-aws/pkg/apis"
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/controllers/providers/instanceprofile/garbagecollection"
+	"github.com/aws/karpenter-provider-aws/pkg/fake"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/test/expectations"
+	. "sigs.k8s.io/karpenter/pkg/utils/testing"
+)
+
+var ctx context.Context
+var stop context.CancelFunc
+var env *coretest.Environment
+var awsEnv *test.Environment
+var controller *garbagecollection.Controller
+
+func TestAWS(t *testing.T) {
+	ctx = TestContextWithLogger(t)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "InstanceProfileGarbageCollection")
+}
+
+var _ = BeforeSuite(func() {
+	env = coretest.NewEnvironment(coretest.WithCRDs(apis.CRDs...), coretest.WithCRDs(v1alpha1.CRDs...))
+	ctx = coreoptions.ToContext(ctx, coretest.Options(coretest.OptionsFields{FeatureGates: coretest.FeatureGates{ReservedCapacity: lo.ToPtr(true)}}))
+	ctx = options.ToContext(ctx, test.Options())
+	ctx, stop = context.WithCancel(ctx)
+	awsEnv = test.NewEnvironment(ctx, env)
+	controller = garbagecollection.NewController(awsEnv.Clock, env.Client, awsEnv.InstanceProfileProvider, awsEnv.InstanceProvider, fake.DefaultRegion)
+})
+
+var _ = AfterSuite(func() {
+	stop()
+	Expect(env.Stop()).To(Succeed(), "Failed to stop environment")
+})
+
+var _ = BeforeEach(func() {
+	ctx = coreoptions.ToContext(ctx, coretest.Options(coretest.OptionsFields{FeatureGates: coretest.FeatureGates{ReservedCapacity: lo.ToPtr(true)}}))
+	ctx = options.ToContext(ctx, test.Options())
+
+	awsEnv.Reset()
+	awsEnv.Clock.SetTime(time.Now())
+})
+
+var _ = AfterEach(func() {
+	ExpectCleanedUp(ctx, env.Client)
+})
+
+var _ = Describe("InstanceProfile GarbageCollection", func() {
+	var nodeClass *v1.EC2NodeClass
+	var profileName string
+
+	instanceProfile := func(name, nodeClassName, region string) *iamtypes.InstanceProfile {
+		return &iamtypes.InstanceProfile{
+			Arn:                 aws.String(fmt.Sprintf("arn:aws:iam::123456789012:instance-profile/%s", name)),
+			CreateDate:          aws.Time(awsEnv.Clock.Now().Add(-time.Hour)),
+			InstanceProfileId:   aws.String(fake.InstanceProfileID()),
+			InstanceProfileName: aws.String(name),
+			Roles:               []iamtypes.Role{{RoleName: aws.String("test-role")}},
+			Tags: []iamtypes.Tag{
+				{Key: aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", options.FromContext(ctx).ClusterName)), Value: aws.String("owned")},
+				{Key: aws.String(v1.EKSClusterNameTagKey), Value: aws.String(options.FromContext(ctx).ClusterName)},
+				{Key: aws.String(v1.LabelNodeClass), Value: aws.String(nodeClassName)},
+				{Key: aws.String(corev1.LabelTopologyRegion), Value: aws.String(region)},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		nodeClass = test.EC2NodeClass()
+		profileName = nodeClass.InstanceProfileName(options.FromContext(ctx).ClusterName, fake.DefaultRegion)
+	})
+	It("should delete instance profiles for nodeclasses that no longer exist", func() {
+		awsEnv.IAMAPI.InstanceProfiles[profileName] = instanceProfile(profileName, nodeClass.Name, fake.DefaultRegion)
+		ExpectSingletonReconciled(ctx, controller)
+		Expect(awsEnv.IAMAPI.InstanceProfiles).ToNot(HaveKey(profileName))
+		Expect(awsEnv.IAMAPI.RemoveRoleFromInstanceProfileBehavior.Calls()).To(Equal(1))
+	})
+	It("should not delete instance profiles for nodeclasses that exist", func() {
+		ExpectApplied(ctx, env.Client, nodeClass)
+		awsEnv.IAMAPI.InstanceProfiles[profileName] = instanceProfile(profileName, nodeClass.Name, fake.DefaultRegion)
+		ExpectSingletonReconciled(ctx, controller)
+		Expect(awsEnv.IAMAPI.InstanceProfiles).To(HaveKey(profileName))
+	})
+	It("should delete instance profiles for nodeclasses that use an unmanaged instance profile", func() {
+		nodeClass.Spec.Role = ""
+		nodeClass.Spec.InstanceProfile = lo.ToPtr("test-instance-profile")
+		ExpectApplied(ctx, env.Client, nodeClass)
+		awsEnv.IAMAPI.InstanceProfiles[profileName] = instanceProfile(profileName, nodeClass.Name, fake.DefaultRegion)
+		ExpectSingletonReconciled(ctx, controller)
+		Expect(awsEnv.IAMAPI.InstanceProfiles).ToNot(HaveKey(profileName))
+	})
+	It("should not delete instance profiles that are still used by an instance", func() {
+		awsEnv.IAMAPI.InstanceProfiles[profileName] = instanceProfile(profileName, nodeClass.Name, fake.DefaultRegion)
+		awsEnv.EC2API.DescribeInstancesBehavior.Output.Set(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{{
+				InstanceId:         aws.String(fake.InstanceID()),
+				State:              &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning},
+				Placement:          &ec2types.Placement{AvailabilityZone: aws.String("test-zone-1a")},
+				IamInstanceProfile: &ec2types.IamInstanceProfile{Arn: awsEnv.IAMAPI.InstanceProfiles[profileName].Arn},
+				Tags: []ec2types.Tag{
+					{Key: aws.String(karpv1.NodePoolLabelKey), Value: aws.String("default")},
+					{Key: aws.String(v1.LabelNodeClass), Value: aws.String(nodeClass.Name)},
+					{Key: aws.String(v1.EKSClusterNameTagKey), Value: aws.String(options.FromContext(ctx).ClusterName)},
+				},
+			}}}},
+		})
+		ExpectSingletonReconciled(ctx, controller)
+		Expect(awsEnv.IAMAPI.InstanceProfiles).To(HaveKey(profileName))
+	})
+	It("should not delete instance profiles created for another region", func() {
+		awsEnv.IAMAPI.InstanceProfiles[profileName] = instanceProfile(profileName, nodeClass.Name, "us-east-1")
+		ExpectSingletonReconciled(ctx, controller)
+		Expect(awsEnv.IAMAPI.InstanceProfiles).To(HaveKey(profileName))
+	})
+	It("should not delete instance profiles that aren't owned by the cluster", func() {
+		awsEnv.IAMAPI.InstanceProfiles[profileName] = instanceProfile(profileName, nodeClass.Name, fake.DefaultRegion)
+		awsEnv.IAMAPI.InstanceProfiles[profileName].Tags = lo.Reject(awsEnv.IAMAPI.InstanceProfiles[profileName].Tags, func(t iamtypes.Tag, _ int) bool {
+			return lo.FromPtr(t.Key) == v1.LabelNodeClass
+		})
+		ExpectSingletonReconciled(ctx, controller)
+		Expect(awsEnv.IAMAPI.InstanceProfiles).To(HaveKey(profileName))
+	})
+	It("should not delete instance profiles that were recently created", func() {
+		awsEnv.IAMAPI.InstanceProfiles[profileName] = instanceProfile(profileName, nodeClass.Name, fake.DefaultRegion)
+		awsEnv.IAMAPI.InstanceProfiles[profileName].CreateDate = aws.Time(awsEnv.Clock.Now())
+		ExpectSingletonReconciled(ctx, controller)
+		Expect(awsEnv.IAMAPI.InstanceProfiles).To(HaveKey(profileName))
+	})
+})
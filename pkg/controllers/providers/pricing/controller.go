@@ -20,22 +20,31 @@ import (
 	"time"
 
 	"github.com/awslabs/operatorpkg/singleton"
+	"github.com/samber/lo"
 	lop "github.com/samber/lo/parallel"
 	"go.uber.org/multierr"
 	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/karpenter/pkg/operator/injection"
 
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/pricing"
 )
 
 type Controller struct {
+	kubeClient      client.Client
 	pricingProvider pricing.Provider
 }
 
-func NewController(pricingProvider pricing.Provider) *Controller {
+func NewController(kubeClient client.Client, pricingProvider pricing.Provider) *Controller {
 	return &Controller{
+		kubeClient:      kubeClient,
 		pricingProvider: pricingProvider,
 	}
 }
@@ -53,15 +62,47 @@ func (c *Controller) Reconcile(ctx context.Context) (reconcile.Result, error) {
 			errs[i] = err
 		}
 	})
+	errs = append(errs, c.updateDedicatedPricing(ctx))
 	if err := multierr.Combine(errs...); err != nil {
 		return reconcile.Result{}, fmt.Errorf("updating pricing, %w", err)
 	}
 	return reconcile.Result{RequeueAfter: 12 * time.Hour}, nil
 }
 
+// updateDedicatedPricing updates the dedicated tenancy prices when an EC2NodeClass launches dedicated instances. The
+// previously retrieved prices, or the shared tenancy prices, are used until they're retrieved.
+func (c *Controller) updateDedicatedPricing(ctx context.Context) error {
+	nodeClassList := &v1.EC2NodeClassList{}
+	if err := c.kubeClient.List(ctx, nodeClassList); err != nil {
+		return fmt.Errorf("listing ec2nodeclasses, %w", err)
+	}
+	if !lo.ContainsBy(nodeClassList.Items, func(nc v1.EC2NodeClass) bool { return nc.Tenancy() == v1.TenancyDedicated }) {
+		return nil
+	}
+	return c.pricingProvider.UpdateDedicatedOnDemandPricing(ctx)
+}
+
 func (c *Controller) Register(_ context.Context, m manager.Manager) error {
 	return controllerruntime.NewControllerManagedBy(m).
 		Named("providers.pricing").
 		WatchesRawSource(singleton.Source()).
+		// Dedicated tenancy prices are only retrieved once an EC2NodeClass launches dedicated instances
+		Watches(
+			&v1.EC2NodeClass{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+				return []reconcile.Request{{}}
+			}),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(e event.CreateEvent) bool { return isDedicated(e.Object) },
+				UpdateFunc:  func(e event.UpdateEvent) bool { return !isDedicated(e.ObjectOld) && isDedicated(e.ObjectNew) },
+				DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+				GenericFunc: func(e event.GenericEvent) bool { return false },
+			}),
+		).
 		Complete(singleton.AsReconciler(c))
 }
+
+func isDedicated(o client.Object) bool {
+	nodeClass, ok := o.(*v1.EC2NodeClass)
+	return ok && nodeClass.Tenancy() == v1.TenancyDedicated
+}
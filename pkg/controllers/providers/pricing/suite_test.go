@@ -26,11 +26,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	awspricing "github.com/aws/aws-sdk-go-v2/service/pricing"
+	pricingtypes "github.com/aws/aws-sdk-go-v2/service/pricing/types"
 	"github.com/samber/lo"
 	coreoptions "sigs.k8s.io/karpenter/pkg/operator/options"
 	coretest "sigs.k8s.io/karpenter/pkg/test"
 
 	"github.com/aws/karpenter-provider-aws/pkg/apis"
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
 	controllerspricing "github.com/aws/karpenter-provider-aws/pkg/controllers/providers/pricing"
 	"github.com/aws/karpenter-provider-aws/pkg/fake"
 	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
@@ -61,7 +63,7 @@ var _ = BeforeSuite(func() {
 	ctx = options.ToContext(ctx, test.Options())
 	ctx, stop = context.WithCancel(ctx)
 	awsEnv = test.NewEnvironment(ctx, env)
-	controller = controllerspricing.NewController(awsEnv.PricingProvider)
+	controller = controllerspricing.NewController(env.Client, awsEnv.PricingProvider)
 })
 
 var _ = AfterSuite(func() {
@@ -138,6 +140,75 @@ var _ = Describe("Pricing", func() {
 			Expect(ok).To(BeTrue())
 			Expect(price).To(BeNumerically("==", 1.23))
 		})
+		It("should update dedicated on-demand pricing with response from the pricing API", func() {
+			ExpectApplied(ctx, env.Client, test.EC2NodeClass(v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Tenancy: lo.ToPtr(v1.TenancyDedicated)}}))
+			awsEnv.PricingAPI.GetProductsBehavior.Output.Set(&awspricing.GetProductsOutput{
+				PriceList: []string{
+					fake.NewOnDemandPrice("c98.large", 1.20),
+				},
+			})
+			ExpectSingletonReconciled(ctx, controller)
+
+			price, ok := awsEnv.PricingProvider.DedicatedOnDemandPrice("c98.large")
+			Expect(ok).To(BeTrue())
+			Expect(price).To(BeNumerically("==", 1.20))
+			Expect(awsEnv.PricingAPI.GetProductsBehavior.CalledWithInput.Len()).To(Equal(3))
+			var tenancies []string
+			awsEnv.PricingAPI.GetProductsBehavior.CalledWithInput.ForEach(func(input *awspricing.GetProductsInput) {
+				filters := lo.SliceToMap(input.Filters, func(f pricingtypes.Filter) (string, string) {
+					return lo.FromPtr(f.Field), lo.FromPtr(f.Value)
+				})
+				tenancies = append(tenancies, fmt.Sprintf("%s/%s", filters["tenancy"], filters["productFamily"]))
+			})
+			Expect(tenancies).To(ConsistOf(
+				"Shared/Compute Instance",
+				"Dedicated/Compute Instance",
+				"Dedicated/Compute Instance (bare metal)",
+			))
+		})
+		DescribeTable("should not retrieve dedicated on-demand pricing unless an EC2NodeClass launches dedicated instances", func(tenancy *v1.Tenancy) {
+			ExpectApplied(ctx, env.Client, test.EC2NodeClass(v1.EC2NodeClass{Spec: v1.EC2NodeClassSpec{Tenancy: tenancy}}))
+			awsEnv.PricingAPI.GetProductsBehavior.Output.Set(&awspricing.GetProductsOutput{
+				PriceList: []string{
+					fake.NewOnDemandPrice("c98.large", 1.20),
+				},
+			})
+			ExpectSingletonReconciled(ctx, controller)
+			var tenancies []string
+			awsEnv.PricingAPI.GetProductsBehavior.CalledWithInput.ForEach(func(input *awspricing.GetProductsInput) {
+				filters := lo.SliceToMap(input.Filters, func(f pricingtypes.Filter) (string, string) {
+					return lo.FromPtr(f.Field), lo.FromPtr(f.Value)
+				})
+				tenancies = append(tenancies, fmt.Sprintf("%s/%s", filters["tenancy"], filters["productFamily"]))
+			})
+			Expect(tenancies).To(ConsistOf(
+				"Shared/Compute Instance",
+				"Dedicated/Compute Instance (bare metal)",
+			))
+		},
+			Entry("default tenancy", nil),
+			Entry("host tenancy", lo.ToPtr(v1.TenancyHost)),
+		)
+		It("should keep the previous dedicated prices when updating them fails", func() {
+			awsEnv.PricingAPI.GetProductsBehavior.Output.Set(&awspricing.GetProductsOutput{
+				PriceList: []string{
+					fake.NewOnDemandPrice("c98.large", 1.20),
+				},
+			})
+			Expect(awsEnv.PricingProvider.UpdateDedicatedOnDemandPricing(ctx)).To(Succeed())
+			awsEnv.PricingAPI.GetProductsBehavior.Error.Set(fmt.Errorf("failed"))
+			Expect(awsEnv.PricingProvider.UpdateDedicatedOnDemandPricing(ctx)).ToNot(Succeed())
+			price, ok := awsEnv.PricingProvider.DedicatedOnDemandPrice("c98.large")
+			Expect(ok).To(BeTrue())
+			Expect(price).To(BeNumerically("==", 1.20))
+		})
+		It("should fall back to the shared tenancy price when the dedicated price is unknown", func() {
+			awsEnv.PricingAPI.GetProductsBehavior.Error.Set(fmt.Errorf("failed"))
+			_ = ExpectSingletonReconcileFailed(ctx, controller)
+			price, ok := awsEnv.PricingProvider.DedicatedOnDemandPrice("c5.large")
+			Expect(ok).To(BeTrue())
+			Expect(price).To(Equal(lo.Must(awsEnv.PricingProvider.OnDemandPrice("c5.large"))))
+		})
 		It("should query for both `Linux/UNIX` and `Linux/UNIX (Amazon VPC)`", func() {
 			// If an account supports EC2 classic, then the non-classic instance types have a product
 			// description of Linux/UNIX (Amazon VPC)
@@ -167,7 +238,7 @@ var _ = Describe("Pricing", func() {
 		})
 		It("should update on-demand pricing with response from the pricing API when in the CN partition", func() {
 			tmpPricingProvider := pricing.NewDefaultProvider(awsEnv.PricingAPI, awsEnv.EC2API, "cn-anywhere-1", false)
-			tmpController := controllerspricing.NewController(tmpPricingProvider)
+			tmpController := controllerspricing.NewController(env.Client, tmpPricingProvider)
 
 			now := time.Now()
 			awsEnv.EC2API.DescribeSpotPriceHistoryBehavior.Output.Set(&ec2.DescribeSpotPriceHistoryOutput{
@@ -35,10 +35,13 @@ const (
 var (
 	// This is not an exhaustive list, add to it as needed
 	notFoundErrorCodes = sets.New[string](
+		"InvalidAMIID.NotFound",
 		"InvalidCapacityReservationId.NotFound",
 		"InvalidInstanceID.NotFound",
 		launchTemplateNameNotFoundCode,
 		"InvalidLaunchTemplateId.NotFound",
+		"InvalidPlacementGroup.Unknown",
+		"InvalidSnapshot.NotFound",
 		"QueueDoesNotExist",
 		"NoSuchEntity",
 		"ParameterNotFound",
@@ -48,6 +51,7 @@ var (
 	)
 
 	reservationCapacityExceededErrorCode = "ReservationCapacityExceeded"
+	insufficientFreeAddressesErrorCode   = "InsufficientFreeAddressesInSubnet"
 
 	// unfulfillableCapacityErrorCodes signify that capacity is temporarily unable to be launched
 	unfulfillableCapacityErrorCodes = sets.New[string](
@@ -56,7 +60,7 @@ var (
 		"VcpuLimitExceeded",
 		"UnfulfillableCapacity",
 		"Unsupported",
-		"InsufficientFreeAddressesInSubnet",
+		insufficientFreeAddressesErrorCode,
 		reservationCapacityExceededErrorCode,
 	)
 )
@@ -165,6 +169,12 @@ func IsReservationCapacityExceeded(err ec2types.CreateFleetError) bool {
 	return *err.ErrorCode == reservationCapacityExceededErrorCode
 }
 
+// IsInsufficientFreeAddresses returns true if the fleet error means the subnet in the override doesn't have enough free
+// IP addresses to launch the instance.
+func IsInsufficientFreeAddresses(err ec2types.CreateFleetError) bool {
+	return *err.ErrorCode == insufficientFreeAddressesErrorCode
+}
+
 func IsLaunchTemplateNotFound(err error) bool {
 	if err == nil {
 		return false
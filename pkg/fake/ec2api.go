@@ -51,18 +51,28 @@ type EC2Behavior struct {
 	DescribeCapacityReservationsOutput  AtomicPtr[ec2.DescribeCapacityReservationsOutput]
 	DescribeImagesOutput                AtomicPtr[ec2.DescribeImagesOutput]
 	DescribeLaunchTemplatesOutput       AtomicPtr[ec2.DescribeLaunchTemplatesOutput]
+	DescribePlacementGroupsOutput       AtomicPtr[ec2.DescribePlacementGroupsOutput]
 	DescribeInstanceTypesOutput         AtomicPtr[ec2.DescribeInstanceTypesOutput]
 	DescribeInstanceTypeOfferingsOutput AtomicPtr[ec2.DescribeInstanceTypeOfferingsOutput]
 	DescribeAvailabilityZonesOutput     AtomicPtr[ec2.DescribeAvailabilityZonesOutput]
 	DescribeSubnetsBehavior             MockedFunction[ec2.DescribeSubnetsInput, ec2.DescribeSubnetsOutput]
 	DescribeSecurityGroupsBehavior      MockedFunction[ec2.DescribeSecurityGroupsInput, ec2.DescribeSecurityGroupsOutput]
+	DescribeSecurityGroupRulesBehavior  MockedFunction[ec2.DescribeSecurityGroupRulesInput, ec2.DescribeSecurityGroupRulesOutput]
+	DescribeVpcsBehavior                MockedFunction[ec2.DescribeVpcsInput, ec2.DescribeVpcsOutput]
+	GetManagedPrefixListEntriesBehavior MockedFunction[ec2.GetManagedPrefixListEntriesInput, ec2.GetManagedPrefixListEntriesOutput]
 	DescribeSpotPriceHistoryBehavior    MockedFunction[ec2.DescribeSpotPriceHistoryInput, ec2.DescribeSpotPriceHistoryOutput]
 	CreateFleetBehavior                 MockedFunction[ec2.CreateFleetInput, ec2.CreateFleetOutput]
 	TerminateInstancesBehavior          MockedFunction[ec2.TerminateInstancesInput, ec2.TerminateInstancesOutput]
 	DescribeInstancesBehavior           MockedFunction[ec2.DescribeInstancesInput, ec2.DescribeInstancesOutput]
 	CreateTagsBehavior                  MockedFunction[ec2.CreateTagsInput, ec2.CreateTagsOutput]
+	DeleteTagsBehavior                  MockedFunction[ec2.DeleteTagsInput, ec2.DeleteTagsOutput]
+	StartInstancesBehavior              MockedFunction[ec2.StartInstancesInput, ec2.StartInstancesOutput]
+	StopInstancesBehavior               MockedFunction[ec2.StopInstancesInput, ec2.StopInstancesOutput]
 	RunInstancesBehavior                MockedFunction[ec2.RunInstancesInput, ec2.RunInstancesOutput]
 	CreateLaunchTemplateBehavior        MockedFunction[ec2.CreateLaunchTemplateInput, ec2.CreateLaunchTemplateOutput]
+	CopyImageBehavior                   MockedFunction[ec2.CopyImageInput, ec2.CopyImageOutput]
+	DeregisterImageBehavior             MockedFunction[ec2.DeregisterImageInput, ec2.DeregisterImageOutput]
+	DeleteSnapshotBehavior              MockedFunction[ec2.DeleteSnapshotInput, ec2.DeleteSnapshotOutput]
 	CalledWithDescribeImagesInput       AtomicPtrSlice[ec2.DescribeImagesInput]
 	Instances                           sync.Map
 	InsufficientCapacityPools           atomic.Slice[CapacityPool]
@@ -90,15 +100,25 @@ var DefaultSupportedUsageClasses = []ec2types.UsageClassType{ec2types.UsageClass
 func (e *EC2API) Reset() {
 	e.DescribeImagesOutput.Reset()
 	e.DescribeLaunchTemplatesOutput.Reset()
+	e.DescribePlacementGroupsOutput.Reset()
 	e.DescribeInstanceTypesOutput.Reset()
 	e.DescribeInstanceTypeOfferingsOutput.Reset()
 	e.DescribeAvailabilityZonesOutput.Reset()
 	e.DescribeSubnetsBehavior.Reset()
 	e.DescribeSecurityGroupsBehavior.Reset()
+	e.DescribeSecurityGroupRulesBehavior.Reset()
+	e.DescribeVpcsBehavior.Reset()
+	e.GetManagedPrefixListEntriesBehavior.Reset()
 	e.CreateFleetBehavior.Reset()
 	e.TerminateInstancesBehavior.Reset()
 	e.DescribeInstancesBehavior.Reset()
+	e.DeleteTagsBehavior.Reset()
+	e.StartInstancesBehavior.Reset()
+	e.StopInstancesBehavior.Reset()
 	e.CreateLaunchTemplateBehavior.Reset()
+	e.CopyImageBehavior.Reset()
+	e.DeregisterImageBehavior.Reset()
+	e.DeleteSnapshotBehavior.Reset()
 	e.CalledWithDescribeImagesInput.Reset()
 	e.DescribeSpotPriceHistoryBehavior.Reset()
 	e.Subnets.Range(func(k, v any) bool {
@@ -179,9 +199,11 @@ func (e *EC2API) CreateFleet(_ context.Context, input *ec2.CreateFleetInput, _ .
 					}
 				}
 				amiID := lo.ToPtr("")
+				var placement *ec2types.LaunchTemplatePlacementRequest
 				if e.CreateLaunchTemplateBehavior.CalledWithInput.Len() > 0 {
 					lt := e.CreateLaunchTemplateBehavior.CalledWithInput.Pop()
 					amiID = lt.LaunchTemplateData.ImageId
+					placement = lt.LaunchTemplateData.Placement
 					e.CreateLaunchTemplateBehavior.CalledWithInput.Add(lt)
 				}
 				instanceState := ec2types.InstanceStateNameRunning
@@ -189,7 +211,7 @@ func (e *EC2API) CreateFleet(_ context.Context, input *ec2.CreateFleetInput, _ .
 					instance := ec2types.Instance{
 						ImageId:               aws.String(*amiID),
 						InstanceId:            aws.String(test.RandomName()),
-						Placement:             &ec2types.Placement{AvailabilityZone: input.LaunchTemplateConfigs[0].Overrides[0].AvailabilityZone},
+						Placement:             e.placement(input.LaunchTemplateConfigs[0].Overrides[0].AvailabilityZone, placement, fulfilled),
 						PrivateDnsName:        aws.String(randomdata.IpV4Address()),
 						InstanceType:          input.LaunchTemplateConfigs[0].Overrides[0].InstanceType,
 						SpotInstanceRequestId: spotInstanceRequestID,
@@ -246,6 +268,35 @@ func (e *EC2API) CreateFleet(_ context.Context, input *ec2.CreateFleetInput, _ .
 	})
 }
 
+// placement returns the placement of the nth instance launched by a fleet request. Instances launched into a
+// partition placement group are distributed across its partitions.
+func (e *EC2API) placement(zone *string, request *ec2types.LaunchTemplatePlacementRequest, n int) *ec2types.Placement {
+	placement := &ec2types.Placement{AvailabilityZone: zone, Tenancy: ec2types.TenancyDefault}
+	if request == nil {
+		return placement
+	}
+	if request.Tenancy != "" {
+		placement.Tenancy = request.Tenancy
+		placement.HostId = request.HostId
+	}
+	if request.GroupId == nil {
+		return placement
+	}
+	placement.GroupId = request.GroupId
+	if e.DescribePlacementGroupsOutput.IsNil() {
+		return placement
+	}
+	if pg, ok := lo.Find(e.DescribePlacementGroupsOutput.Clone().PlacementGroups, func(pg ec2types.PlacementGroup) bool {
+		return lo.FromPtr(pg.GroupId) == lo.FromPtr(request.GroupId)
+	}); ok {
+		placement.GroupName = pg.GroupName
+		if pg.Strategy == ec2types.PlacementStrategyPartition && lo.FromPtr(pg.PartitionCount) > 0 {
+			placement.PartitionNumber = lo.ToPtr(int32(n)%lo.FromPtr(pg.PartitionCount) + 1)
+		}
+	}
+	return placement
+}
+
 func (e *EC2API) TerminateInstances(_ context.Context, input *ec2.TerminateInstancesInput, _ ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error) {
 	return e.TerminateInstancesBehavior.Invoke(input, func(input *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
 		var instanceStateChanges []ec2types.InstanceStateChange
@@ -312,6 +363,70 @@ func (e *EC2API) CreateTags(_ context.Context, input *ec2.CreateTagsInput, _ ...
 	})
 }
 
+func (e *EC2API) DeleteTags(_ context.Context, input *ec2.DeleteTagsInput, _ ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	return e.DeleteTagsBehavior.Invoke(input, func(input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+		for _, id := range input.Resources {
+			raw, ok := e.Instances.Load(id)
+			if !ok {
+				return nil, serrors.Wrap(fmt.Errorf("instance does not exist"), "instance-id", id)
+			}
+			instance := raw.(ec2types.Instance)
+			instance.Tags = lo.Reject(instance.Tags, func(t ec2types.Tag, _ int) bool {
+				return lo.ContainsBy(input.Tags, func(d ec2types.Tag) bool {
+					return aws.ToString(d.Key) == aws.ToString(t.Key)
+				})
+			})
+			e.Instances.Swap(id, instance)
+		}
+		return &ec2.DeleteTagsOutput{}, nil
+	})
+}
+
+func (e *EC2API) StartInstances(_ context.Context, input *ec2.StartInstancesInput, _ ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error) {
+	return e.StartInstancesBehavior.Invoke(input, func(input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+		var instanceStateChanges []ec2types.InstanceStateChange
+		for _, id := range input.InstanceIds {
+			raw, ok := e.Instances.Load(id)
+			if !ok {
+				return nil, serrors.Wrap(fmt.Errorf("instance does not exist"), "instance-id", id)
+			}
+			instance := raw.(ec2types.Instance)
+			previousState := instance.State
+			instance.State = &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning, Code: aws.Int32(16)}
+			instance.LaunchTime = aws.Time(time.Now())
+			e.Instances.Swap(id, instance)
+			instanceStateChanges = append(instanceStateChanges, ec2types.InstanceStateChange{
+				PreviousState: previousState,
+				CurrentState:  instance.State,
+				InstanceId:    aws.String(id),
+			})
+		}
+		return &ec2.StartInstancesOutput{StartingInstances: instanceStateChanges}, nil
+	})
+}
+
+func (e *EC2API) StopInstances(_ context.Context, input *ec2.StopInstancesInput, _ ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error) {
+	return e.StopInstancesBehavior.Invoke(input, func(input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
+		var instanceStateChanges []ec2types.InstanceStateChange
+		for _, id := range input.InstanceIds {
+			raw, ok := e.Instances.Load(id)
+			if !ok {
+				return nil, serrors.Wrap(fmt.Errorf("instance does not exist"), "instance-id", id)
+			}
+			instance := raw.(ec2types.Instance)
+			previousState := instance.State
+			instance.State = &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped, Code: aws.Int32(80)}
+			e.Instances.Swap(id, instance)
+			instanceStateChanges = append(instanceStateChanges, ec2types.InstanceStateChange{
+				PreviousState: previousState,
+				CurrentState:  instance.State,
+				InstanceId:    aws.String(id),
+			})
+		}
+		return &ec2.StopInstancesOutput{StoppingInstances: instanceStateChanges}, nil
+	})
+}
+
 func (e *EC2API) DescribeInstances(_ context.Context, input *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
 	return e.DescribeInstancesBehavior.Invoke(input, func(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
 		var instances []ec2types.Instance
@@ -395,6 +510,25 @@ func (e *EC2API) DescribeCapacityReservations(ctx context.Context, input *ec2.De
 	return &ec2.DescribeCapacityReservationsOutput{}, nil
 }
 
+func (e *EC2API) DescribePlacementGroups(_ context.Context, input *ec2.DescribePlacementGroupsInput, _ ...func(*ec2.Options)) (*ec2.DescribePlacementGroupsOutput, error) {
+	if !e.NextError.IsNil() {
+		defer e.NextError.Reset()
+		return nil, e.NextError.Get()
+	}
+	out := &ec2.DescribePlacementGroupsOutput{}
+	if !e.DescribePlacementGroupsOutput.IsNil() {
+		out = e.DescribePlacementGroupsOutput.Clone()
+		out.PlacementGroups = FilterDescribePlacementGroups(out.PlacementGroups, input.GroupIds, input.Filters)
+	}
+	if len(input.GroupIds) != 0 && len(out.PlacementGroups) == 0 {
+		return nil, &smithy.GenericAPIError{
+			Code:    "InvalidPlacementGroup.Unknown",
+			Message: "The specified placement group does not exist.",
+		}
+	}
+	return out, nil
+}
+
 func (e *EC2API) DescribeImages(ctx context.Context, input *ec2.DescribeImagesInput, _ ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
 	if !e.NextError.IsNil() {
 		defer e.NextError.Reset()
@@ -423,6 +557,24 @@ func (e *EC2API) DescribeImages(ctx context.Context, input *ec2.DescribeImagesIn
 	}, nil
 }
 
+func (e *EC2API) CopyImage(_ context.Context, input *ec2.CopyImageInput, _ ...func(*ec2.Options)) (*ec2.CopyImageOutput, error) {
+	return e.CopyImageBehavior.Invoke(input, func(_ *ec2.CopyImageInput) (*ec2.CopyImageOutput, error) {
+		return &ec2.CopyImageOutput{ImageId: aws.String(ImageID())}, nil
+	})
+}
+
+func (e *EC2API) DeregisterImage(_ context.Context, input *ec2.DeregisterImageInput, _ ...func(*ec2.Options)) (*ec2.DeregisterImageOutput, error) {
+	return e.DeregisterImageBehavior.Invoke(input, func(_ *ec2.DeregisterImageInput) (*ec2.DeregisterImageOutput, error) {
+		return &ec2.DeregisterImageOutput{}, nil
+	})
+}
+
+func (e *EC2API) DeleteSnapshot(_ context.Context, input *ec2.DeleteSnapshotInput, _ ...func(*ec2.Options)) (*ec2.DeleteSnapshotOutput, error) {
+	return e.DeleteSnapshotBehavior.Invoke(input, func(_ *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
+		return &ec2.DeleteSnapshotOutput{}, nil
+	})
+}
+
 func (e *EC2API) DescribeLaunchTemplates(_ context.Context, input *ec2.DescribeLaunchTemplatesInput, _ ...func(*ec2.Options)) (*ec2.DescribeLaunchTemplatesOutput, error) {
 	if !e.NextError.IsNil() {
 		defer e.NextError.Reset()
@@ -566,6 +718,37 @@ func (e *EC2API) DescribeSecurityGroups(_ context.Context, input *ec2.DescribeSe
 	})
 }
 
+func (e *EC2API) DescribeSecurityGroupRules(_ context.Context, input *ec2.DescribeSecurityGroupRulesInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupRulesOutput, error) {
+	return e.DescribeSecurityGroupRulesBehavior.Invoke(input, func(_ *ec2.DescribeSecurityGroupRulesInput) (*ec2.DescribeSecurityGroupRulesOutput, error) {
+		return &ec2.DescribeSecurityGroupRulesOutput{}, nil
+	})
+}
+
+func (e *EC2API) DescribeVpcs(_ context.Context, input *ec2.DescribeVpcsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	return e.DescribeVpcsBehavior.Invoke(input, func(_ *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+		return &ec2.DescribeVpcsOutput{
+			Vpcs: []ec2types.Vpc{
+				{
+					VpcId:     aws.String("vpc-test1"),
+					CidrBlock: aws.String("10.0.0.0/16"),
+					CidrBlockAssociationSet: []ec2types.VpcCidrBlockAssociation{
+						{
+							CidrBlock:      aws.String("10.0.0.0/16"),
+							CidrBlockState: &ec2types.VpcCidrBlockState{State: ec2types.VpcCidrBlockStateCodeAssociated},
+						},
+					},
+				},
+			},
+		}, nil
+	})
+}
+
+func (e *EC2API) GetManagedPrefixListEntries(_ context.Context, input *ec2.GetManagedPrefixListEntriesInput, _ ...func(*ec2.Options)) (*ec2.GetManagedPrefixListEntriesOutput, error) {
+	return e.GetManagedPrefixListEntriesBehavior.Invoke(input, func(_ *ec2.GetManagedPrefixListEntriesInput) (*ec2.GetManagedPrefixListEntriesOutput, error) {
+		return &ec2.GetManagedPrefixListEntriesOutput{}, nil
+	})
+}
+
 func (e *EC2API) DescribeAvailabilityZones(context.Context, *ec2.DescribeAvailabilityZonesInput, ...func(*ec2.Options)) (*ec2.DescribeAvailabilityZonesOutput, error) {
 	if !e.NextError.IsNil() {
 		defer e.NextError.Reset()
@@ -17,6 +17,7 @@ package fake
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,6 +36,7 @@ const ()
 // pollute each other.
 type IAMAPIBehavior struct {
 	GetInstanceProfileBehavior            MockedFunction[iam.GetInstanceProfileInput, iam.GetInstanceProfileOutput]
+	ListInstanceProfilesBehavior          MockedFunction[iam.ListInstanceProfilesInput, iam.ListInstanceProfilesOutput]
 	CreateInstanceProfileBehavior         MockedFunction[iam.CreateInstanceProfileInput, iam.CreateInstanceProfileOutput]
 	DeleteInstanceProfileBehavior         MockedFunction[iam.DeleteInstanceProfileInput, iam.DeleteInstanceProfileOutput]
 	AddRoleToInstanceProfileBehavior      MockedFunction[iam.AddRoleToInstanceProfileInput, iam.AddRoleToInstanceProfileOutput]
@@ -57,9 +59,11 @@ func NewIAMAPI() *IAMAPI {
 
 func (s *IAMAPI) Reset() {
 	s.GetInstanceProfileBehavior.Reset()
+	s.ListInstanceProfilesBehavior.Reset()
 	s.CreateInstanceProfileBehavior.Reset()
 	s.DeleteInstanceProfileBehavior.Reset()
 	s.AddRoleToInstanceProfileBehavior.Reset()
+	s.TagInstanceProfileBehavior.Reset()
 	s.RemoveRoleFromInstanceProfileBehavior.Reset()
 	s.InstanceProfiles = map[string]*iamtypes.InstanceProfile{}
 }
@@ -80,6 +84,27 @@ func (s *IAMAPI) GetInstanceProfile(_ context.Context, input *iam.GetInstancePro
 	})
 }
 
+func (s *IAMAPI) ListInstanceProfiles(_ context.Context, input *iam.ListInstanceProfilesInput, _ ...func(*iam.Options)) (*iam.ListInstanceProfilesOutput, error) {
+	return s.ListInstanceProfilesBehavior.Invoke(input, func(*iam.ListInstanceProfilesInput) (*iam.ListInstanceProfilesOutput, error) {
+		s.Lock()
+		defer s.Unlock()
+
+		// ListInstanceProfiles doesn't return the tags of the instance profiles
+		return &iam.ListInstanceProfilesOutput{
+			InstanceProfiles: lo.FilterMap(lo.Values(s.InstanceProfiles), func(i *iamtypes.InstanceProfile, _ int) (iamtypes.InstanceProfile, bool) {
+				return iamtypes.InstanceProfile{
+					Arn:                 i.Arn,
+					CreateDate:          i.CreateDate,
+					InstanceProfileId:   i.InstanceProfileId,
+					InstanceProfileName: i.InstanceProfileName,
+					Path:                i.Path,
+					Roles:               i.Roles,
+				}, strings.HasPrefix(lo.Ternary(i.Path != nil, lo.FromPtr(i.Path), "/"), lo.FromPtr(input.PathPrefix))
+			}),
+		}, nil
+	})
+}
+
 func (s *IAMAPI) CreateInstanceProfile(_ context.Context, input *iam.CreateInstanceProfileInput, _ ...func(*iam.Options)) (*iam.CreateInstanceProfileOutput, error) {
 	return s.CreateInstanceProfileBehavior.Invoke(input, func(output *iam.CreateInstanceProfileInput) (*iam.CreateInstanceProfileOutput, error) {
 		s.Lock()
@@ -110,6 +110,26 @@ func FilterDescribeCapacityReservations(crs []ec2types.CapacityReservation, ids
 	})
 }
 
+// FilterDescribePlacementGroups filters the passed in placement groups based on the ids and filters passed in.
+// Filters are chained with a logical "AND"
+func FilterDescribePlacementGroups(pgs []ec2types.PlacementGroup, ids []string, filters []ec2types.Filter) []ec2types.PlacementGroup {
+	idSet := sets.New[string](ids...)
+	strategyFilters, filters := lo.FilterReject(filters, func(filter ec2types.Filter, _ int) bool {
+		return aws.ToString(filter.Name) == "strategy"
+	})
+	return lo.Filter(pgs, func(pg ec2types.PlacementGroup, _ int) bool {
+		if len(ids) != 0 && !idSet.Has(*pg.GroupId) {
+			return false
+		}
+		if !lo.EveryBy(strategyFilters, func(filter ec2types.Filter) bool {
+			return lo.Contains(filter.Values, string(pg.Strategy))
+		}) {
+			return false
+		}
+		return Filter(filters, *pg.GroupId, *pg.GroupName, "", string(pg.State), pg.Tags)
+	})
+}
+
 func FilterDescribeImages(images []ec2types.Image, filters []ec2types.Filter) []ec2types.Image {
 	return lo.Filter(images, func(image ec2types.Image, _ int) bool {
 		return Filter(filters, *image.ImageId, *image.Name, "", string(image.State), image.Tags)
@@ -36,14 +36,19 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(true),
+			NitroEnclavesSupport:          "supported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("AWS"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"arm64"},
 				SustainedClockSpeedInGhz: aws.Float64(2.500000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(2),
-				DefaultVCpus: aws.Int32(2),
+				DefaultCores:          aws.Int32(2),
+				DefaultThreadsPerCore: aws.Int32(1),
+				DefaultVCpus:          aws.Int32(2),
+				ValidCores:            []int32{1, 2},
+				ValidThreadsPerCore:   []int32{1},
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(4096),
@@ -81,14 +86,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(false),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("Intel"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(3.000000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(48),
-				DefaultVCpus: aws.Int32(96),
+				DefaultCores:          aws.Int32(48),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(96),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(786432),
@@ -156,14 +164,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(false),
+			NitroEnclavesSupport:          "supported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("AMD"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(3.000000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(32),
-				DefaultVCpus: aws.Int32(64),
+				DefaultCores:          aws.Int32(32),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(64),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(262144),
@@ -216,14 +227,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(true),
+			NitroEnclavesSupport:          "supported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("Intel"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(2.500000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(16),
-				DefaultVCpus: aws.Int32(32),
+				DefaultCores:          aws.Int32(16),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(32),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(131072),
@@ -279,14 +293,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(false),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("AMD"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(3.600000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(48),
-				DefaultVCpus: aws.Int32(96),
+				DefaultCores:          aws.Int32(48),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(96),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(393216),
@@ -339,14 +356,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(false),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("AMD"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(3.600000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(2),
-				DefaultVCpus: aws.Int32(4),
+				DefaultCores:          aws.Int32(2),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(4),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(16384),
@@ -399,14 +419,19 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(true),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("Intel"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(3.100000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(1),
-				DefaultVCpus: aws.Int32(2),
+				DefaultCores:          aws.Int32(1),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(2),
+				ValidCores:            []int32{1},
+				ValidThreadsPerCore:   []int32{1, 2},
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(8192),
@@ -444,14 +469,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(true),
 			Hypervisor:                    "",
+			HibernationSupported:          aws.Bool(false),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("Intel"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(3.100000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(48),
-				DefaultVCpus: aws.Int32(96),
+				DefaultCores:          aws.Int32(48),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(96),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(393216),
@@ -489,14 +517,19 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(true),
+			NitroEnclavesSupport:          "supported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("Intel"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(3.100000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(2),
-				DefaultVCpus: aws.Int32(4),
+				DefaultCores:          aws.Int32(2),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(4),
+				ValidCores:            []int32{2},
+				ValidThreadsPerCore:   []int32{1, 2},
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(16384),
@@ -534,14 +567,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(false),
+			NitroEnclavesSupport:          "supported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("Intel"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(3.500000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(64),
-				DefaultVCpus: aws.Int32(128),
+				DefaultCores:          aws.Int32(64),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(128),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(524288),
@@ -589,14 +625,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "xen",
+			HibernationSupported:          aws.Bool(false),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("Intel"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(2.700000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(16),
-				DefaultVCpus: aws.Int32(32),
+				DefaultCores:          aws.Int32(16),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(32),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(249856),
@@ -646,14 +685,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(true),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(true),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("Intel"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(2.500000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(1),
-				DefaultVCpus: aws.Int32(2),
+				DefaultCores:          aws.Int32(1),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(2),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(8192),
@@ -691,14 +733,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(true),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(true),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("AWS"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"arm64"},
 				SustainedClockSpeedInGhz: aws.Float64(2.500000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(2),
-				DefaultVCpus: aws.Int32(2),
+				DefaultCores:          aws.Int32(2),
+				DefaultThreadsPerCore: aws.Int32(1),
+				DefaultVCpus:          aws.Int32(2),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(4096),
@@ -736,14 +781,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(true),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(true),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("AWS"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"arm64"},
 				SustainedClockSpeedInGhz: aws.Float64(2.500000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(2),
-				DefaultVCpus: aws.Int32(2),
+				DefaultCores:          aws.Int32(2),
+				DefaultThreadsPerCore: aws.Int32(1),
+				DefaultVCpus:          aws.Int32(2),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(2048),
@@ -781,14 +829,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(true),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(true),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("AWS"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"arm64"},
 				SustainedClockSpeedInGhz: aws.Float64(2.500000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(4),
-				DefaultVCpus: aws.Int32(4),
+				DefaultCores:          aws.Int32(4),
+				DefaultThreadsPerCore: aws.Int32(1),
+				DefaultVCpus:          aws.Int32(4),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(16384),
@@ -826,14 +877,17 @@ var defaultDescribeInstanceTypesOutput = &ec2.DescribeInstanceTypesOutput{
 			BurstablePerformanceSupported: aws.Bool(false),
 			BareMetal:                     aws.Bool(false),
 			Hypervisor:                    "nitro",
+			HibernationSupported:          aws.Bool(false),
+			NitroEnclavesSupport:          "unsupported",
 			ProcessorInfo: &ec2types.ProcessorInfo{
 				Manufacturer:             aws.String("Intel"),
 				SupportedArchitectures:   []ec2types.ArchitectureType{"x86_64"},
 				SustainedClockSpeedInGhz: aws.Float64(3.500000),
 			},
 			VCpuInfo: &ec2types.VCpuInfo{
-				DefaultCores: aws.Int32(4),
-				DefaultVCpus: aws.Int32(8),
+				DefaultCores:          aws.Int32(4),
+				DefaultThreadsPerCore: aws.Int32(2),
+				DefaultVCpus:          aws.Int32(8),
 			},
 			MemoryInfo: &ec2types.MemoryInfo{
 				SizeInMiB: aws.Int64(32768),
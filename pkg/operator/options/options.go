@@ -33,15 +33,41 @@ func init() {
 
 type optionsKey struct{}
 
+// IPAccountingMode describes how the VPC CNI consumes IP addresses from the node's subnet and how many pods a node supports
+const (
+	// IPAccountingModeSecondaryIP assigns a secondary IP address from the node's subnet to each pod
+	IPAccountingModeSecondaryIP = "SecondaryIP"
+	// IPAccountingModePrefixDelegation assigns /28 IPv4 prefixes from the node's subnet to the node's network interfaces
+	IPAccountingModePrefixDelegation = "PrefixDelegation"
+	// IPAccountingModeCustomNetworking assigns pod IP addresses from the ENIConfig subnet, so the node's subnet only provides
+	// the node's primary IP address and the primary network interface isn't used for pods
+	IPAccountingModeCustomNetworking = "CustomNetworking"
+	// IPAccountingModeCustomNetworkingPrefixDelegation combines custom networking with prefix delegation on the pod network interfaces
+	IPAccountingModeCustomNetworkingPrefixDelegation = "CustomNetworkingPrefixDelegation"
+)
+
+var IPAccountingModes = []string{
+	IPAccountingModeSecondaryIP,
+	IPAccountingModePrefixDelegation,
+	IPAccountingModeCustomNetworking,
+	IPAccountingModeCustomNetworkingPrefixDelegation,
+}
+
 type Options struct {
-	ClusterCABundle         string
-	ClusterName             string
-	ClusterEndpoint         string
-	IsolatedVPC             bool
-	EKSControlPlane         bool
-	VMMemoryOverheadPercent float64
-	InterruptionQueue       string
-	ReservedENIs            int
+	ClusterCABundle            string
+	ClusterName                string
+	ClusterEndpoint            string
+	IsolatedVPC                bool
+	EKSControlPlane            bool
+	VMMemoryOverheadPercent    float64
+	InterruptionQueue          string
+	ReservedENIs               int
+	SubnetsPerZone             int
+	IPAccountingMode           string
+	SubnetCapacityThreshold    int
+	ValidateSecurityGroupRules bool
+	EnableUserDataRendering    bool
+	UserDataRenderingPort      int
 }
 
 func (o *Options) AddFlags(fs *coreoptions.FlagSet) {
@@ -53,6 +79,22 @@ func (o *Options) AddFlags(fs *coreoptions.FlagSet) {
 	fs.Float64Var(&o.VMMemoryOverheadPercent, "vm-memory-overhead-percent", utils.WithDefaultFloat64("VM_MEMORY_OVERHEAD_PERCENT", 0.075), "The VM memory overhead as a percent that will be subtracted from the total memory for all instance types when cached information is unavailable.")
 	fs.StringVar(&o.InterruptionQueue, "interruption-queue", env.WithDefaultString("INTERRUPTION_QUEUE", ""), "Interruption queue is the name of the SQS queue used for processing interruption events from EC2. Interruption handling is disabled if not specified. Enabling interruption handling may require additional permissions on the controller service account. Additional permissions are outlined in the docs.")
 	fs.IntVar(&o.ReservedENIs, "reserved-enis", env.WithDefaultInt("RESERVED_ENIS", 0), "Reserved ENIs are not included in the calculations for max-pods or kube-reserved. This is most often used in the VPC CNI custom networking setup https://docs.aws.amazon.com/eks/latest/userguide/cni-custom-network.html.")
+	fs.IntVar(&o.SubnetsPerZone, "subnets-per-zone", env.WithDefaultInt("SUBNETS_PER_ZONE", 1), "The maximum number of subnets per zone, ordered by available IP addresses, that are included in a CreateFleet request. Values greater than 1 allow EC2 Fleet to fall back to another subnet in the same zone when a subnet runs out of free IP addresses.")
+	fs.StringVar(&o.IPAccountingMode, "ip-accounting-mode", env.WithDefaultString("IP_ACCOUNTING_MODE", IPAccountingModeSecondaryIP), "How the VPC CNI consumes IP addresses, used when tracking the available IP addresses of subnets and computing max-pods. Can be one of 'SecondaryIP', 'PrefixDelegation', 'CustomNetworking' and 'CustomNetworkingPrefixDelegation'. Custom networking modes reserve the primary network interface when reserved-enis is 0.")
+	fs.IntVar(&o.SubnetCapacityThreshold, "subnet-capacity-threshold", env.WithDefaultInt("SUBNET_CAPACITY_THRESHOLD", 0), "The number of available IP addresses at or below which a subnet is considered exhausted. An EC2NodeClass reports that its subnets lack capacity when every subnet in a zone is exhausted.")
+	fs.BoolVarWithEnv(&o.ValidateSecurityGroupRules, "validate-security-group-rules", "VALIDATE_SECURITY_GROUP_RULES", false, "If true, then the ingress rules of each EC2NodeClass's security groups are checked for the traffic nodes need to join the cluster: the API server to the kubelet, DNS and node-to-node ephemeral ports. Gaps are reported in the SecurityGroupRulesValid status condition.")
+	fs.BoolVarWithEnv(&o.EnableUserDataRendering, "enable-userdata-rendering", "ENABLE_USERDATA_RENDERING", false, "If true, then the launch templates that would be created for a NodePool, including their decoded userData, can be rendered from /debug/userdata on a server that only listens on localhost. The rendered userData can contain secrets and the server isn't authenticated, so anyone who can reach localhost in the controller's pod, e.g. with kubectl exec or port-forward, can read it.")
+	fs.IntVar(&o.UserDataRenderingPort, "userdata-rendering-port", env.WithDefaultInt("USERDATA_RENDERING_PORT", 8082), "The port the localhost-only server that renders userData listens on when enable-userdata-rendering is set.")
+}
+
+// PrefixDelegationEnabled returns true if the VPC CNI assigns IPv4 prefixes rather than individual IP addresses
+func (o *Options) PrefixDelegationEnabled() bool {
+	return o.IPAccountingMode == IPAccountingModePrefixDelegation || o.IPAccountingMode == IPAccountingModeCustomNetworkingPrefixDelegation
+}
+
+// CustomNetworkingEnabled returns true if pods receive IP addresses from a subnet other than the node's subnet
+func (o *Options) CustomNetworkingEnabled() bool {
+	return o.IPAccountingMode == IPAccountingModeCustomNetworking || o.IPAccountingMode == IPAccountingModeCustomNetworkingPrefixDelegation
 }
 
 func (o *Options) Parse(fs *coreoptions.FlagSet, args ...string) error {
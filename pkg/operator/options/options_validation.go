@@ -19,6 +19,7 @@ import (
 	"net/url"
 
 	"github.com/awslabs/operatorpkg/serrors"
+	"github.com/samber/lo"
 	"go.uber.org/multierr"
 )
 
@@ -27,6 +28,10 @@ func (o *Options) Validate() error {
 		o.validateEndpoint(),
 		o.validateVMMemoryOverheadPercent(),
 		o.validateReservedENIs(),
+		o.validateSubnetsPerZone(),
+		o.validateIPAccountingMode(),
+		o.validateSubnetCapacityThreshold(),
+		o.validateUserDataRenderingPort(),
 		o.validateRequiredFields(),
 	)
 }
@@ -58,6 +63,34 @@ func (o *Options) validateReservedENIs() error {
 	return nil
 }
 
+func (o *Options) validateSubnetsPerZone() error {
+	if o.SubnetsPerZone < 1 {
+		return fmt.Errorf("subnets-per-zone must be at least 1")
+	}
+	return nil
+}
+
+func (o *Options) validateIPAccountingMode() error {
+	if !lo.Contains(IPAccountingModes, o.IPAccountingMode) {
+		return fmt.Errorf("ip-accounting-mode must be one of %v", IPAccountingModes)
+	}
+	return nil
+}
+
+func (o *Options) validateUserDataRenderingPort() error {
+	if o.UserDataRenderingPort < 1 || o.UserDataRenderingPort > 65535 {
+		return fmt.Errorf("userdata-rendering-port must be between 1 and 65535")
+	}
+	return nil
+}
+
+func (o *Options) validateSubnetCapacityThreshold() error {
+	if o.SubnetCapacityThreshold < 0 {
+		return fmt.Errorf("subnet-capacity-threshold cannot be negative")
+	}
+	return nil
+}
+
 func (o *Options) validateRequiredFields() error {
 	if o.ClusterName == "" {
 		return fmt.Errorf("missing field, cluster-name")
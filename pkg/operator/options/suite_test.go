@@ -62,16 +62,28 @@ var _ = Describe("Options", func() {
 			"--isolated-vpc",
 			"--vm-memory-overhead-percent", "0.1",
 			"--interruption-queue", "env-cluster",
-			"--reserved-enis", "10")
+			"--reserved-enis", "10",
+			"--subnets-per-zone", "3",
+			"--ip-accounting-mode", "PrefixDelegation",
+			"--subnet-capacity-threshold", "20",
+			"--validate-security-group-rules",
+			"--enable-userdata-rendering",
+			"--userdata-rendering-port", "9000")
 		Expect(err).ToNot(HaveOccurred())
 		expectOptionsEqual(opts, test.Options(test.OptionsFields{
-			ClusterCABundle:         lo.ToPtr("env-bundle"),
-			ClusterName:             lo.ToPtr("env-cluster"),
-			ClusterEndpoint:         lo.ToPtr("https://env-cluster"),
-			IsolatedVPC:             lo.ToPtr(true),
-			VMMemoryOverheadPercent: lo.ToPtr[float64](0.1),
-			InterruptionQueue:       lo.ToPtr("env-cluster"),
-			ReservedENIs:            lo.ToPtr(10),
+			ClusterCABundle:            lo.ToPtr("env-bundle"),
+			ClusterName:                lo.ToPtr("env-cluster"),
+			ClusterEndpoint:            lo.ToPtr("https://env-cluster"),
+			IsolatedVPC:                lo.ToPtr(true),
+			VMMemoryOverheadPercent:    lo.ToPtr[float64](0.1),
+			InterruptionQueue:          lo.ToPtr("env-cluster"),
+			ReservedENIs:               lo.ToPtr(10),
+			SubnetsPerZone:             lo.ToPtr(3),
+			IPAccountingMode:           lo.ToPtr("PrefixDelegation"),
+			SubnetCapacityThreshold:    lo.ToPtr(20),
+			ValidateSecurityGroupRules: lo.ToPtr(true),
+			EnableUserDataRendering:    lo.ToPtr(true),
+			UserDataRenderingPort:      lo.ToPtr(9000),
 		}))
 	})
 	It("should correctly fallback to env vars when CLI flags aren't set", func() {
@@ -82,6 +94,12 @@ var _ = Describe("Options", func() {
 		os.Setenv("VM_MEMORY_OVERHEAD_PERCENT", "0.1")
 		os.Setenv("INTERRUPTION_QUEUE", "env-cluster")
 		os.Setenv("RESERVED_ENIS", "10")
+		os.Setenv("SUBNETS_PER_ZONE", "3")
+		os.Setenv("IP_ACCOUNTING_MODE", "PrefixDelegation")
+		os.Setenv("SUBNET_CAPACITY_THRESHOLD", "20")
+		os.Setenv("VALIDATE_SECURITY_GROUP_RULES", "true")
+		os.Setenv("ENABLE_USERDATA_RENDERING", "true")
+		os.Setenv("USERDATA_RENDERING_PORT", "9000")
 
 		// Add flags after we set the environment variables so that the parsing logic correctly refers
 		// to the new environment variable values
@@ -89,13 +107,19 @@ var _ = Describe("Options", func() {
 		err := opts.Parse(fs)
 		Expect(err).ToNot(HaveOccurred())
 		expectOptionsEqual(opts, test.Options(test.OptionsFields{
-			ClusterCABundle:         lo.ToPtr("env-bundle"),
-			ClusterName:             lo.ToPtr("env-cluster"),
-			ClusterEndpoint:         lo.ToPtr("https://env-cluster"),
-			IsolatedVPC:             lo.ToPtr(true),
-			VMMemoryOverheadPercent: lo.ToPtr[float64](0.1),
-			InterruptionQueue:       lo.ToPtr("env-cluster"),
-			ReservedENIs:            lo.ToPtr(10),
+			ClusterCABundle:            lo.ToPtr("env-bundle"),
+			ClusterName:                lo.ToPtr("env-cluster"),
+			ClusterEndpoint:            lo.ToPtr("https://env-cluster"),
+			IsolatedVPC:                lo.ToPtr(true),
+			VMMemoryOverheadPercent:    lo.ToPtr[float64](0.1),
+			InterruptionQueue:          lo.ToPtr("env-cluster"),
+			ReservedENIs:               lo.ToPtr(10),
+			SubnetsPerZone:             lo.ToPtr(3),
+			IPAccountingMode:           lo.ToPtr("PrefixDelegation"),
+			SubnetCapacityThreshold:    lo.ToPtr(20),
+			ValidateSecurityGroupRules: lo.ToPtr(true),
+			EnableUserDataRendering:    lo.ToPtr(true),
+			UserDataRenderingPort:      lo.ToPtr(9000),
 		}))
 	})
 
@@ -119,6 +143,22 @@ var _ = Describe("Options", func() {
 			err := opts.Parse(fs, "--cluster-name", "test-cluster", "--reserved-enis", "-1")
 			Expect(err).To(HaveOccurred())
 		})
+		It("should fail when subnetsPerZone is less than 1", func() {
+			err := opts.Parse(fs, "--cluster-name", "test-cluster", "--subnets-per-zone", "0")
+			Expect(err).To(HaveOccurred())
+		})
+		It("should fail when ipAccountingMode is not a known mode", func() {
+			err := opts.Parse(fs, "--cluster-name", "test-cluster", "--ip-accounting-mode", "Unknown")
+			Expect(err).To(HaveOccurred())
+		})
+		It("should fail when userDataRenderingPort is out of range", func() {
+			err := opts.Parse(fs, "--cluster-name", "test-cluster", "--userdata-rendering-port", "0")
+			Expect(err).To(HaveOccurred())
+		})
+		It("should fail when subnetCapacityThreshold is negative", func() {
+			err := opts.Parse(fs, "--cluster-name", "test-cluster", "--subnet-capacity-threshold", "-1")
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
 
@@ -131,4 +171,10 @@ func expectOptionsEqual(optsA *options.Options, optsB *options.Options) {
 	Expect(optsA.VMMemoryOverheadPercent).To(Equal(optsB.VMMemoryOverheadPercent))
 	Expect(optsA.InterruptionQueue).To(Equal(optsB.InterruptionQueue))
 	Expect(optsA.ReservedENIs).To(Equal(optsB.ReservedENIs))
+	Expect(optsA.SubnetsPerZone).To(Equal(optsB.SubnetsPerZone))
+	Expect(optsA.IPAccountingMode).To(Equal(optsB.IPAccountingMode))
+	Expect(optsA.SubnetCapacityThreshold).To(Equal(optsB.SubnetCapacityThreshold))
+	Expect(optsA.ValidateSecurityGroupRules).To(Equal(optsB.ValidateSecurityGroupRules))
+	Expect(optsA.EnableUserDataRendering).To(Equal(optsB.EnableUserDataRendering))
+	Expect(optsA.UserDataRenderingPort).To(Equal(optsB.UserDataRenderingPort))
 }
@@ -92,6 +92,7 @@ func (a AL2023) UserData(kubeletConfig *v1.KubeletConfiguration, taints []corev1
 			CustomUserData:      customUserData,
 			InstanceStorePolicy: instanceStorePolicy,
 		},
+		NodeConfig: a.Options.NodeConfig,
 	}
 }
 
@@ -17,6 +17,7 @@ package amifamily
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"strings"
 	"sync"
 
@@ -98,6 +99,13 @@ func (p *DefaultProvider) DescribeImageQueries(ctx context.Context, nodeClass *v
 	queries := []DescribeImageQuery{}
 	for _, term := range nodeClass.Spec.AMISelectorTerms {
 		switch {
+		// Terms that can't share the ID filter with the other terms are queried on their own
+		case term.ID != "" && (term.Replication != nil || len(term.Requirements) != 0):
+			queries = append(queries, DescribeImageQuery{
+				Filters:      []ec2types.Filter{{Name: aws.String("image-id"), Values: []string{term.ID}}},
+				SourceRegion: lo.TernaryF(term.Replication != nil, func() string { return term.Replication.SourceRegion }, func() string { return "" }),
+				Requirements: term.Requirements,
+			})
 		case term.ID != "":
 			idFilter.Values = append(idFilter.Values, term.ID)
 		case term.SSMParameter != "":
@@ -116,6 +124,13 @@ func (p *DefaultProvider) DescribeImageQueries(ctx context.Context, nodeClass *v
 				log.FromContext(ctx).WithValues("ssmParameter", term.SSMParameter, "id", imageID).V(1).Error(nil, "parameter value is an invalid AMI ID")
 				continue
 			}
+			if len(term.Requirements) != 0 {
+				queries = append(queries, DescribeImageQuery{
+					Filters:      []ec2types.Filter{{Name: aws.String("image-id"), Values: []string{imageID}}},
+					Requirements: term.Requirements,
+				})
+				continue
+			}
 			idFilter.Values = append(idFilter.Values, imageID)
 		default:
 			query := DescribeImageQuery{
@@ -124,9 +139,7 @@ func (p *DefaultProvider) DescribeImageQueries(ctx context.Context, nodeClass *v
 			if term.Name != "" {
 				// Default owners to self,amazon to ensure Karpenter only discovers cross-account AMIs if the user specifically allows it.
 				// Removing this default would cause Karpenter to discover publicly shared AMIs passing the name filter.
-				query = DescribeImageQuery{
-					Owners: lo.Ternary(term.Owner != "", []string{term.Owner}, []string{"self", "amazon"}),
-				}
+				query.Owners = lo.Ternary(term.Owner != "", []string{term.Owner}, []string{"self", "amazon"})
 				query.Filters = append(query.Filters, ec2types.Filter{
 					Name:   aws.String("name"),
 					Values: []string{term.Name},
@@ -146,6 +159,16 @@ func (p *DefaultProvider) DescribeImageQueries(ctx context.Context, nodeClass *v
 					})
 				}
 			}
+			if term.MinAge != nil {
+				query.MinAge = term.MinAge.Duration
+			}
+			if term.MaxAge != nil {
+				query.MaxAge = term.MaxAge.Duration
+			}
+			if term.Replication != nil {
+				query.SourceRegion = term.Replication.SourceRegion
+			}
+			query.Requirements = term.Requirements
 			queries = append(queries, query)
 		}
 	}
@@ -170,7 +193,11 @@ func (p *DefaultProvider) amis(ctx context.Context, queries []DescribeImageQuery
 	for _, query := range queries {
 		paginator := ec2.NewDescribeImagesPaginator(p.ec2api, query.DescribeImagesInput())
 		for paginator.HasMorePages() {
-			page, err := paginator.NextPage(ctx)
+			page, err := paginator.NextPage(ctx, func(o *ec2.Options) {
+				if query.SourceRegion != "" {
+					o.Region = query.SourceRegion
+				}
+			})
 			if err != nil {
 				return nil, fmt.Errorf("describing images, %w", err)
 			}
@@ -179,6 +206,9 @@ func (p *DefaultProvider) amis(ctx context.Context, queries []DescribeImageQuery
 				if !ok {
 					continue
 				}
+				if !query.MatchesAge(p.clk.Since(parseTimeWithDefault(lo.FromPtr(image.CreationDate), minTime))) {
+					continue
+				}
 				// Each image may have multiple associated sets of requirements. For example, an image may be compatible with Neuron instances
 				// and GPU instances. In that case, we'll have a set of requirements for each, and will create one "image" for each.
 				for _, reqs := range query.RequirementsForImageWithArchitecture(lo.FromPtr(image.ImageId), arch) {
@@ -189,11 +219,13 @@ func (p *DefaultProvider) amis(ctx context.Context, queries []DescribeImageQuery
 					reqsHash := lo.Must(hashstructure.Hash(reqs.NodeSelectorRequirements(), hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true}))
 					candidateDeprecated := parseTimeWithDefault(lo.FromPtr(image.DeprecationTime), maxTime).Unix() <= p.clk.Now().Unix()
 					ami := AMI{
-						Name:         lo.FromPtr(image.Name),
-						AmiID:        lo.FromPtr(image.ImageId),
-						CreationDate: lo.FromPtr(image.CreationDate),
-						Deprecated:   candidateDeprecated,
-						Requirements: reqs,
+						Name:            lo.FromPtr(image.Name),
+						AmiID:           lo.FromPtr(image.ImageId),
+						CreationDate:    lo.FromPtr(image.CreationDate),
+						DeprecationTime: lo.FromPtr(image.DeprecationTime),
+						Deprecated:      candidateDeprecated,
+						Requirements:    reqs,
+						SourceRegion:    query.SourceRegion,
 					}
 					if v, ok := images[reqsHash]; ok {
 						if cmpResult := compareAMI(v, ami); cmpResult <= 0 {
@@ -209,8 +241,19 @@ func (p *DefaultProvider) amis(ctx context.Context, queries []DescribeImageQuery
 	return lo.Values(images), nil
 }
 
-// MapToInstanceTypes returns a map of AMIIDs that are the most recent on creationDate to compatible instancetypes
-func MapToInstanceTypes(instanceTypes []*cloudprovider.InstanceType, amis []v1.AMI) map[string][]*cloudprovider.InstanceType {
+// MapToInstanceTypes returns a map of AMIIDs that are the most recent on creationDate to compatible instancetypes.
+// AMIs that are being rolled out are only mapped for canary launches, where they're preferred over the stable AMIs.
+// Halted AMIs are never mapped.
+func MapToInstanceTypes(instanceTypes []*cloudprovider.InstanceType, amis []v1.AMI, canary bool) map[string][]*cloudprovider.InstanceType {
+	amis = lo.Filter(amis, func(ami v1.AMI, _ int) bool {
+		return ami.RolloutPhase != v1.AMIRolloutPhaseHalted && (canary || ami.RolloutPhase != v1.AMIRolloutPhaseCanary)
+	})
+	if canary {
+		amis = append(
+			lo.Filter(amis, func(ami v1.AMI, _ int) bool { return ami.RolloutPhase == v1.AMIRolloutPhaseCanary }),
+			lo.Reject(amis, func(ami v1.AMI, _ int) bool { return ami.RolloutPhase == v1.AMIRolloutPhaseCanary })...,
+		)
+	}
 	amiIDs := map[string][]*cloudprovider.InstanceType{}
 	for _, instanceType := range instanceTypes {
 		for _, ami := range amis {
@@ -225,6 +268,19 @@ func MapToInstanceTypes(instanceTypes []*cloudprovider.InstanceType, amis []v1.A
 	return amiIDs
 }
 
+// IsCanaryLaunch returns true if the NodeClaim should be launched with the AMIs that are being rolled out. NodeClaims
+// are selected by hashing their name, so the same NodeClaim is always selected for the same canary percentage.
+func IsCanaryLaunch(nodeClass *v1.EC2NodeClass, nodeClaimName string) bool {
+	if nodeClass.Spec.AMIRolloutPolicy == nil || !lo.ContainsBy(nodeClass.Status.AMIs, func(ami v1.AMI) bool {
+		return ami.RolloutPhase == v1.AMIRolloutPhaseCanary
+	}) {
+		return false
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(nodeClaimName))
+	return int32(h.Sum32()%100) < nodeClass.Spec.AMIRolloutPolicy.CanaryPercentage
+}
+
 // Compare two AMI's based on their deprecation status, creation time or name
 // If both AMIs are deprecated, compare creation time and return the one with the newer creation time
 // If both AMIs are non-deprecated, compare creation time and return the one with the newer creation time
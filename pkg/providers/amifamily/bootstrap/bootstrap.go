@@ -40,6 +40,35 @@ type Options struct {
 	InstanceStorePolicy *v1.InstanceStorePolicy
 }
 
+// instanceStoreMountScriptTemplate formats and mounts the first NVMe instance store disk, and bind mounts each of the
+// directories onto it. Existing content is copied so that anything the AMI placed in the directories is preserved.
+const instanceStoreMountScriptTemplate = `DISK=$(find -L /dev/disk/by-id/ -xtype l -name '*NVMe_Instance_Storage_*' -exec readlink -f {} \; | sort -u | head -n 1)
+if [ -n "$DISK" ]; then
+  MOUNT_POINT=/mnt/k8s-disks/0
+  mkfs.xfs -f "$DISK"
+  mkdir -p "$MOUNT_POINT"
+  mount -o defaults,noatime "$DISK" "$MOUNT_POINT"
+  for DIR in %s; do
+    mkdir -p "$MOUNT_POINT$DIR" "$DIR"
+    cp -a "$DIR/." "$MOUNT_POINT$DIR/"
+    mount --bind "$MOUNT_POINT$DIR" "$DIR"
+  done
+fi
+`
+
+// instanceStoreMountScript returns the script that mounts the first NVMe instance store disk for the
+// InstanceStorePolicy, or an empty string if the policy doesn't mount a single disk
+func (o Options) instanceStoreMountScript() string {
+	switch lo.FromPtr(o.InstanceStorePolicy) {
+	case v1.InstanceStorePolicyMount:
+		return fmt.Sprintf(instanceStoreMountScriptTemplate, "/var/lib/containerd /var/lib/kubelet /var/log/pods")
+	case v1.InstanceStorePolicyContainerdOnly:
+		return fmt.Sprintf(instanceStoreMountScriptTemplate, "/var/lib/containerd")
+	default:
+		return ""
+	}
+}
+
 func (o Options) kubeletExtraArgs() (args []string) {
 	args = append(args, o.nodeLabelArg(), o.nodeTaintArg())
 
@@ -71,6 +100,36 @@ func (o Options) kubeletExtraArgs() (args []string) {
 	if o.KubeletConfig.CPUCFSQuota != nil {
 		args = append(args, fmt.Sprintf("--cpu-cfs-quota=%t", lo.FromPtr(o.KubeletConfig.CPUCFSQuota)))
 	}
+	if o.KubeletConfig.CPUManagerPolicy != nil {
+		args = append(args, fmt.Sprintf("--cpu-manager-policy=%s", lo.FromPtr(o.KubeletConfig.CPUManagerPolicy)))
+	}
+	if o.KubeletConfig.CPUManagerReconcilePeriod != nil {
+		args = append(args, fmt.Sprintf("--cpu-manager-reconcile-period=%s", o.KubeletConfig.CPUManagerReconcilePeriod.Duration))
+	}
+	if o.KubeletConfig.TopologyManagerPolicy != nil {
+		args = append(args, fmt.Sprintf("--topology-manager-policy=%s", lo.FromPtr(o.KubeletConfig.TopologyManagerPolicy)))
+	}
+	if o.KubeletConfig.TopologyManagerScope != nil {
+		args = append(args, fmt.Sprintf("--topology-manager-scope=%s", lo.FromPtr(o.KubeletConfig.TopologyManagerScope)))
+	}
+	if o.KubeletConfig.RegistryPullQPS != nil {
+		args = append(args, fmt.Sprintf("--registry-qps=%d", lo.FromPtr(o.KubeletConfig.RegistryPullQPS)))
+	}
+	if o.KubeletConfig.RegistryBurst != nil {
+		args = append(args, fmt.Sprintf("--registry-burst=%d", lo.FromPtr(o.KubeletConfig.RegistryBurst)))
+	}
+	if o.KubeletConfig.ContainerLogMaxSize != nil {
+		args = append(args, fmt.Sprintf("--container-log-max-size=%s", lo.FromPtr(o.KubeletConfig.ContainerLogMaxSize)))
+	}
+	if o.KubeletConfig.ContainerLogMaxFiles != nil {
+		args = append(args, fmt.Sprintf("--container-log-max-files=%d", lo.FromPtr(o.KubeletConfig.ContainerLogMaxFiles)))
+	}
+	if o.KubeletConfig.SeccompDefault != nil {
+		args = append(args, fmt.Sprintf("--seccomp-default=%t", lo.FromPtr(o.KubeletConfig.SeccompDefault)))
+	}
+	if o.KubeletConfig.PodPidsLimit != nil {
+		args = append(args, fmt.Sprintf("--pod-max-pids=%d", lo.FromPtr(o.KubeletConfig.PodPidsLimit)))
+	}
 	return lo.Compact(args)
 }
 
@@ -29,6 +29,8 @@ import (
 
 type Bottlerocket struct {
 	Options
+	// Configuration is the EC2NodeClass's Bottlerocket settings, which take precedence over the custom UserData
+	Configuration *v1.BottlerocketConfiguration
 }
 
 // nolint:gocyclo
@@ -84,19 +86,65 @@ func (b Bottlerocket) Script() (string, error) {
 		if b.KubeletConfig.CPUCFSQuota != nil {
 			s.Settings.Kubernetes.CPUCFSQuota = b.KubeletConfig.CPUCFSQuota
 		}
+		if b.KubeletConfig.CPUManagerPolicy != nil {
+			s.Settings.Kubernetes.CPUManagerPolicy = b.KubeletConfig.CPUManagerPolicy
+		}
+		if b.KubeletConfig.CPUManagerReconcilePeriod != nil {
+			s.Settings.Kubernetes.CPUManagerReconcilePeriod = lo.ToPtr(b.KubeletConfig.CPUManagerReconcilePeriod.Duration.String())
+		}
+		if b.KubeletConfig.TopologyManagerPolicy != nil {
+			s.Settings.Kubernetes.TopologyManagerPolicy = b.KubeletConfig.TopologyManagerPolicy
+		}
+		if b.KubeletConfig.TopologyManagerScope != nil {
+			s.Settings.Kubernetes.TopologyManagerScope = b.KubeletConfig.TopologyManagerScope
+		}
+		if b.KubeletConfig.ShutdownGracePeriod != nil {
+			s.Settings.Kubernetes.ShutdownGracePeriod = lo.ToPtr(b.KubeletConfig.ShutdownGracePeriod.Duration.String())
+		}
+		if b.KubeletConfig.ShutdownGracePeriodCriticalPods != nil {
+			s.Settings.Kubernetes.ShutdownGracePeriodForCriticalPods = lo.ToPtr(b.KubeletConfig.ShutdownGracePeriodCriticalPods.Duration.String())
+		}
+		if b.KubeletConfig.RegistryPullQPS != nil {
+			s.Settings.Kubernetes.RegistryQPS = aws.Int(int(lo.FromPtr(b.KubeletConfig.RegistryPullQPS)))
+		}
+		if b.KubeletConfig.RegistryBurst != nil {
+			s.Settings.Kubernetes.RegistryBurst = aws.Int(int(lo.FromPtr(b.KubeletConfig.RegistryBurst)))
+		}
+		if b.KubeletConfig.ContainerLogMaxSize != nil {
+			s.Settings.Kubernetes.ContainerLogMaxSize = b.KubeletConfig.ContainerLogMaxSize
+		}
+		if b.KubeletConfig.ContainerLogMaxFiles != nil {
+			s.Settings.Kubernetes.ContainerLogMaxFiles = aws.Int(int(lo.FromPtr(b.KubeletConfig.ContainerLogMaxFiles)))
+		}
+		if b.KubeletConfig.SeccompDefault != nil {
+			s.Settings.Kubernetes.SeccompDefault = b.KubeletConfig.SeccompDefault
+		}
+		if b.KubeletConfig.PodPidsLimit != nil {
+			s.Settings.Kubernetes.PodPidsLimit = aws.Int(int(lo.FromPtr(b.KubeletConfig.PodPidsLimit)))
+		}
 	}
 
+	b.applyConfiguration(&s.Settings)
+
 	s.Settings.Kubernetes.NodeTaints = map[string][]string{}
 	for _, taint := range b.Taints {
 		s.Settings.Kubernetes.NodeTaints[taint.Key] = append(s.Settings.Kubernetes.NodeTaints[taint.Key], fmt.Sprintf("%s:%s", taint.Value, taint.Effect))
 	}
 
-	if lo.FromPtr(b.InstanceStorePolicy) == v1.InstanceStorePolicyRAID0 {
+	// Bottlerocket's ephemeral storage can't select individual disks, so Mount uses every disk in the same way as RAID0
+	var dirs []string
+	switch lo.FromPtr(b.InstanceStorePolicy) {
+	case v1.InstanceStorePolicyRAID0, v1.InstanceStorePolicyMount:
+		dirs = []string{"/var/lib/containerd", "/var/lib/kubelet", "/var/log/pods"}
+	case v1.InstanceStorePolicyContainerdOnly:
+		dirs = []string{"/var/lib/containerd"}
+	}
+	if len(dirs) != 0 {
 		if s.Settings.BootstrapCommands == nil {
 			s.Settings.BootstrapCommands = map[string]BootstrapCommand{}
 		}
 		s.Settings.BootstrapCommands["000-mount-instance-storage"] = BootstrapCommand{
-			Commands:  [][]string{{"apiclient", "ephemeral-storage", "init"}, {"apiclient", "ephemeral-storage", "bind", "--dirs", "/var/lib/containerd", "/var/lib/kubelet", "/var/log/pods"}},
+			Commands:  [][]string{{"apiclient", "ephemeral-storage", "init"}, append([]string{"apiclient", "ephemeral-storage", "bind", "--dirs"}, dirs...)},
 			Essential: true,
 			Mode:      BootstrapCommandModeAlways,
 		}
@@ -107,3 +155,92 @@ func (b Bottlerocket) Script() (string, error) {
 	}
 	return base64.StdEncoding.EncodeToString(script), nil
 }
+
+// applyConfiguration sets the settings from the EC2NodeClass's Bottlerocket configuration. Settings are merged with
+// those from the custom UserData, so that the UserData can set fields which aren't part of the configuration.
+//
+//nolint:gocyclo
+func (b Bottlerocket) applyConfiguration(s *BottlerocketSettings) {
+	if b.Configuration == nil {
+		return
+	}
+	if len(b.Configuration.BootstrapContainers) != 0 && s.BootstrapContainers == nil {
+		s.BootstrapContainers = map[string]BottlerocketBootstrapContainer{}
+	}
+	for name, container := range b.Configuration.BootstrapContainers {
+		s.BootstrapContainers[name] = BottlerocketBootstrapContainer{
+			Source:    lo.ToPtr(container.Source),
+			Mode:      lo.ToPtr(string(lo.Ternary(container.Mode == "", v1.BottlerocketBootstrapModeAlways, container.Mode))),
+			Essential: container.Essential,
+			UserData:  container.UserData,
+		}
+	}
+	if len(b.Configuration.BootstrapCommands) != 0 && s.BootstrapCommands == nil {
+		s.BootstrapCommands = map[string]BootstrapCommand{}
+	}
+	for name, command := range b.Configuration.BootstrapCommands {
+		s.BootstrapCommands[name] = BootstrapCommand{
+			Commands:  command.Commands,
+			Mode:      BootstrapCommandMode(lo.Ternary(command.Mode == "", v1.BottlerocketBootstrapModeAlways, command.Mode)),
+			Essential: lo.FromPtr(command.Essential),
+		}
+	}
+	if hostContainers := b.Configuration.HostContainers; hostContainers != nil {
+		for name, container := range map[string]*v1.BottlerocketHostContainer{"admin": hostContainers.Admin, "control": hostContainers.Control} {
+			if container == nil {
+				continue
+			}
+			if s.HostContainers == nil {
+				s.HostContainers = map[string]BottlerocketHostContainer{}
+			}
+			s.HostContainers[name] = BottlerocketHostContainer{
+				Enabled:      container.Enabled,
+				Source:       container.Source,
+				Superpowered: container.Superpowered,
+				UserData:     container.UserData,
+			}
+		}
+	}
+	if kernel := b.Configuration.Kernel; kernel != nil && len(kernel.Sysctl) != 0 {
+		if s.Kernel == nil {
+			s.Kernel = &BottlerocketKernel{}
+		}
+		s.Kernel.Sysctl = lo.Assign(s.Kernel.Sysctl, kernel.Sysctl)
+	}
+	if registry := b.Configuration.ContainerRegistry; registry != nil && len(registry.Mirrors) != 0 {
+		if s.ContainerRegistry == nil {
+			s.ContainerRegistry = &BottlerocketContainerRegistry{}
+		}
+		// Mirrors from the UserData are replaced by those for the same registry
+		s.ContainerRegistry.Mirrors = lo.Reject(s.ContainerRegistry.Mirrors, func(m BottlerocketRegistryMirror, _ int) bool {
+			return lo.ContainsBy(registry.Mirrors, func(mirror v1.BottlerocketRegistryMirror) bool { return mirror.Registry == lo.FromPtr(m.Registry) })
+		})
+		for _, mirror := range registry.Mirrors {
+			s.ContainerRegistry.Mirrors = append(s.ContainerRegistry.Mirrors, BottlerocketRegistryMirror{Registry: lo.ToPtr(mirror.Registry), Endpoint: mirror.Endpoints})
+		}
+	}
+	if ntp := b.Configuration.NTP; ntp != nil {
+		if s.NTP == nil {
+			s.NTP = &BottlerocketNTP{}
+		}
+		if len(ntp.TimeServers) != 0 {
+			s.NTP.TimeServers = ntp.TimeServers
+		}
+		if len(ntp.Options) != 0 {
+			s.NTP.Options = ntp.Options
+		}
+	}
+	if ociDefaults := b.Configuration.OCIDefaults; ociDefaults != nil {
+		if s.OCIDefaults == nil {
+			s.OCIDefaults = &BottlerocketOCIDefaults{}
+		}
+		if len(ociDefaults.Capabilities) != 0 {
+			s.OCIDefaults.Capabilities = lo.Assign(s.OCIDefaults.Capabilities, ociDefaults.Capabilities)
+		}
+		if len(ociDefaults.ResourceLimits) != 0 {
+			s.OCIDefaults.ResourceLimits = lo.Assign(s.OCIDefaults.ResourceLimits, lo.MapValues(ociDefaults.ResourceLimits, func(limit v1.BottlerocketResourceLimit, _ string) BottlerocketResourceLimit {
+				return BottlerocketResourceLimit{HardLimit: lo.ToPtr(limit.HardLimit), SoftLimit: lo.ToPtr(limit.SoftLimit)}
+			}))
+		}
+	}
+}
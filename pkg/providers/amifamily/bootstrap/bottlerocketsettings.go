@@ -38,8 +38,14 @@ type BottlerocketConfig struct {
 // BottlerocketSettings is a subset of all configuration in https://github.com/bottlerocket-os/bottlerocket/blob/d427c40931cba6e6bedc5b75e9c084a6e1818db9/sources/models/src/lib.rs#L260
 // These settings apply across all K8s versions that karpenter supports.
 type BottlerocketSettings struct {
-	Kubernetes        BottlerocketKubernetes      `toml:"kubernetes"`
-	BootstrapCommands map[string]BootstrapCommand `toml:"bootstrap-commands,omitempty"`
+	Kubernetes          BottlerocketKubernetes                    `toml:"kubernetes"`
+	BootstrapCommands   map[string]BootstrapCommand               `toml:"bootstrap-commands,omitempty"`
+	BootstrapContainers map[string]BottlerocketBootstrapContainer `toml:"bootstrap-containers,omitempty"`
+	HostContainers      map[string]BottlerocketHostContainer      `toml:"host-containers,omitempty"`
+	Kernel              *BottlerocketKernel                       `toml:"kernel,omitempty"`
+	ContainerRegistry   *BottlerocketContainerRegistry            `toml:"container-registry,omitempty"`
+	NTP                 *BottlerocketNTP                          `toml:"ntp,omitempty"`
+	OCIDefaults         *BottlerocketOCIDefaults                  `toml:"oci-defaults,omitempty"`
 }
 
 // BottlerocketKubernetes is k8s specific configuration for bottlerocket api
@@ -116,6 +122,60 @@ type BootstrapCommand struct {
 	Essential bool                 `toml:"essential"`
 }
 
+// BottlerocketBootstrapContainer is a container that runs before the kubelet starts
+// See https://bottlerocket.dev/en/os/latest/api/settings/bootstrap-containers/
+type BottlerocketBootstrapContainer struct {
+	Source    *string `toml:"source,omitempty"`
+	Mode      *string `toml:"mode,omitempty"`
+	Essential *bool   `toml:"essential,omitempty"`
+	UserData  *string `toml:"user-data,omitempty"`
+}
+
+// BottlerocketHostContainer is a host container, such as the admin and control containers
+// See https://bottlerocket.dev/en/os/latest/api/settings/host-containers/
+type BottlerocketHostContainer struct {
+	Enabled      *bool   `toml:"enabled,omitempty"`
+	Source       *string `toml:"source,omitempty"`
+	Superpowered *bool   `toml:"superpowered,omitempty"`
+	UserData     *string `toml:"user-data,omitempty"`
+}
+
+// BottlerocketKernel is a subset of the kernel settings
+// See https://bottlerocket.dev/en/os/latest/api/settings/kernel/
+type BottlerocketKernel struct {
+	Sysctl map[string]string `toml:"sysctl,omitempty"`
+}
+
+// BottlerocketContainerRegistry is a subset of the container registry settings
+// See https://bottlerocket.dev/en/os/latest/api/settings/container-registry/
+type BottlerocketContainerRegistry struct {
+	Mirrors []BottlerocketRegistryMirror `toml:"mirrors,omitempty"`
+}
+
+type BottlerocketRegistryMirror struct {
+	Registry *string  `toml:"registry,omitempty"`
+	Endpoint []string `toml:"endpoint,omitempty"`
+}
+
+// BottlerocketNTP is the NTP settings
+// See https://bottlerocket.dev/en/os/latest/api/settings/ntp/
+type BottlerocketNTP struct {
+	TimeServers []string `toml:"time-servers,omitempty"`
+	Options     []string `toml:"options,omitempty"`
+}
+
+// BottlerocketOCIDefaults is the OCI defaults settings
+// See https://bottlerocket.dev/en/os/latest/api/settings/oci-defaults/
+type BottlerocketOCIDefaults struct {
+	Capabilities   map[string]bool                      `toml:"capabilities,omitempty"`
+	ResourceLimits map[string]BottlerocketResourceLimit `toml:"resource-limits,omitempty"`
+}
+
+type BottlerocketResourceLimit struct {
+	HardLimit *int64 `toml:"hard-limit,omitempty"`
+	SoftLimit *int64 `toml:"soft-limit,omitempty"`
+}
+
 func (c *BottlerocketConfig) UnmarshalTOML(data []byte) error {
 	// unmarshal known settings
 	s := struct {
@@ -140,5 +200,55 @@ func (c *BottlerocketConfig) MarshalTOML() ([]byte, error) {
 	if c.Settings.BootstrapCommands != nil {
 		c.SettingsRaw["bootstrap-commands"] = c.Settings.BootstrapCommands
 	}
+	// These settings are only modelled in part, so they're merged into the untyped settings rather than replacing them.
+	// Otherwise, any fields that aren't modelled would be dropped from the UserData.
+	for key, setting := range map[string]interface{}{
+		"bootstrap-containers": c.Settings.BootstrapContainers,
+		"host-containers":      c.Settings.HostContainers,
+		"kernel":               c.Settings.Kernel,
+		"container-registry":   c.Settings.ContainerRegistry,
+		"ntp":                  c.Settings.NTP,
+		"oci-defaults":         c.Settings.OCIDefaults,
+	} {
+		if err := c.mergeSetting(key, setting); err != nil {
+			return nil, err
+		}
+	}
 	return toml.Marshal(c)
 }
+
+// mergeSetting merges a typed setting into the untyped setting with the same key
+func (c *BottlerocketConfig) mergeSetting(key string, setting interface{}) error {
+	data, err := toml.Marshal(map[string]interface{}{key: setting})
+	if err != nil {
+		return err
+	}
+	tables := map[string]interface{}{}
+	if err = toml.Unmarshal(data, &tables); err != nil {
+		return err
+	}
+	table, ok := tables[key].(map[string]interface{})
+	if !ok || len(table) == 0 {
+		return nil
+	}
+	existing, ok := c.SettingsRaw[key].(map[string]interface{})
+	if !ok {
+		c.SettingsRaw[key] = table
+		return nil
+	}
+	mergeTables(existing, table)
+	return nil
+}
+
+// mergeTables recursively merges src into dst. Values in src take precedence, except for tables which are merged.
+func mergeTables(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if srcTable, ok := v.(map[string]interface{}); ok {
+			if dstTable, ok := dst[k].(map[string]interface{}); ok {
+				mergeTables(dstTable, srcTable)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
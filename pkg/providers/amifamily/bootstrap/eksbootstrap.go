@@ -62,6 +62,13 @@ func (e EKS) eksBootstrapScript() string {
 	var userData bytes.Buffer
 	userData.WriteString("#!/bin/bash -xe\n")
 	userData.WriteString("exec > >(tee /var/log/user-data.log|logger -t user-data -s 2>/dev/console) 2>&1\n")
+	// The disk must be mounted before bootstrap.sh starts containerd and the kubelet
+	userData.WriteString(e.instanceStoreMountScript())
+	// The kubelet doesn't have flags for graceful node shutdown, so the kubelet config file is updated before
+	// bootstrap.sh starts the kubelet
+	if patch := e.kubeletConfigPatch(); patch != "" {
+		userData.WriteString(fmt.Sprintf("echo \"$(jq '%s' /etc/kubernetes/kubelet/kubelet-config.json)\" > /etc/kubernetes/kubelet/kubelet-config.json\n", patch))
+	}
 	// Due to the way bootstrap.sh is written, parameters should not be passed to it with an equal sign
 	userData.WriteString(fmt.Sprintf("/etc/eks/bootstrap.sh '%s' --apiserver-endpoint '%s' %s", e.ClusterName, e.ClusterEndpoint, caBundleArg))
 
@@ -83,6 +90,21 @@ func (e EKS) eksBootstrapScript() string {
 	return userData.String()
 }
 
+// kubeletConfigPatch returns a jq filter that sets the kubelet config fields which can't be set by kubelet flags
+func (e EKS) kubeletConfigPatch() string {
+	if e.KubeletConfig == nil {
+		return ""
+	}
+	var filters []string
+	if e.KubeletConfig.ShutdownGracePeriod != nil {
+		filters = append(filters, fmt.Sprintf(".shutdownGracePeriod=%q", e.KubeletConfig.ShutdownGracePeriod.Duration))
+	}
+	if e.KubeletConfig.ShutdownGracePeriodCriticalPods != nil {
+		filters = append(filters, fmt.Sprintf(".shutdownGracePeriodCriticalPods=%q", e.KubeletConfig.ShutdownGracePeriodCriticalPods.Duration))
+	}
+	return strings.Join(filters, " | ")
+}
+
 func (e EKS) mergeCustomUserData(userDatas ...string) (string, error) {
 	var outputBuffer bytes.Buffer
 	writer := multipart.NewWriter(&outputBuffer)
@@ -21,6 +21,7 @@ import (
 
 	admapi "github.com/awslabs/amazon-eks-ami/nodeadm/api"
 	admv1alpha1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
+	"github.com/pelletier/go-toml/v2"
 	"github.com/samber/lo"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,6 +35,8 @@ import (
 
 type Nodeadm struct {
 	Options
+	// NodeConfig is the EC2NodeClass's nodeadm settings, which are merged into the generated NodeConfig
+	NodeConfig *v1.NodeConfigOverlay
 }
 
 func (n Nodeadm) Script() (string, error) {
@@ -45,6 +48,13 @@ func (n Nodeadm) Script() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("parsing custom UserData, %w", err)
 	}
+	// Shell scripts run before nodeadm starts containerd and the kubelet, so the disk is mounted before either uses it
+	if script := n.instanceStoreMountScript(); script != "" {
+		customEntries = append(customEntries, mime.Entry{
+			ContentType: mime.ContentTypeShellScript,
+			Content:     "#!/bin/bash -xe\n" + script,
+		})
+	}
 	mimeArchive := mime.Archive(append(customEntries, mime.Entry{
 		ContentType: mime.ContentTypeNodeConfig,
 		Content:     nodeConfigYAML,
@@ -94,6 +104,9 @@ func (n Nodeadm) getNodeConfigYAML() (string, error) {
 	if arg := n.nodeLabelArg(); arg != "" {
 		config.Spec.Kubelet.Flags = []string{arg}
 	}
+	if err := n.applyNodeConfig(config); err != nil {
+		return "", err
+	}
 
 	// Convert to YAML at the end for improved legibility.
 	configYAML, err := yaml.Marshal(config)
@@ -103,6 +116,36 @@ func (n Nodeadm) getNodeConfigYAML() (string, error) {
 	return fmt.Sprintf("# Karpenter Generated NodeConfig\n%s", string(configYAML)), nil
 }
 
+// ValidateContainerdConfig returns an error if the containerd config isn't valid TOML, since nodeadm fails to start
+// containerd with it
+func ValidateContainerdConfig(config string) error {
+	if err := toml.Unmarshal([]byte(config), lo.ToPtr(map[string]interface{}{})); err != nil {
+		return fmt.Errorf("parsing containerd config, %w", err)
+	}
+	return nil
+}
+
+// applyNodeConfig merges the EC2NodeClass's nodeadm settings into the generated NodeConfig
+func (n Nodeadm) applyNodeConfig(config *admv1alpha1.NodeConfig) error {
+	if n.NodeConfig == nil {
+		return nil
+	}
+	if c := n.NodeConfig.Containerd; c != nil && c.Config != nil {
+		// The EC2NodeClass isn't ready with an invalid config, but it's checked again so an instance is never launched with it
+		if err := ValidateContainerdConfig(*c.Config); err != nil {
+			return err
+		}
+		config.Spec.Containerd.Config = *c.Config
+	}
+	if i := n.NodeConfig.Instance; i != nil && i.LocalStorage != nil {
+		config.Spec.Instance.LocalStorage.Strategy = admv1alpha1.LocalStorageStrategy(i.LocalStorage.Strategy)
+	}
+	if k := n.NodeConfig.Kubelet; k != nil {
+		config.Spec.Kubelet.Flags = append(config.Spec.Kubelet.Flags, k.Flags...)
+	}
+	return nil
+}
+
 // generateInlineKubeletConfiguration returns a serialized form of the KubeletConfiguration specified by the Nodeadm
 // options, for use with nodeadm's NodeConfig struct.
 func (n Nodeadm) generateInlineKubeletConfiguration() (map[string]runtime.RawExtension, error) {
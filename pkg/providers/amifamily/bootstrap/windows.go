@@ -18,36 +18,132 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/samber/lo"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
 )
 
 type Windows struct {
 	Options
 }
 
+// powershellBlock matches the <powershell> sections of EC2Launch userData
+var powershellBlock = regexp.MustCompile(`(?s)<powershell>(.*?)</powershell>`)
+
+// windowsUnsupportedEvictionSignals are the eviction signals that the kubelet rejects on Windows
+var windowsUnsupportedEvictionSignals = []string{"pid.available", "nodefs.inodesFree", "imagefs.inodesFree"}
+
+// windowsInstanceStoreScriptTemplate formats the NVMe instance store disks that can be pooled, mounts the volume at
+// C:\k8s-disks\0, and replaces each of the directories with a junction onto it. Existing content is copied so that
+// anything the AMI placed in the directories is preserved. Containerd is stopped while its root is moved.
+const windowsInstanceStoreScriptTemplate = `$Disks = @(Get-PhysicalDisk -CanPool $true | Where-Object { $_.FriendlyName -like '*Amazon EC2 NVMe*' } | Sort-Object { [int]$_.DeviceId })
+if ($Disks.Count -gt 0) {
+  $MountPoint = 'C:\k8s-disks\0'
+%s  $Partition = $Disk | Initialize-Disk -PartitionStyle GPT -PassThru | New-Partition -UseMaximumSize
+  $Partition | Format-Volume -FileSystem NTFS -Confirm:$false | Out-Null
+  New-Item -ItemType Directory -Force -Path $MountPoint | Out-Null
+  $Partition | Add-PartitionAccessPath -AccessPath $MountPoint
+  Stop-Service -Name containerd -ErrorAction SilentlyContinue
+  foreach ($Dir in @(%s)) {
+    $Target = Join-Path $MountPoint $Dir.Substring(3)
+    New-Item -ItemType Directory -Force -Path $Dir, $Target | Out-Null
+    Copy-Item -Path (Join-Path $Dir '*') -Destination $Target -Recurse -Force
+    Remove-Item -Path $Dir -Recurse -Force
+    New-Item -ItemType Junction -Path $Dir -Target $Target | Out-Null
+  }
+  Start-Service -Name containerd -ErrorAction SilentlyContinue
+}
+`
+
+const (
+	// windowsStripedDiskScript stripes all of the disks into a single Storage Spaces volume
+	windowsStripedDiskScript = `  New-StoragePool -FriendlyName 'k8s-disks' -StorageSubSystemFriendlyName 'Windows Storage*' -PhysicalDisks $Disks | Out-Null
+  $Disk = New-VirtualDisk -StoragePoolFriendlyName 'k8s-disks' -FriendlyName 'k8s-disks' -ResiliencySettingName Simple -NumberOfColumns $Disks.Count -UseMaximumSize | Get-Disk
+`
+	// windowsFirstDiskScript uses the first disk only
+	windowsFirstDiskScript = `  $Disk = Get-Disk -Number $Disks[0].DeviceId
+`
+)
+
 // nolint:gocyclo
 func (w Windows) Script() (string, error) {
+	script, extra := w.customUserData()
 	var userData bytes.Buffer
 	userData.WriteString("<powershell>\n")
 
-	customUserData := lo.FromPtr(w.CustomUserData)
-	if customUserData != "" {
-		userData.WriteString(customUserData + "\n")
+	if script != "" {
+		userData.WriteString(script + "\n")
 	}
+	// The disks must be mounted before Start-EKSBootstrap.ps1 starts the kubelet
+	userData.WriteString(w.instanceStoreScript())
 
 	userData.WriteString("[string]$EKSBootstrapScriptFile = \"$env:ProgramFiles\\Amazon\\EKS\\Start-EKSBootstrap.ps1\"\n")
 	userData.WriteString(fmt.Sprintf(`& $EKSBootstrapScriptFile -EKSClusterName '%s' -APIServerEndpoint '%s'`, w.ClusterName, w.ClusterEndpoint))
 	if w.CABundle != nil {
 		userData.WriteString(fmt.Sprintf(` -Base64ClusterCA '%s'`, *w.CABundle))
 	}
-	if args := w.kubeletExtraArgs(); len(args) > 0 {
+	if args := w.windowsOptions().kubeletExtraArgs(); len(args) > 0 {
 		userData.WriteString(fmt.Sprintf(` -KubeletExtraArgs '%s'`, strings.Join(args, " ")))
 	}
 	if w.KubeletConfig != nil && len(w.KubeletConfig.ClusterDNS) > 0 {
 		userData.WriteString(fmt.Sprintf(` -DNSClusterIP '%s'`, w.KubeletConfig.ClusterDNS[0]))
 	}
 	userData.WriteString("\n</powershell>")
+	if extra != "" {
+		userData.WriteString("\n" + extra)
+	}
 	return base64.StdEncoding.EncodeToString(userData.Bytes()), nil
 }
+
+// customUserData splits the custom userData into the PowerShell script that runs before bootstrap, and the other
+// EC2Launch sections (e.g. <persist>) that are kept after the Karpenter managed <powershell> section. UserData that
+// doesn't have any <powershell> sections is treated as a PowerShell script.
+func (w Windows) customUserData() (script string, extra string) {
+	customUserData := lo.FromPtr(w.CustomUserData)
+	blocks := powershellBlock.FindAllStringSubmatch(customUserData, -1)
+	if len(blocks) == 0 {
+		return customUserData, ""
+	}
+	scripts := lo.Map(blocks, func(block []string, _ int) string { return strings.Trim(block[1], "\r\n") })
+	return strings.Join(lo.Compact(scripts), "\n"), strings.TrimSpace(powershellBlock.ReplaceAllString(customUserData, ""))
+}
+
+// instanceStoreScript returns the script that mounts the NVMe instance store disks for the InstanceStorePolicy, or an
+// empty string if the policy doesn't mount any disks
+func (w Windows) instanceStoreScript() string {
+	const allDirs = `'C:\ProgramData\containerd\root', 'C:\var\lib\kubelet', 'C:\var\log\pods'`
+	switch lo.FromPtr(w.InstanceStorePolicy) {
+	case v1.InstanceStorePolicyRAID0:
+		return fmt.Sprintf(windowsInstanceStoreScriptTemplate, windowsStripedDiskScript, allDirs)
+	case v1.InstanceStorePolicyMount:
+		return fmt.Sprintf(windowsInstanceStoreScriptTemplate, windowsFirstDiskScript, allDirs)
+	case v1.InstanceStorePolicyContainerdOnly:
+		return fmt.Sprintf(windowsInstanceStoreScriptTemplate, windowsFirstDiskScript, `'C:\ProgramData\containerd\root'`)
+	default:
+		return ""
+	}
+}
+
+// windowsOptions returns the Options without the kubelet configuration that isn't supported on Windows, so that
+// the kubelet doesn't fail to start with flags that are only valid on Linux
+func (w Windows) windowsOptions() Options {
+	if w.KubeletConfig == nil {
+		return w.Options
+	}
+	opts := w.Options
+	opts.KubeletConfig = w.KubeletConfig.DeepCopy()
+	opts.KubeletConfig.CPUCFSQuota = nil
+	opts.KubeletConfig.CPUManagerPolicy = nil
+	opts.KubeletConfig.CPUManagerReconcilePeriod = nil
+	opts.KubeletConfig.TopologyManagerPolicy = nil
+	opts.KubeletConfig.TopologyManagerScope = nil
+	opts.KubeletConfig.SeccompDefault = nil
+	opts.KubeletConfig.PodPidsLimit = nil
+	opts.KubeletConfig.EvictionHard = lo.OmitByKeys(opts.KubeletConfig.EvictionHard, windowsUnsupportedEvictionSignals)
+	opts.KubeletConfig.EvictionSoft = lo.OmitByKeys(opts.KubeletConfig.EvictionSoft, windowsUnsupportedEvictionSignals)
+	opts.KubeletConfig.EvictionSoftGracePeriod = lo.OmitByKeys(opts.KubeletConfig.EvictionSoftGracePeriod, windowsUnsupportedEvictionSignals)
+	return opts
+}
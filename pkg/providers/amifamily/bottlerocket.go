@@ -88,6 +88,7 @@ func (b Bottlerocket) UserData(kubeletConfig *v1.KubeletConfiguration, taints []
 			CustomUserData:      customUserData,
 			InstanceStorePolicy: instanceStorePolicy,
 		},
+		Configuration: b.Options.Bottlerocket,
 	}
 }
 
@@ -0,0 +1,134 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amifamily
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/types"
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/scheduling"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+)
+
+const (
+	// HealthTrackerTTL is how long the outcome of a NodeClaim is tracked for its AMI
+	HealthTrackerTTL = 24 * time.Hour
+	// ReadyTimeout is how long a NodeClaim may take to launch and become ready before it's counted as a failure for
+	// its AMIs. This is shorter than the registration TTL after which the NodeClaim is deleted, so the failure is
+	// observed.
+	ReadyTimeout = 10 * time.Minute
+)
+
+// NodeClaimOutcome is the outcome of a NodeClaim for the AMIs that it was, or would have been, launched from
+type NodeClaimOutcome struct {
+	// AMIs are the IDs of the AMIs that the outcome is attributed to
+	AMIs []string
+	// Decided is true once the NodeClaim has become ready, or has failed to within the ReadyTimeout
+	Decided bool
+	Healthy bool
+	// Time is when the outcome was decided, or when the NodeClaim fails if it hasn't become ready by then
+	Time time.Time
+}
+
+// Outcome returns the outcome of the NodeClaim, or false if it can't be attributed to an AMI. A NodeClaim fails if it
+// doesn't become ready within the ReadyTimeout of launching, or if its launch failed and it hasn't launched within the
+// ReadyTimeout of being created. Since a NodeClaim whose launch failed has no instance, its failure is attributed to
+// the AMIs that its launch templates are resolved with.
+func Outcome(nodeClass *v1.EC2NodeClass, nodeClaim *karpv1.NodeClaim, now time.Time) (NodeClaimOutcome, bool) {
+	launched := nodeClaim.StatusConditions().Get(karpv1.ConditionTypeLaunched)
+	if launched.IsTrue() {
+		if nodeClaim.Status.ImageID == "" {
+			return NodeClaimOutcome{}, false
+		}
+		if initialized := nodeClaim.StatusConditions().Get(karpv1.ConditionTypeInitialized); initialized.IsTrue() {
+			return NodeClaimOutcome{AMIs: []string{nodeClaim.Status.ImageID}, Decided: true, Healthy: true, Time: initialized.LastTransitionTime.Time}, true
+		}
+		failed := launched.LastTransitionTime.Add(ReadyTimeout)
+		return NodeClaimOutcome{AMIs: []string{nodeClaim.Status.ImageID}, Decided: !failed.After(now), Time: failed}, true
+	}
+	// The Launched condition is given the reason of the launch's error when the launch fails
+	if nodeClass == nil || launched == nil || launched.Reason == "" || launched.Reason == "AwaitingReconciliation" {
+		return NodeClaimOutcome{}, false
+	}
+	amis := launchAMIs(nodeClass, nodeClaim)
+	if len(amis) == 0 {
+		return NodeClaimOutcome{}, false
+	}
+	failed := nodeClaim.CreationTimestamp.Add(ReadyTimeout)
+	return NodeClaimOutcome{AMIs: amis, Decided: !failed.After(now), Time: failed}, true
+}
+
+// launchAMIs returns the IDs of the AMIs that the NodeClaim's launch templates are resolved with. Like
+// MapToInstanceTypes, canary launches prefer the AMIs that are being rolled out and other launches never use them.
+func launchAMIs(nodeClass *v1.EC2NodeClass, nodeClaim *karpv1.NodeClaim) []string {
+	requirements := scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaim.Spec.Requirements...)
+	canary := IsCanaryLaunch(nodeClass, nodeClaim.Name)
+	compatible := lo.Filter(nodeClass.Status.AMIs, func(ami v1.AMI, _ int) bool {
+		return ami.RolloutPhase != v1.AMIRolloutPhaseHalted && (canary || ami.RolloutPhase != v1.AMIRolloutPhaseCanary) &&
+			requirements.Intersects(scheduling.NewNodeSelectorRequirements(ami.Requirements...)) == nil
+	})
+	if canaries := lo.Filter(compatible, func(ami v1.AMI, _ int) bool { return ami.RolloutPhase == v1.AMIRolloutPhaseCanary }); len(canaries) != 0 {
+		compatible = canaries
+	}
+	return lo.Uniq(lo.Map(compatible, func(ami v1.AMI, _ int) string { return ami.ID }))
+}
+
+// HealthTracker records the outcomes of the NodeClaims launched from each AMI, so that AMIs whose nodes fail to become
+// ready can be excluded
+type HealthTracker struct {
+	outcomes *cache.Cache
+}
+
+func NewHealthTracker() *HealthTracker {
+	return &HealthTracker{
+		outcomes: cache.New(HealthTrackerTTL, time.Hour),
+	}
+}
+
+// Record records whether a NodeClaim launched from the AMI became ready. A NodeClaim that becomes ready after it was
+// recorded as failed is recorded as healthy, but a healthy NodeClaim is never recorded as failed.
+func (h *HealthTracker) Record(amiID string, nodeClaimUID types.UID, healthy bool) {
+	key := fmt.Sprintf("%s/%s", amiID, nodeClaimUID)
+	if healthy {
+		h.outcomes.SetDefault(key, true)
+		return
+	}
+	_ = h.outcomes.Add(key, false, cache.DefaultExpiration)
+}
+
+// Outcomes returns the number of NodeClaims launched from the AMI with a recorded outcome, and how many of them failed
+func (h *HealthTracker) Outcomes(amiID string) (total int, failed int) {
+	for key, item := range h.outcomes.Items() {
+		if !strings.HasPrefix(key, amiID+"/") {
+			continue
+		}
+		total++
+		if !item.Object.(bool) {
+			failed++
+		}
+	}
+	return total, failed
+}
+
+// Reset clears all recorded outcomes
+func (h *HealthTracker) Reset() {
+	h.outcomes.Flush()
+}
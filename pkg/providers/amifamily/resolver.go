@@ -25,6 +25,7 @@ import (
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
 
@@ -57,6 +58,8 @@ type Options struct {
 	InstanceProfile     string
 	CABundle            *string `hash:"ignore"`
 	InstanceStorePolicy *v1.InstanceStorePolicy
+	Bottlerocket        *v1.BottlerocketConfiguration
+	NodeConfig          *v1.NodeConfigOverlay
 	// Level-triggered fields that may change out of sync.
 	SecurityGroups           []v1.SecurityGroup
 	Tags                     map[string]string
@@ -72,12 +75,35 @@ type LaunchTemplate struct {
 	UserData              bootstrap.Bootstrapper
 	BlockDeviceMappings   []*v1.BlockDeviceMapping
 	MetadataOptions       *v1.MetadataOptions
+	CPUOptions            *v1.CPUOptions
+	EnclavesEnabled       bool
+	HibernationConfigured bool
 	AMIID                 string
 	InstanceTypes         []*cloudprovider.InstanceType `hash:"ignore"`
 	DetailedMonitoring    bool
 	EFACount              int
 	CapacityType          string
 	CapacityReservationID string
+	// Zone is only set when the launch template configures secondary network interfaces, since their subnets must be in
+	// the same zone as the instance.
+	Zone              string
+	NetworkInterfaces []NetworkInterface
+	// PlacementGroupID is the placement group resolved by the EC2NodeClass's placement group selector
+	PlacementGroupID string
+	Tenancy          v1.Tenancy
+	// HostID and HostResourceGroupARN are resolved from the EC2NodeClass's host selector when tenancy is host
+	HostID               string
+	HostResourceGroupARN string
+}
+
+// NetworkInterface holds the resolved parameters of a network interface configured on the EC2NodeClass
+type NetworkInterface struct {
+	DeviceIndex         int32
+	InterfaceType       *string
+	ENASrdSpecification *v1.ENASrdSpecification
+	// SubnetID is empty for the primary network interface, whose subnet is chosen when the instance is launched
+	SubnetID       string
+	SecurityGroups []v1.SecurityGroup
 }
 
 // AMIFamily can be implemented to override the default logic for generating dynamic launch template parameters
@@ -127,10 +153,11 @@ func (r DefaultResolver) Resolve(nodeClass *v1.EC2NodeClass, nodeClaim *karpv1.N
 	if len(nodeClass.Status.AMIs) == 0 {
 		return nil, fmt.Errorf("no amis exist given constraints")
 	}
-	mappedAMIs := MapToInstanceTypes(instanceTypes, nodeClass.Status.AMIs)
+	mappedAMIs := MapToInstanceTypes(instanceTypes, nodeClass.Status.AMIs, nodeClaim != nil && IsCanaryLaunch(nodeClass, nodeClaim.Name))
 	if len(mappedAMIs) == 0 {
 		return nil, fmt.Errorf("no instance types satisfy requirements of amis %v", lo.Uniq(lo.Map(nodeClass.Status.AMIs, func(a v1.AMI, _ int) string { return a.ID })))
 	}
+	blockDeviceMappings := BlockDeviceMappings(nodeClass, amiFamily)
 	var resolvedTemplates []*LaunchTemplate
 	for amiID, instanceTypes := range mappedAMIs {
 		// In order to support reserved ENIs for CNI custom networking setups,
@@ -141,13 +168,18 @@ func (r DefaultResolver) Resolve(nodeClass *v1.EC2NodeClass, nodeClaim *karpv1.N
 		// Reservations IDs are also included since we need to create a separate LaunchTemplate per reservation ID when
 		// launching reserved capacity. If it's a reserved capacity launch, we've already filtered the instance types
 		// further up the call stack.
+		// Block device mappings with volume size expressions resolve to different sizes depending on the instance
+		// type, so each distinct set of sizes requires a unique launch template as well.
 		type launchTemplateParams struct {
 			efaCount int
 			maxPods  int
 			// reservationIDs is encoded as a string rather than a slice to ensure this type is comparable for use by `lo.GroupBy`.
 			reservationIDs string
+			// volumeSizes is encoded as a string for the same reason, and is empty if no volume size expressions are used.
+			volumeSizes string
 		}
 		paramsToInstanceTypes := lo.GroupBy(instanceTypes, func(it *cloudprovider.InstanceType) launchTemplateParams {
+			_, volumeSizes := resolveInstanceTypeVolumeSizes(blockDeviceMappings, it)
 			return launchTemplateParams{
 				efaCount: lo.Ternary(
 					lo.Contains(lo.Keys(nodeClaim.Spec.Resources.Requests), v1.ResourceEFA),
@@ -165,12 +197,15 @@ func (r DefaultResolver) Resolve(nodeClass *v1.EC2NodeClass, nodeClaim *karpv1.N
 					}), ","),
 					"",
 				),
+				volumeSizes: volumeSizes,
 			}
 		})
 
 		for params, instanceTypes := range paramsToInstanceTypes {
 			reservationIDs := strings.Split(params.reservationIDs, ",")
-			resolvedTemplates = append(resolvedTemplates, r.resolveLaunchTemplates(nodeClass, nodeClaim, instanceTypes, capacityType, amiFamily, amiID, params.maxPods, params.efaCount, reservationIDs, options)...)
+			// Every instance type in the group resolves to the same volume sizes
+			bdms, _ := resolveInstanceTypeVolumeSizes(blockDeviceMappings, instanceTypes[0])
+			resolvedTemplates = append(resolvedTemplates, r.resolveLaunchTemplates(nodeClass, nodeClaim, instanceTypes, capacityType, amiFamily, amiID, params.maxPods, params.efaCount, reservationIDs, bdms, options)...)
 		}
 	}
 	return resolvedTemplates, nil
@@ -184,6 +219,8 @@ func GetAMIFamily(amiFamily string, options *Options) AMIFamily {
 		return &Windows{Options: options, Version: v1.Windows2019, Build: v1.Windows2019Build}
 	case v1.AMIFamilyWindows2022:
 		return &Windows{Options: options, Version: v1.Windows2022, Build: v1.Windows2022Build}
+	case v1.AMIFamilyWindows2025:
+		return &Windows{Options: options, Version: v1.Windows2025, Build: v1.Windows2025Build}
 	case v1.AMIFamilyCustom:
 		return &Custom{Options: options}
 	case v1.AMIFamilyAL2023:
@@ -229,6 +266,7 @@ func (r DefaultResolver) resolveLaunchTemplates(
 	maxPods int,
 	efaCount int,
 	capacityReservationIDs []string,
+	blockDeviceMappings []*v1.BlockDeviceMapping,
 	options *Options,
 ) []*LaunchTemplate {
 	kubeletConfig := &v1.KubeletConfiguration{}
@@ -258,7 +296,18 @@ func (r DefaultResolver) resolveLaunchTemplates(
 	if len(capacityReservationIDs) == 0 {
 		capacityReservationIDs = append(capacityReservationIDs, "")
 	}
-	return lo.Map(capacityReservationIDs, func(id string, _ int) *LaunchTemplate {
+	// Secondary network interfaces are placed in a subnet in the instance's zone, so a launch template is resolved
+	// for each zone the network interfaces can be placed in.
+	type launchTemplateParams struct {
+		capacityReservationID string
+		zone                  string
+	}
+	params := lo.FlatMap(capacityReservationIDs, func(id string, _ int) []launchTemplateParams {
+		return lo.Map(networkInterfaceZones(nodeClass, nodeClaim), func(zone string, _ int) launchTemplateParams {
+			return launchTemplateParams{capacityReservationID: id, zone: zone}
+		})
+	})
+	return lo.Map(params, func(p launchTemplateParams, _ int) *LaunchTemplate {
 		resolved := &LaunchTemplate{
 			Options: options,
 			UserData: amiFamily.UserData(
@@ -270,17 +319,23 @@ func (r DefaultResolver) resolveLaunchTemplates(
 				nodeClass.Spec.UserData,
 				options.InstanceStorePolicy,
 			),
-			BlockDeviceMappings:   nodeClass.Spec.BlockDeviceMappings,
+			BlockDeviceMappings:   blockDeviceMappings,
 			MetadataOptions:       nodeClass.Spec.MetadataOptions,
+			CPUOptions:            nodeClass.Spec.CPUOptions,
+			EnclavesEnabled:       nodeClass.EnclavesEnabled(),
+			HibernationConfigured: nodeClass.HibernationConfigured(),
 			DetailedMonitoring:    aws.ToBool(nodeClass.Spec.DetailedMonitoring),
 			AMIID:                 amiID,
 			InstanceTypes:         instanceTypes,
 			EFACount:              efaCount,
 			CapacityType:          capacityType,
-			CapacityReservationID: id,
-		}
-		if len(resolved.BlockDeviceMappings) == 0 {
-			resolved.BlockDeviceMappings = amiFamily.DefaultBlockDeviceMappings()
+			CapacityReservationID: p.capacityReservationID,
+			Zone:                  p.zone,
+			NetworkInterfaces:     resolveNetworkInterfaces(nodeClass, p.zone),
+			PlacementGroupID:      lo.FromPtr(nodeClass.Status.PlacementGroup).ID,
+			Tenancy:               nodeClass.Tenancy(),
+			HostID:                lo.FromPtr(nodeClass.Spec.HostSelector).ID,
+			HostResourceGroupARN:  lo.FromPtr(nodeClass.Spec.HostSelector).ResourceGroupARN,
 		}
 		if resolved.MetadataOptions == nil {
 			resolved.MetadataOptions = amiFamily.DefaultMetadataOptions()
@@ -288,3 +343,38 @@ func (r DefaultResolver) resolveLaunchTemplates(
 		return resolved
 	})
 }
+
+// networkInterfaceZones returns the zones that launch templates should be resolved for. If no secondary network
+// interfaces are configured, a single empty zone is returned since the launch template can be used in any zone.
+// Otherwise, the zones are those compatible with the NodeClaim in which every secondary network interface has a subnet.
+func networkInterfaceZones(nodeClass *v1.EC2NodeClass, nodeClaim *karpv1.NodeClaim) []string {
+	if !lo.ContainsBy(nodeClass.Spec.NetworkInterfaces, func(ni v1.NetworkInterface) bool { return ni.DeviceIndex != 0 }) {
+		return []string{""}
+	}
+	zoneRequirement := scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaim.Spec.Requirements...).Get(corev1.LabelTopologyZone)
+	zones := sets.New(lo.Map(nodeClass.Status.Subnets, func(s v1.Subnet, _ int) string { return s.Zone })...)
+	for _, ni := range nodeClass.Spec.NetworkInterfaces {
+		if ni.DeviceIndex == 0 {
+			continue
+		}
+		status, _ := lo.Find(nodeClass.Status.NetworkInterfaces, func(s v1.NetworkInterfaceStatus) bool { return s.DeviceIndex == ni.DeviceIndex })
+		zones = zones.Intersection(sets.New(lo.Map(status.Subnets, func(s v1.Subnet, _ int) string { return s.Zone })...))
+	}
+	return lo.Filter(sets.List(zones), func(zone string, _ int) bool { return zoneRequirement.Has(zone) })
+}
+
+// resolveNetworkInterfaces resolves the network interfaces configured on the EC2NodeClass for instances launched in the
+// zone. Secondary network interfaces are placed in the first of their subnets in the zone.
+func resolveNetworkInterfaces(nodeClass *v1.EC2NodeClass, zone string) []NetworkInterface {
+	return lo.Map(nodeClass.Spec.NetworkInterfaces, func(ni v1.NetworkInterface, _ int) NetworkInterface {
+		status, _ := lo.Find(nodeClass.Status.NetworkInterfaces, func(s v1.NetworkInterfaceStatus) bool { return s.DeviceIndex == ni.DeviceIndex })
+		subnet, _ := lo.Find(status.Subnets, func(s v1.Subnet) bool { return s.Zone == zone })
+		return NetworkInterface{
+			DeviceIndex:         ni.DeviceIndex,
+			InterfaceType:       ni.InterfaceType,
+			ENASrdSpecification: ni.ENASrdSpecification,
+			SubnetID:            subnet.ID,
+			SecurityGroups:      status.SecurityGroups,
+		}
+	})
+}
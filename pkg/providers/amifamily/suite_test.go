@@ -34,8 +34,10 @@ import (
 
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
 	coreoptions "sigs.k8s.io/karpenter/pkg/operator/options"
 	"sigs.k8s.io/karpenter/pkg/scheduling"
 	coretest "sigs.k8s.io/karpenter/pkg/test"
@@ -189,6 +191,15 @@ var _ = Describe("AMIProvider", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(amis).To(HaveLen(1))
 	})
+	It("should succeed to resolve AMIs (Windows2025)", func() {
+		nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "windows2025@latest"}}
+		awsEnv.SSMAPI.Parameters = map[string]string{
+			fmt.Sprintf("/aws/service/ami-windows-latest/Windows_Server-2025-English-Core-EKS_Optimized-%s/image_id", version): amd64AMI,
+		}
+		amis, err := awsEnv.AMIProvider.List(ctx, nodeClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(amis).To(HaveLen(1))
+	})
 
 	It("should not cause data races when calling Get() simultaneously", func() {
 		nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{
@@ -348,6 +359,23 @@ var _ = Describe("AMIProvider", func() {
 				),
 			}))
 		})
+		It("should add the selector term's requirements to the AMI requirements", func() {
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{
+				{
+					Tags: map[string]string{"*": "*"},
+					Requirements: []corev1.NodeSelectorRequirement{
+						{Key: v1.LabelInstanceGPUManufacturer, Operator: corev1.NodeSelectorOpIn, Values: []string{"amd"}},
+					},
+				},
+			}
+			amis, err := awsEnv.AMIProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(amis).To(HaveLen(1))
+			Expect(amis[0].Requirements).To(Equal(scheduling.NewRequirements(
+				scheduling.NewRequirement(corev1.LabelArchStable, corev1.NodeSelectorOpIn, karpv1.ArchitectureAmd64),
+				scheduling.NewRequirement(v1.LabelInstanceGPUManufacturer, corev1.NodeSelectorOpIn, "amd"),
+			)))
+		})
 	})
 	Context("AMI List requirements", func() {
 		BeforeEach(func() {
@@ -436,10 +464,11 @@ var _ = Describe("AMIProvider", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(amis).To(HaveLen(1))
 			Expect(amis).To(ConsistOf(amifamily.AMI{
-				Name:         amd64AMI,
-				AmiID:        "ami-1234",
-				CreationDate: "2021-08-31T00:12:42.000Z",
-				Deprecated:   false,
+				Name:            amd64AMI,
+				AmiID:           "ami-1234",
+				CreationDate:    "2021-08-31T00:12:42.000Z",
+				DeprecationTime: awsEnv.Clock.Now().Add(10 * time.Minute).Format(time.RFC3339),
+				Deprecated:      false,
 				Requirements: scheduling.NewRequirements(
 					scheduling.NewRequirement(corev1.LabelArchStable, corev1.NodeSelectorOpIn, karpv1.ArchitectureAmd64),
 				),
@@ -480,10 +509,11 @@ var _ = Describe("AMIProvider", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(amis).To(HaveLen(1))
 			Expect(amis).To(ConsistOf(amifamily.AMI{
-				Name:         "test-ami-1",
-				AmiID:        "ami-1234",
-				CreationDate: "2021-08-31T00:12:42.000Z",
-				Deprecated:   false,
+				Name:            "test-ami-1",
+				AmiID:           "ami-1234",
+				CreationDate:    "2021-08-31T00:12:42.000Z",
+				DeprecationTime: awsEnv.Clock.Now().Add(10 * time.Minute).Format(time.RFC3339),
+				Deprecated:      false,
 				Requirements: scheduling.NewRequirements(
 					scheduling.NewRequirement(corev1.LabelArchStable, corev1.NodeSelectorOpIn, karpv1.ArchitectureAmd64),
 				),
@@ -523,15 +553,52 @@ var _ = Describe("AMIProvider", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(amis).To(HaveLen(1))
 			Expect(amis).To(ConsistOf(amifamily.AMI{
-				Name:         amd64AMI,
-				AmiID:        "ami-5678",
-				CreationDate: "2021-08-31T00:12:42.000Z",
-				Deprecated:   true,
+				Name:            amd64AMI,
+				AmiID:           "ami-5678",
+				CreationDate:    "2021-08-31T00:12:42.000Z",
+				DeprecationTime: awsEnv.Clock.Now().Add(-1 * time.Hour).Format(time.RFC3339),
+				Deprecated:      true,
 				Requirements: scheduling.NewRequirements(
 					scheduling.NewRequirement(corev1.LabelArchStable, corev1.NodeSelectorOpIn, karpv1.ArchitectureAmd64),
 				),
 			}))
 		})
+		Context("Age", func() {
+			BeforeEach(func() {
+				awsEnv.EC2API.DescribeImagesOutput.Set(&ec2.DescribeImagesOutput{
+					Images: lo.Map([]time.Duration{time.Hour, 3 * 24 * time.Hour, 10 * 24 * time.Hour}, func(age time.Duration, i int) ec2types.Image {
+						return ec2types.Image{
+							Name:         aws.String(amd64AMI),
+							ImageId:      aws.String(fmt.Sprintf("ami-%d", i)),
+							CreationDate: aws.String(awsEnv.Clock.Now().Add(-age).Format(time.RFC3339)),
+							Architecture: "x86_64",
+							State:        ec2types.ImageStateAvailable,
+						}
+					}),
+				})
+			})
+			It("should select the newest ami that's older than the min age", func() {
+				nodeClass.Spec.AMISelectorTerms[0].MinAge = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+				amis, err := awsEnv.AMIProvider.List(ctx, nodeClass)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(amis).To(HaveLen(1))
+				Expect(amis[0].AmiID).To(Equal("ami-2"))
+			})
+			It("should select the newest ami that's younger than the max age", func() {
+				nodeClass.Spec.AMISelectorTerms[0].MinAge = &metav1.Duration{Duration: 2 * time.Hour}
+				nodeClass.Spec.AMISelectorTerms[0].MaxAge = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+				amis, err := awsEnv.AMIProvider.List(ctx, nodeClass)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(amis).To(HaveLen(1))
+				Expect(amis[0].AmiID).To(Equal("ami-1"))
+			})
+			It("should not select any amis when none satisfy the age constraints", func() {
+				nodeClass.Spec.AMISelectorTerms[0].MinAge = &metav1.Duration{Duration: 30 * 24 * time.Hour}
+				amis, err := awsEnv.AMIProvider.List(ctx, nodeClass)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(amis).To(BeEmpty())
+			})
+		})
 	})
 	Context("AMI Selectors", func() {
 		// When you tag public or shared resources, the tags you assign are available only to your AWS account; no other AWS account will have access to those tags
@@ -608,6 +675,56 @@ var _ = Describe("AMIProvider", func() {
 				},
 			}, queries)
 		})
+		It("should query the source region for replicated terms", func() {
+			queries, err := awsEnv.AMIProvider.DescribeImageQueries(ctx, &v1.EC2NodeClass{
+				Spec: v1.EC2NodeClassSpec{
+					AMISelectorTerms: []v1.AMISelectorTerm{
+						{
+							ID: "ami-abcd1234",
+						},
+						{
+							ID:          "ami-cafeaced",
+							Replication: &v1.AMIReplication{SourceRegion: "us-east-1"},
+						},
+						{
+							Name:        "my-ami",
+							Owner:       "123456789012",
+							Replication: &v1.AMIReplication{SourceRegion: "eu-west-1"},
+						},
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			ExpectConsistsOfAMIQueries([]amifamily.DescribeImageQuery{
+				{
+					Filters: []ec2types.Filter{
+						{
+							Name:   lo.ToPtr("image-id"),
+							Values: []string{"ami-abcd1234"},
+						},
+					},
+				},
+				{
+					Filters: []ec2types.Filter{
+						{
+							Name:   lo.ToPtr("image-id"),
+							Values: []string{"ami-cafeaced"},
+						},
+					},
+					SourceRegion: "us-east-1",
+				},
+				{
+					Filters: []ec2types.Filter{
+						{
+							Name:   lo.ToPtr("name"),
+							Values: []string{"my-ami"},
+						},
+					},
+					Owners:       []string{"123456789012"},
+					SourceRegion: "eu-west-1",
+				},
+			}, queries)
+		})
 		It("should allow only specifying owners", func() {
 			queries, err := awsEnv.AMIProvider.DescribeImageQueries(ctx, &v1.EC2NodeClass{
 				Spec: v1.EC2NodeClassSpec{
@@ -898,6 +1015,97 @@ var _ = Describe("AMIProvider", func() {
 	})
 })
 
+var _ = Describe("AMI Rollout", func() {
+	var instanceTypes []*cloudprovider.InstanceType
+	BeforeEach(func() {
+		instanceTypes = []*cloudprovider.InstanceType{{
+			Name:         "m5.large",
+			Requirements: scheduling.NewRequirements(scheduling.NewRequirement(corev1.LabelArchStable, corev1.NodeSelectorOpIn, karpv1.ArchitectureAmd64)),
+		}}
+		nodeClass = test.EC2NodeClass()
+		nodeClass.Spec.AMIRolloutPolicy = &v1.AMIRolloutPolicy{CanaryPercentage: 50, BakeTime: metav1.Duration{Duration: time.Hour}}
+		nodeClass.Status.AMIs = []v1.AMI{
+			{ID: "ami-canary", RolloutPhase: v1.AMIRolloutPhaseCanary},
+			{ID: "ami-stable", RolloutPhase: v1.AMIRolloutPhaseStable},
+			{ID: "ami-halted", RolloutPhase: v1.AMIRolloutPhaseHalted},
+		}
+	})
+	It("should map stable AMIs for launches that aren't canaries", func() {
+		Expect(lo.Keys(amifamily.MapToInstanceTypes(instanceTypes, nodeClass.Status.AMIs, false))).To(ConsistOf("ami-stable"))
+	})
+	It("should prefer canary AMIs for canary launches", func() {
+		Expect(lo.Keys(amifamily.MapToInstanceTypes(instanceTypes, nodeClass.Status.AMIs, true))).To(ConsistOf("ami-canary"))
+	})
+	It("should never map halted AMIs", func() {
+		nodeClass.Status.AMIs = nodeClass.Status.AMIs[2:]
+		Expect(amifamily.MapToInstanceTypes(instanceTypes, nodeClass.Status.AMIs, false)).To(BeEmpty())
+		Expect(amifamily.MapToInstanceTypes(instanceTypes, nodeClass.Status.AMIs, true)).To(BeEmpty())
+	})
+	It("should select roughly the canary percentage of launches", func() {
+		canaries := lo.CountBy(lo.Range(1000), func(i int) bool {
+			return amifamily.IsCanaryLaunch(nodeClass, fmt.Sprintf("default-%d", i))
+		})
+		Expect(canaries).To(BeNumerically("~", 500, 100))
+	})
+	It("should always select the same NodeClaim", func() {
+		Expect(amifamily.IsCanaryLaunch(nodeClass, "default-abcde")).To(Equal(amifamily.IsCanaryLaunch(nodeClass, "default-abcde")))
+	})
+	It("should not select canaries when there are no canary AMIs", func() {
+		nodeClass.Status.AMIs = nodeClass.Status.AMIs[1:]
+		Expect(lo.ContainsBy(lo.Range(100), func(i int) bool {
+			return amifamily.IsCanaryLaunch(nodeClass, fmt.Sprintf("default-%d", i))
+		})).To(BeFalse())
+	})
+})
+
+var _ = Describe("AMI Variants", func() {
+	instanceType := func(name string, reqs ...*scheduling.Requirement) *cloudprovider.InstanceType {
+		return &cloudprovider.InstanceType{
+			Name:         name,
+			Requirements: scheduling.NewRequirements(append(reqs, scheduling.NewRequirement(corev1.LabelArchStable, corev1.NodeSelectorOpIn, karpv1.ArchitectureAmd64))...),
+		}
+	}
+	gpuInstanceType := func(name, manufacturer string) *cloudprovider.InstanceType {
+		return instanceType(name,
+			scheduling.NewRequirement(v1.LabelInstanceGPUCount, corev1.NodeSelectorOpIn, "1"),
+			scheduling.NewRequirement(v1.LabelInstanceGPUManufacturer, corev1.NodeSelectorOpIn, manufacturer),
+		)
+	}
+	ami := func(id string, variant amifamily.Variant) v1.AMI {
+		return v1.AMI{ID: id, Requirements: lo.Map(variant.Requirements().NodeSelectorRequirements(), func(r karpv1.NodeSelectorRequirementWithMinValues, _ int) corev1.NodeSelectorRequirement {
+			return r.NodeSelectorRequirement
+		})}
+	}
+	It("should only map instance types with NVIDIA GPUs to the NVIDIA AMI", func() {
+		instanceTypes := []*cloudprovider.InstanceType{
+			instanceType("m5.large",
+				scheduling.NewRequirement(v1.LabelInstanceGPUCount, corev1.NodeSelectorOpDoesNotExist),
+				scheduling.NewRequirement(v1.LabelInstanceAcceleratorCount, corev1.NodeSelectorOpDoesNotExist),
+			),
+			gpuInstanceType("g5.xlarge", "nvidia"),
+			gpuInstanceType("g4ad.xlarge", "amd"),
+			gpuInstanceType("dl1.24xlarge", "habana"),
+		}
+		amis := amifamily.MapToInstanceTypes(instanceTypes, []v1.AMI{
+			ami("ami-nvidia", amifamily.VariantNvidia),
+			ami("ami-standard", amifamily.VariantStandard),
+		}, false)
+		names := lo.MapValues(amis, func(its []*cloudprovider.InstanceType, _ string) []string {
+			return lo.Map(its, func(it *cloudprovider.InstanceType, _ int) string { return it.Name })
+		})
+		Expect(names).To(Equal(map[string][]string{
+			"ami-standard": {"m5.large"},
+			"ami-nvidia":   {"g5.xlarge"},
+		}))
+	})
+	It("should not map GPU instance types without an AMI for their GPU manufacturer", func() {
+		amis := amifamily.MapToInstanceTypes([]*cloudprovider.InstanceType{gpuInstanceType("g4ad.xlarge", "amd")}, []v1.AMI{
+			ami("ami-nvidia", amifamily.VariantNvidia),
+		}, false)
+		Expect(amis).To(BeEmpty())
+	})
+})
+
 func ExpectConsistsOfAMIQueries(expected, actual []amifamily.DescribeImageQuery) {
 	GinkgoHelper()
 	Expect(actual).To(HaveLen(len(expected)))
@@ -33,11 +33,14 @@ import (
 )
 
 type AMI struct {
-	Name         string
-	AmiID        string
-	CreationDate string
-	Deprecated   bool
-	Requirements scheduling.Requirements
+	Name            string
+	AmiID           string
+	CreationDate    string
+	DeprecationTime string
+	Deprecated      bool
+	Requirements    scheduling.Requirements
+	// SourceRegion is set for AMIs that need to be copied into the cluster's account and region before they're used
+	SourceRegion string
 }
 
 type AMIs []AMI
@@ -89,8 +92,12 @@ func (v Variant) Requirements() scheduling.Requirements {
 			scheduling.NewRequirement(v1.LabelInstanceAcceleratorCount, corev1.NodeSelectorOpDoesNotExist),
 			scheduling.NewRequirement(v1.LabelInstanceGPUCount, corev1.NodeSelectorOpDoesNotExist),
 		)
+	// Instances with AMD or Habana GPUs need different drivers, so they aren't mapped to the NVIDIA variant
 	case VariantNvidia:
-		return scheduling.NewRequirements(scheduling.NewRequirement(v1.LabelInstanceGPUCount, corev1.NodeSelectorOpExists))
+		return scheduling.NewRequirements(
+			scheduling.NewRequirement(v1.LabelInstanceGPUCount, corev1.NodeSelectorOpExists),
+			scheduling.NewRequirement(v1.LabelInstanceGPUManufacturer, corev1.NodeSelectorOpIn, string(v)),
+		)
 	case VariantNeuron:
 		return scheduling.NewRequirements(scheduling.NewRequirement(v1.LabelInstanceAcceleratorCount, corev1.NodeSelectorOpExists))
 	}
@@ -105,6 +112,20 @@ type DescribeImageQuery struct {
 	// Sometimes, an image may have multiple sets of known requirements. For example, the AL2 GPU AMI is compatible with both Neuron and Nvidia GPU
 	// instances, which means we need a set of requirements for either instance type.
 	KnownRequirements map[string][]scheduling.Requirements
+	// MinAge and MaxAge constrain the creation date of the images that are discovered, if non-zero
+	MinAge time.Duration
+	MaxAge time.Duration
+	// SourceRegion is the region that images are discovered in for replication. Images are discovered in the cluster's
+	// region if it's empty.
+	SourceRegion string
+	// Requirements are added to the requirements of every image that's discovered. These are set by selector terms to
+	// map images to the accelerators that they support.
+	Requirements []corev1.NodeSelectorRequirement
+}
+
+// MatchesAge returns true if an image of the given age satisfies the query's age constraints
+func (q DescribeImageQuery) MatchesAge(age time.Duration) bool {
+	return (q.MinAge == 0 || age >= q.MinAge) && (q.MaxAge == 0 || age <= q.MaxAge)
 }
 
 func (q DescribeImageQuery) DescribeImagesInput() *ec2.DescribeImagesInput {
@@ -123,8 +144,12 @@ func (q DescribeImageQuery) RequirementsForImageWithArchitecture(image string, a
 	if knownRequirements, ok := q.KnownRequirements[image]; ok {
 		return lo.Map(knownRequirements, func(r scheduling.Requirements, _ int) scheduling.Requirements {
 			r.Add(scheduling.NewRequirement(corev1.LabelArchStable, corev1.NodeSelectorOpIn, arch))
+			r.Add(scheduling.NewNodeSelectorRequirements(q.Requirements...).Values()...)
 			return r
 		})
 	}
-	return []scheduling.Requirements{scheduling.NewRequirements(scheduling.NewRequirement(corev1.LabelArchStable, corev1.NodeSelectorOpIn, arch))}
+	return []scheduling.Requirements{scheduling.NewRequirements(append(
+		scheduling.NewNodeSelectorRequirements(q.Requirements...).Values(),
+		scheduling.NewRequirement(corev1.LabelArchStable, corev1.NodeSelectorOpIn, arch),
+	)...)}
 }
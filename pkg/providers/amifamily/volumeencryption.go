@@ -0,0 +1,55 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amifamily
+
+import (
+	"github.com/samber/lo"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+)
+
+// BlockDeviceMappings returns the block device mappings that instances launched with the EC2NodeClass are configured
+// with, falling back to the AMI family's default mappings when none are specified. If the EC2NodeClass has a
+// VolumeEncryptionPolicy in Override mode, every EBS mapping is encrypted with the policy's KMS key.
+func BlockDeviceMappings(nodeClass *v1.EC2NodeClass, amiFamily AMIFamily) []*v1.BlockDeviceMapping {
+	bdms := nodeClass.Spec.BlockDeviceMappings
+	if len(bdms) == 0 {
+		bdms = amiFamily.DefaultBlockDeviceMappings()
+	}
+	if nodeClass.Spec.VolumeEncryptionPolicy == nil || nodeClass.VolumeEncryptionPolicyMode() != v1.VolumeEncryptionPolicyModeOverride {
+		return bdms
+	}
+	kmsKeyID := nodeClass.Spec.VolumeEncryptionPolicy.KMSKeyID
+	return lo.Map(bdms, func(bdm *v1.BlockDeviceMapping, _ int) *v1.BlockDeviceMapping {
+		// Default mappings share their EBS configuration, so we copy the mapping rather than mutating it
+		encrypted := bdm.DeepCopy()
+		if encrypted.EBS != nil {
+			encrypted.EBS.Encrypted = lo.ToPtr(true)
+			encrypted.EBS.KMSKeyID = lo.ToPtr(kmsKeyID)
+		}
+		return encrypted
+	})
+}
+
+// NonCompliantBlockDeviceMappings returns the device names of the EBS block device mappings that aren't encrypted with
+// the KMS key required by the VolumeEncryptionPolicy
+func NonCompliantBlockDeviceMappings(policy *v1.VolumeEncryptionPolicy, bdms []*v1.BlockDeviceMapping) []string {
+	return lo.FilterMap(bdms, func(bdm *v1.BlockDeviceMapping, _ int) (string, bool) {
+		if bdm.EBS == nil {
+			return "", false
+		}
+		return lo.FromPtr(bdm.DeviceName), !lo.FromPtr(bdm.EBS.Encrypted) || lo.FromPtr(bdm.EBS.KMSKeyID) != policy.KMSKeyID
+	})
+}
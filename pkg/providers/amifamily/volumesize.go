@@ -0,0 +1,66 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amifamily
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+)
+
+// ResolveVolumeSizes returns the block device mappings with each VolumeSizeExpression resolved to a fixed VolumeSize
+// for an instance type with the given number of vCPUs and memory in MiB. Mappings without an expression are returned
+// as-is.
+func ResolveVolumeSizes(bdms []*v1.BlockDeviceMapping, vcpus int64, memoryMiB int64) []*v1.BlockDeviceMapping {
+	if !lo.ContainsBy(bdms, hasVolumeSizeExpression) {
+		return bdms
+	}
+	return lo.Map(bdms, func(bdm *v1.BlockDeviceMapping, _ int) *v1.BlockDeviceMapping {
+		if !hasVolumeSizeExpression(bdm) {
+			return bdm
+		}
+		resolved := bdm.DeepCopy()
+		resolved.EBS.VolumeSize = bdm.EBS.VolumeSizeExpression.VolumeSize(vcpus, memoryMiB)
+		resolved.EBS.VolumeSizeExpression = nil
+		return resolved
+	})
+}
+
+// resolveInstanceTypeVolumeSizes resolves the block device mappings for the instance type, using the vCPU and memory
+// labels so that the sizes match those used to compute the instance type's ephemeral-storage capacity. The returned
+// key is empty if no mapping has an expression, and otherwise identifies the resolved sizes.
+func resolveInstanceTypeVolumeSizes(bdms []*v1.BlockDeviceMapping, instanceType *cloudprovider.InstanceType) ([]*v1.BlockDeviceMapping, string) {
+	if !lo.ContainsBy(bdms, hasVolumeSizeExpression) {
+		return bdms, ""
+	}
+	vcpus, _ := strconv.ParseInt(instanceType.Requirements.Get(v1.LabelInstanceCPU).Any(), 10, 64)
+	memoryMiB, _ := strconv.ParseInt(instanceType.Requirements.Get(v1.LabelInstanceMemory).Any(), 10, 64)
+	resolved := ResolveVolumeSizes(bdms, vcpus, memoryMiB)
+	return resolved, strings.Join(lo.Map(resolved, func(bdm *v1.BlockDeviceMapping, _ int) string {
+		if bdm.EBS == nil || bdm.EBS.VolumeSize == nil {
+			return ""
+		}
+		return bdm.EBS.VolumeSize.String()
+	}), ",")
+}
+
+func hasVolumeSizeExpression(bdm *v1.BlockDeviceMapping) bool {
+	return bdm.EBS != nil && bdm.EBS.VolumeSizeExpression != nil
+}
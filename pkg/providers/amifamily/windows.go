@@ -40,7 +40,7 @@ import (
 type Windows struct {
 	DefaultFamily
 	*Options
-	// Version is the major version of Windows Server (2019 or 2022).
+	// Version is the major version of Windows Server (2019, 2022 or 2025).
 	// Only the core version of each version is supported by Karpenter, so this field only indicates the year.
 	Version string
 	// Build is a specific build code associated with the Version
@@ -70,16 +70,17 @@ func (w Windows) DescribeImageQuery(ctx context.Context, ssmProvider ssm.Provide
 }
 
 // UserData returns the default userdata script for the AMI Family
-func (w Windows) UserData(kubeletConfig *v1.KubeletConfiguration, taints []corev1.Taint, labels map[string]string, caBundle *string, _ []*cloudprovider.InstanceType, customUserData *string, _ *v1.InstanceStorePolicy) bootstrap.Bootstrapper {
+func (w Windows) UserData(kubeletConfig *v1.KubeletConfiguration, taints []corev1.Taint, labels map[string]string, caBundle *string, _ []*cloudprovider.InstanceType, customUserData *string, instanceStorePolicy *v1.InstanceStorePolicy) bootstrap.Bootstrapper {
 	return bootstrap.Windows{
 		Options: bootstrap.Options{
-			ClusterName:     w.Options.ClusterName,
-			ClusterEndpoint: w.Options.ClusterEndpoint,
-			KubeletConfig:   kubeletConfig,
-			Taints:          taints,
-			Labels:          labels,
-			CABundle:        caBundle,
-			CustomUserData:  customUserData,
+			ClusterName:         w.Options.ClusterName,
+			ClusterEndpoint:     w.Options.ClusterEndpoint,
+			KubeletConfig:       kubeletConfig,
+			Taints:              taints,
+			Labels:              labels,
+			CABundle:            caBundle,
+			CustomUserData:      customUserData,
+			InstanceStorePolicy: instanceStorePolicy,
 		},
 	}
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	gocache "github.com/patrickmn/go-cache"
 	"github.com/samber/lo"
 	"go.uber.org/multierr"
 	corev1 "k8s.io/api/core/v1"
@@ -58,6 +59,9 @@ const (
 	instanceTypeFlexibilityThreshold = 5
 	// The maximum number of instance types to include in a Create request
 	maxInstanceTypes = 60
+	// The maximum number of overrides across the launch template configs of a CreateFleet request when adding overrides
+	// for additional subnets in a zone. Overrides for the preferred subnet in each zone are always included.
+	maxOverrides = 300
 )
 
 var (
@@ -79,6 +83,9 @@ type Provider interface {
 	List(context.Context) ([]*Instance, error)
 	Delete(context.Context, string) error
 	CreateTags(context.Context, string, map[string]string) error
+	DeleteTags(context.Context, string, []string) error
+	Stop(context.Context, string, bool) error
+	ListWarmPool(context.Context) ([]*Instance, error)
 }
 
 type DefaultProvider struct {
@@ -90,6 +97,7 @@ type DefaultProvider struct {
 	launchTemplateProvider      launchtemplate.Provider
 	ec2Batcher                  *batcher.EC2API
 	capacityReservationProvider capacityreservation.Provider
+	warmPoolClaims              *gocache.Cache
 }
 
 func NewDefaultProvider(
@@ -111,6 +119,7 @@ func NewDefaultProvider(
 		launchTemplateProvider:      launchTemplateProvider,
 		ec2Batcher:                  batcher.EC2(ctx, ec2api),
 		capacityReservationProvider: capacityReservationProvider,
+		warmPoolClaims:              gocache.New(warmPoolClaimTTL, cache.DefaultCleanupInterval),
 	}
 }
 
@@ -120,6 +129,15 @@ func (p *DefaultProvider) Create(ctx context.Context, nodeClass *v1.EC2NodeClass
 		return nil, err
 	}
 	capacityType := getCapacityType(nodeClaim, instanceTypes)
+	if IsWarmPoolCandidate(nodeClass, nodeClaim, tags, capacityType) {
+		instance, err := p.claimWarmPoolInstance(ctx, nodeClass, nodeClaim, tags, instanceTypes)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed claiming warm pool instance, launching a new instance")
+		}
+		if instance != nil {
+			return instance, nil
+		}
+	}
 	fleetInstance, err := p.launchInstance(ctx, nodeClass, nodeClaim, capacityType, instanceTypes, tags)
 	if awserrors.IsLaunchTemplateNotFound(err) {
 		// retry once if launch template is not found. This allows karpenter to generate a new LT if the
@@ -144,6 +162,7 @@ func (p *DefaultProvider) Create(ctx context.Context, nodeClass *v1.EC2NodeClass
 		capacityType,
 		capacityReservation,
 		lo.Contains(lo.Keys(nodeClaim.Spec.Resources.Requests), v1.ResourceEFA),
+		string(nodeClass.Tenancy()),
 	), nil
 }
 
@@ -295,7 +314,7 @@ func (p *DefaultProvider) launchInstance(
 	}
 
 	createFleetOutput, err := p.ec2Batcher.CreateFleet(ctx, createFleetInput)
-	p.subnetProvider.UpdateInflightIPs(createFleetInput, createFleetOutput, instanceTypes, lo.Values(zonalSubnets), capacityType)
+	p.subnetProvider.UpdateInflightIPs(ctx, createFleetInput, createFleetOutput, instanceTypes, lo.Flatten(lo.Values(zonalSubnets)), capacityType)
 	if err != nil {
 		reason, message := awserrors.ToReasonMessage(err)
 		if awserrors.IsLaunchTemplateNotFound(err) {
@@ -306,7 +325,7 @@ func (p *DefaultProvider) launchInstance(
 		}
 		return ec2types.CreateFleetInstance{}, cloudprovider.NewCreateError(fmt.Errorf("creating fleet request, %w", err), reason, fmt.Sprintf("Error creating fleet request: %s", message))
 	}
-	p.updateUnavailableOfferingsCache(ctx, createFleetOutput.Errors, capacityType, nodeClaim, instanceTypes)
+	p.updateUnavailableOfferingsCache(ctx, filterSubnetAddressErrors(createFleetOutput.Errors, launchTemplateConfigs), capacityType, nodeClaim, instanceTypes)
 	if len(createFleetOutput.Instances) == 0 || len(createFleetOutput.Instances[0].InstanceIds) == 0 {
 		requestID, _ := awsmiddleware.GetRequestIDMetadata(createFleetOutput.ResultMetadata)
 		return ec2types.CreateFleetInstance{}, serrors.Wrap(
@@ -336,7 +355,7 @@ func GetCreateFleetInput(nodeClass *v1.EC2NodeClass, capacityType string, tags m
 		},
 		TagSpecifications: []ec2types.TagSpecification{
 			{ResourceType: ec2types.ResourceTypeInstance, Tags: utils.EC2MergeTags(tags)},
-			{ResourceType: ec2types.ResourceTypeVolume, Tags: utils.EC2MergeTags(tags)},
+			{ResourceType: ec2types.ResourceTypeVolume, Tags: utils.EC2MergeTags(lo.Assign(tags, nodeClass.Spec.VolumeTags))},
 			{ResourceType: ec2types.ResourceTypeFleet, Tags: utils.EC2MergeTags(tags)},
 		},
 	}
@@ -367,7 +386,7 @@ func (p *DefaultProvider) getLaunchTemplateConfigs(
 	nodeClass *v1.EC2NodeClass,
 	nodeClaim *karpv1.NodeClaim,
 	instanceTypes []*cloudprovider.InstanceType,
-	zonalSubnets map[string]*subnet.Subnet,
+	zonalSubnets map[string][]*subnet.Subnet,
 	capacityType string,
 	tags map[string]string,
 ) ([]ec2types.FleetLaunchTemplateConfigRequest, error) {
@@ -378,9 +397,18 @@ func (p *DefaultProvider) getLaunchTemplateConfigs(
 	}
 	requirements := scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaim.Spec.Requirements...)
 	requirements[karpv1.CapacityTypeLabelKey] = scheduling.NewRequirement(karpv1.CapacityTypeLabelKey, corev1.NodeSelectorOpIn, capacityType)
-	for _, launchTemplate := range launchTemplates {
+	rankedOverrides := lo.Map(launchTemplates, func(launchTemplate *launchtemplate.LaunchTemplate, _ int) [][]ec2types.FleetLaunchTemplateOverridesRequest {
+		launchTemplateSubnets := zonalSubnets
+		// Launch templates with secondary network interfaces can only be used in the zone of the interfaces' subnets
+		if launchTemplate.Zone != "" {
+			launchTemplateSubnets = lo.PickByKeys(zonalSubnets, []string{launchTemplate.Zone})
+		}
+		return p.getOverrides(launchTemplate.InstanceTypes, launchTemplateSubnets, requirements, launchTemplate.ImageID, launchTemplate.CapacityReservationID)
+	})
+	overrides := limitOverrides(rankedOverrides)
+	for i, launchTemplate := range launchTemplates {
 		launchTemplateConfig := ec2types.FleetLaunchTemplateConfigRequest{
-			Overrides: p.getOverrides(launchTemplate.InstanceTypes, zonalSubnets, requirements, launchTemplate.ImageID, launchTemplate.CapacityReservationID),
+			Overrides: overrides[i],
 			LaunchTemplateSpecification: &ec2types.FleetLaunchTemplateSpecificationRequest{
 				LaunchTemplateName: aws.String(launchTemplate.Name),
 				Version:            aws.String("$Latest"),
@@ -396,14 +424,37 @@ func (p *DefaultProvider) getLaunchTemplateConfigs(
 	return launchTemplateConfigs, nil
 }
 
+// limitOverrides flattens the overrides of each launch template config, which are grouped by the rank of their subnet
+// in its zone. Overrides for the preferred subnet in each zone are always included, and overrides for each following
+// rank are only included while the number of overrides across all launch template configs remains within maxOverrides.
+func limitOverrides(rankedOverrides [][][]ec2types.FleetLaunchTemplateOverridesRequest) [][]ec2types.FleetLaunchTemplateOverridesRequest {
+	overrides := make([][]ec2types.FleetLaunchTemplateOverridesRequest, len(rankedOverrides))
+	count := 0
+	for rank := 0; rank < lo.Max(lo.Map(rankedOverrides, func(ranks [][]ec2types.FleetLaunchTemplateOverridesRequest, _ int) int { return len(ranks) })); rank++ {
+		rankCount := lo.SumBy(rankedOverrides, func(ranks [][]ec2types.FleetLaunchTemplateOverridesRequest) int {
+			return lo.Ternary(rank < len(ranks), len(ranks[rank]), 0)
+		})
+		if rank > 0 && count+rankCount > maxOverrides {
+			break
+		}
+		for i, ranks := range rankedOverrides {
+			if rank < len(ranks) {
+				overrides[i] = append(overrides[i], ranks[rank]...)
+			}
+		}
+		count += rankCount
+	}
+	return overrides
+}
+
 // getOverrides creates and returns launch template overrides for the cross product of InstanceTypes and subnets (with subnets being constrained by
-// zones and the offerings in InstanceTypes)
+// zones and the offerings in InstanceTypes). The overrides are grouped by the rank of their subnet in its zone, starting with the preferred subnet.
 func (p *DefaultProvider) getOverrides(
 	instanceTypes []*cloudprovider.InstanceType,
-	zonalSubnets map[string]*subnet.Subnet,
+	zonalSubnets map[string][]*subnet.Subnet,
 	reqs scheduling.Requirements,
 	image, capacityReservationID string,
-) []ec2types.FleetLaunchTemplateOverridesRequest {
+) [][]ec2types.FleetLaunchTemplateOverridesRequest {
 	// Unwrap all the offerings to a flat slice that includes a pointer
 	// to the parent instance type name
 	type offeringWithParentName struct {
@@ -429,24 +480,57 @@ func (p *DefaultProvider) getOverrides(
 			})
 		}
 	}
-	var overrides []ec2types.FleetLaunchTemplateOverridesRequest
-	for _, offering := range filteredOfferings {
-		subnet, ok := zonalSubnets[offering.Zone()]
-		if !ok {
-			continue
+	var overrides [][]ec2types.FleetLaunchTemplateOverridesRequest
+	for rank := 0; rank < lo.Max(lo.Map(lo.Values(zonalSubnets), func(s []*subnet.Subnet, _ int) int { return len(s) })); rank++ {
+		var rankOverrides []ec2types.FleetLaunchTemplateOverridesRequest
+		for _, offering := range filteredOfferings {
+			subnets, ok := zonalSubnets[offering.Zone()]
+			if !ok || rank >= len(subnets) {
+				continue
+			}
+			rankOverrides = append(rankOverrides, ec2types.FleetLaunchTemplateOverridesRequest{
+				InstanceType: offering.parentInstanceTypeName,
+				SubnetId:     lo.ToPtr(subnets[rank].ID),
+				ImageId:      lo.ToPtr(image),
+				// This is technically redundant, but is useful if we have to parse insufficient capacity errors from
+				// CreateFleet so that we can figure out the zone rather than additional API calls to look up the subnet
+				AvailabilityZone: lo.ToPtr(subnets[rank].Zone),
+			})
 		}
-		overrides = append(overrides, ec2types.FleetLaunchTemplateOverridesRequest{
-			InstanceType: offering.parentInstanceTypeName,
-			SubnetId:     lo.ToPtr(subnet.ID),
-			ImageId:      lo.ToPtr(image),
-			// This is technically redundant, but is useful if we have to parse insufficient capacity errors from
-			// CreateFleet so that we can figure out the zone rather than additional API calls to look up the subnet
-			AvailabilityZone: lo.ToPtr(subnet.Zone),
-		})
+		overrides = append(overrides, rankOverrides)
 	}
 	return overrides
 }
 
+// filterSubnetAddressErrors removes fleet errors caused by a subnet running out of free IP addresses when another subnet
+// in the same zone was included in the request and didn't run out. In this case, the offering is still launchable from
+// the zone, so it shouldn't be marked as unavailable. Subnets which were left out of the request's overrides aren't
+// considered, since the request gives no indication of whether they have free addresses.
+func filterSubnetAddressErrors(errs []ec2types.CreateFleetError, launchTemplateConfigs []ec2types.FleetLaunchTemplateConfigRequest) []ec2types.CreateFleetError {
+	zonalSubnets := map[string]sets.Set[string]{}
+	for _, launchTemplateConfig := range launchTemplateConfigs {
+		for _, override := range launchTemplateConfig.Overrides {
+			zone := lo.FromPtr(override.AvailabilityZone)
+			if _, ok := zonalSubnets[zone]; !ok {
+				zonalSubnets[zone] = sets.New[string]()
+			}
+			zonalSubnets[zone].Insert(lo.FromPtr(override.SubnetId))
+		}
+	}
+	exhaustedSubnets := sets.New(lo.FilterMap(errs, func(err ec2types.CreateFleetError, _ int) (string, bool) {
+		if !awserrors.IsInsufficientFreeAddresses(err) || err.LaunchTemplateAndOverrides == nil || err.LaunchTemplateAndOverrides.Overrides == nil {
+			return "", false
+		}
+		return lo.FromPtr(err.LaunchTemplateAndOverrides.Overrides.SubnetId), true
+	})...)
+	return lo.Reject(errs, func(err ec2types.CreateFleetError, _ int) bool {
+		if !awserrors.IsInsufficientFreeAddresses(err) || err.LaunchTemplateAndOverrides == nil || err.LaunchTemplateAndOverrides.Overrides == nil {
+			return false
+		}
+		return zonalSubnets[lo.FromPtr(err.LaunchTemplateAndOverrides.Overrides.AvailabilityZone)].Difference(exhaustedSubnets).Len() > 0
+	})
+}
+
 func (p *DefaultProvider) updateUnavailableOfferingsCache(
 	ctx context.Context,
 	errs []ec2types.CreateFleetError,
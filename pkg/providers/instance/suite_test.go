@@ -348,4 +348,106 @@ var _ = Describe("InstanceProvider", func() {
 		retrievedIDs := sets.New[string](lo.Map(instances, func(i *instance.Instance, _ int) string { return i.ID })...)
 		Expect(ids.Equal(retrievedIDs)).To(BeTrue())
 	})
+	Context("Warm Pool", func() {
+		var instanceTypes []*corecloudprovider.InstanceType
+		var storeWarmPoolMember func(zone string, tags map[string]string) string
+		BeforeEach(func() {
+			nodeClass.Spec.WarmPool = &v1.WarmPool{Size: 1}
+			nodeClaim.Annotations = lo.Assign(nodeClaim.Annotations, map[string]string{karpv1.NodePoolHashAnnotationKey: nodePool.Hash()})
+			ExpectApplied(ctx, env.Client, nodeClaim, nodePool, nodeClass)
+			nodeClass = ExpectExists(ctx, env.Client, nodeClass)
+			var err error
+			instanceTypes, err = cloudProvider.GetInstanceTypes(ctx, nodePool)
+			Expect(err).ToNot(HaveOccurred())
+			instanceTypes = lo.Filter(instanceTypes, func(i *corecloudprovider.InstanceType, _ int) bool { return i.Name == "m5.xlarge" })
+
+			storeWarmPoolMember = func(zone string, tags map[string]string) string {
+				instanceID := fake.InstanceID()
+				tags = lo.Assign(map[string]string{
+					fmt.Sprintf("kubernetes.io/cluster/%s", options.FromContext(ctx).ClusterName): "owned",
+					v1.EKSClusterNameTagKey:          options.FromContext(ctx).ClusterName,
+					karpv1.NodePoolLabelKey:          nodePool.Name,
+					v1.LabelNodeClass:                nodeClass.Name,
+					v1.WarmPoolTagKey:                nodePool.Name,
+					v1.AnnotationEC2NodeClassHash:    nodeClass.Hash(),
+					karpv1.NodePoolHashAnnotationKey: nodePool.Hash(),
+				}, tags)
+				awsEnv.EC2API.Instances.Store(instanceID, ec2types.Instance{
+					State:   &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped},
+					ImageId: aws.String(nodeClass.Status.AMIs[0].ID),
+					Tags: lo.MapToSlice(tags, func(k, v string) ec2types.Tag {
+						return ec2types.Tag{Key: aws.String(k), Value: aws.String(v)}
+					}),
+					Placement:    &ec2types.Placement{AvailabilityZone: aws.String(zone)},
+					LaunchTime:   aws.Time(time.Now().Add(-time.Hour)),
+					InstanceId:   lo.ToPtr(instanceID),
+					InstanceType: "m5.xlarge",
+				})
+				return instanceID
+			}
+		})
+		It("should start a compatible warm pool member rather than launching a new instance", func() {
+			instanceID := storeWarmPoolMember("test-zone-1a", nil)
+			claimed, err := awsEnv.InstanceProvider.Create(ctx, nodeClass, nodeClaim, map[string]string{"custom-tag": "custom-value"}, instanceTypes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed.ID).To(Equal(instanceID))
+			Expect(claimed.WarmPool).To(BeEmpty())
+			Expect(claimed.CapacityType).To(Equal(karpv1.CapacityTypeOnDemand))
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(0))
+			Expect(awsEnv.EC2API.StartInstancesBehavior.Calls()).To(Equal(1))
+
+			raw, ok := awsEnv.EC2API.Instances.Load(instanceID)
+			Expect(ok).To(BeTrue())
+			stored := instance.NewInstance(ctx, raw.(ec2types.Instance))
+			Expect(stored.State).To(Equal(ec2types.InstanceStateNameRunning))
+			Expect(stored.WarmPool).To(BeEmpty())
+			Expect(stored.Tags).To(HaveKeyWithValue("custom-tag", "custom-value"))
+		})
+		DescribeTable("should launch a new instance when the warm pool member isn't compatible", func(tags map[string]string) {
+			storeWarmPoolMember("test-zone-1a", tags)
+			_, err := awsEnv.InstanceProvider.Create(ctx, nodeClass, nodeClaim, nil, instanceTypes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awsEnv.EC2API.StartInstancesBehavior.Calls()).To(Equal(0))
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(1))
+		},
+			Entry("belongs to another nodepool", map[string]string{v1.WarmPoolTagKey: "other"}),
+			Entry("stale nodeclass hash", map[string]string{v1.AnnotationEC2NodeClassHash: "stale"}),
+			Entry("stale nodepool hash", map[string]string{karpv1.NodePoolHashAnnotationKey: "stale"}),
+		)
+		It("should launch a new instance when the warm pool member's AMI is no longer resolved", func() {
+			instanceID := storeWarmPoolMember("test-zone-1a", nil)
+			raw, _ := awsEnv.EC2API.Instances.Load(instanceID)
+			member := raw.(ec2types.Instance)
+			member.ImageId = aws.String("ami-stale")
+			awsEnv.EC2API.Instances.Store(instanceID, member)
+			_, err := awsEnv.InstanceProvider.Create(ctx, nodeClass, nodeClaim, nil, instanceTypes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awsEnv.EC2API.StartInstancesBehavior.Calls()).To(Equal(0))
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(1))
+		})
+		It("should launch a new instance when the warm pool member's zone isn't compatible", func() {
+			storeWarmPoolMember("test-zone-1a", nil)
+			nodeClaim.Spec.Requirements = append(nodeClaim.Spec.Requirements, karpv1.NodeSelectorRequirementWithMinValues{
+				NodeSelectorRequirement: corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"test-zone-1b"}},
+			})
+			_, err := awsEnv.InstanceProvider.Create(ctx, nodeClass, nodeClaim, nil, instanceTypes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awsEnv.EC2API.StartInstancesBehavior.Calls()).To(Equal(0))
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(1))
+		})
+		It("should not claim a warm pool member when launching an instance for the warm pool", func() {
+			storeWarmPoolMember("test-zone-1a", nil)
+			_, err := awsEnv.InstanceProvider.Create(ctx, nodeClass, nodeClaim, map[string]string{v1.WarmPoolTagKey: nodePool.Name}, instanceTypes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awsEnv.EC2API.StartInstancesBehavior.Calls()).To(Equal(0))
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(1))
+		})
+		It("should launch a new instance when starting the warm pool member fails", func() {
+			storeWarmPoolMember("test-zone-1a", nil)
+			awsEnv.EC2API.StartInstancesBehavior.Error.Set(fmt.Errorf("failed"))
+			_, err := awsEnv.InstanceProvider.Create(ctx, nodeClass, nodeClaim, nil, instanceTypes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awsEnv.EC2API.CreateFleetBehavior.Calls()).To(Equal(1))
+		})
+	})
 })
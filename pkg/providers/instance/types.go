@@ -16,6 +16,7 @@ package instance
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -23,6 +24,8 @@ import (
 
 	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
 	"sigs.k8s.io/karpenter/pkg/operator/options"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
 )
 
 // Instance is an internal data representation of either an ec2.Instance or an ec2.FleetInstance
@@ -40,6 +43,24 @@ type Instance struct {
 	SubnetID              string
 	Tags                  map[string]string
 	EFAEnabled            bool
+	NetworkInterfaces     []NetworkInterface
+	// InstanceProfile is the name of the instance profile associated with the instance
+	InstanceProfile string
+	// PlacementGroupID is the placement group the instance was launched into
+	PlacementGroupID string
+	// PartitionNumber is the partition the instance was placed in, if it was launched into a partition placement group
+	PartitionNumber int32
+	// Tenancy is the tenancy of the instance's placement
+	Tenancy string
+	// WarmPool is the name of the NodePool whose warm pool the instance belongs to, if it hasn't been claimed yet
+	WarmPool string
+}
+
+// NetworkInterface is an internal data representation of a network interface attached to the instance's first network card
+type NetworkInterface struct {
+	DeviceIndex      int32
+	SubnetID         string
+	SecurityGroupIDs []string
 }
 
 func NewInstance(ctx context.Context, out ec2types.Instance) *Instance {
@@ -69,6 +90,27 @@ func NewInstance(ctx context.Context, out ec2types.Instance) *Instance {
 		EFAEnabled: lo.ContainsBy(out.NetworkInterfaces, func(item ec2types.InstanceNetworkInterface) bool {
 			return item.InterfaceType != nil && *item.InterfaceType == string(ec2types.NetworkInterfaceTypeEfa)
 		}),
+		NetworkInterfaces: lo.FilterMap(out.NetworkInterfaces, func(ni ec2types.InstanceNetworkInterface, _ int) (NetworkInterface, bool) {
+			if ni.Attachment == nil || lo.FromPtr(ni.Attachment.NetworkCardIndex) != 0 {
+				return NetworkInterface{}, false
+			}
+			return NetworkInterface{
+				DeviceIndex: lo.FromPtr(ni.Attachment.DeviceIndex),
+				SubnetID:    lo.FromPtr(ni.SubnetId),
+				SecurityGroupIDs: lo.Map(ni.Groups, func(group ec2types.GroupIdentifier, _ int) string {
+					return lo.FromPtr(group.GroupId)
+				}),
+			}, true
+		}),
+		// The instance profile is only identified by its ARN, which includes its path
+		// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_identifiers.html#identifiers-arns
+		InstanceProfile:  lo.LastOr(strings.Split(lo.FromPtr(lo.FromPtr(out.IamInstanceProfile).Arn), "/"), ""),
+		PlacementGroupID: lo.FromPtr(out.Placement.GroupId),
+		PartitionNumber:  lo.FromPtr(out.Placement.PartitionNumber),
+		Tenancy:          string(out.Placement.Tenancy),
+		WarmPool: lo.FromPtr(lo.FindOrElse(out.Tags, ec2types.Tag{}, func(t ec2types.Tag) bool {
+			return lo.FromPtr(t.Key) == v1.WarmPoolTagKey
+		}).Value),
 	}
 
 }
@@ -79,6 +121,7 @@ func NewInstanceFromFleet(
 	capacityType string,
 	capacityReservationID string,
 	efaEnabled bool,
+	tenancy string,
 ) *Instance {
 	return &Instance{
 		LaunchTime:            time.Now(), // estimate the launch time since we just launched
@@ -92,5 +135,6 @@ func NewInstanceFromFleet(
 		SubnetID:              lo.FromPtr(out.LaunchTemplateAndOverrides.Overrides.SubnetId),
 		Tags:                  tags,
 		EFAEnabled:            efaEnabled,
+		Tenancy:               tenancy,
 	}
 }
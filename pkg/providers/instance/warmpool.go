@@ -0,0 +1,209 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/scheduling"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	awserrors "github.com/aws/karpenter-provider-aws/pkg/errors"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+)
+
+// warmPoolClaimTTL is how long a warm pool member remains reserved for the NodeClaim that claimed it. This guards
+// against two concurrent launches starting the same stopped instance before DescribeInstances reflects the change.
+const warmPoolClaimTTL = 5 * time.Minute
+
+// IsWarmPoolCandidate returns true if a launch for the NodeClaim may be satisfied by starting a warm pool member
+func IsWarmPoolCandidate(nodeClass *v1.EC2NodeClass, nodeClaim *karpv1.NodeClaim, tags map[string]string, capacityType string) bool {
+	if nodeClass.WarmPoolSize() == 0 || nodeClaim.Labels[karpv1.NodePoolLabelKey] == "" {
+		return false
+	}
+	// Launches that populate the warm pool should never be satisfied from it
+	if _, ok := tags[v1.WarmPoolTagKey]; ok {
+		return false
+	}
+	// Warm pool members are always on-demand instances
+	return capacityType != karpv1.CapacityTypeReserved &&
+		scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaim.Spec.Requirements...).Get(karpv1.CapacityTypeLabelKey).Has(karpv1.CapacityTypeOnDemand)
+}
+
+// claimWarmPoolInstance starts a stopped warm pool member that is compatible with the NodeClaim. Members are only
+// compatible if they were launched with the current EC2NodeClass and NodePool hashes, use one of the EC2NodeClass's
+// resolved AMIs, and have an available on-demand offering that satisfies the NodeClaim's requirements. If no member
+// could be claimed, nil is returned and the caller should fall back to launching a new instance.
+func (p *DefaultProvider) claimWarmPoolInstance(
+	ctx context.Context,
+	nodeClass *v1.EC2NodeClass,
+	nodeClaim *karpv1.NodeClaim,
+	tags map[string]string,
+	instanceTypes []*cloudprovider.InstanceType,
+) (*Instance, error) {
+	nodePoolName := nodeClaim.Labels[karpv1.NodePoolLabelKey]
+	members, err := p.listWarmPoolMembers(ctx, nodePoolName)
+	if err != nil {
+		return nil, err
+	}
+	amiIDs := lo.FilterSliceToMap(nodeClass.Status.AMIs, func(ami v1.AMI) (string, struct{}, bool) {
+		return ami.ID, struct{}{}, ami.RolloutPhase != v1.AMIRolloutPhaseHalted
+	})
+	reqs := scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaim.Spec.Requirements...)
+	for _, member := range members {
+		if member.State != ec2types.InstanceStateNameStopped ||
+			member.Tags[v1.NodeClassTagKey] != nodeClass.Name ||
+			member.Tags[v1.AnnotationEC2NodeClassHash] != nodeClass.Hash() ||
+			member.Tags[karpv1.NodePoolHashAnnotationKey] != nodeClaim.Annotations[karpv1.NodePoolHashAnnotationKey] {
+			continue
+		}
+		if _, ok := amiIDs[member.ImageID]; !ok {
+			continue
+		}
+		if !isCompatibleWarmPoolMember(member, reqs, instanceTypes) {
+			continue
+		}
+		if err := p.warmPoolClaims.Add(member.ID, nodeClaim.Name, warmPoolClaimTTL); err != nil {
+			// Another launch has already claimed this member
+			continue
+		}
+		if _, err := p.ec2api.StartInstances(ctx, &ec2.StartInstancesInput{InstanceIds: []string{member.ID}}); err != nil {
+			p.warmPoolClaims.Delete(member.ID)
+			return nil, fmt.Errorf("starting warm pool instance, %w", err)
+		}
+		log.FromContext(ctx).WithValues("instance", member.ID, "instance-type", member.Type, "zone", member.Zone).V(1).Info("claimed warm pool instance")
+		// The member was launched with the same EC2NodeClass, so we only need to add tags that it doesn't already have
+		if missing := lo.OmitBy(tags, func(k, v string) bool { return member.Tags[k] == v }); len(missing) != 0 {
+			if err := p.CreateTags(ctx, member.ID, missing); err != nil {
+				log.FromContext(ctx).Error(err, "failed tagging warm pool instance", "instance", member.ID)
+			}
+		}
+		if err := p.DeleteTags(ctx, member.ID, []string{v1.WarmPoolTagKey}); err != nil {
+			// The tagging controller removes the warm pool tag from registered NodeClaims, so this is safe to ignore
+			log.FromContext(ctx).Error(err, "failed removing warm pool tag", "instance", member.ID)
+		}
+		member.State = ec2types.InstanceStateNamePending
+		member.LaunchTime = time.Now()
+		member.CapacityType = karpv1.CapacityTypeOnDemand
+		member.Tags = lo.OmitByKeys(lo.Assign(member.Tags, tags), []string{v1.WarmPoolTagKey})
+		member.WarmPool = ""
+		return member, nil
+	}
+	return nil, nil
+}
+
+// ListWarmPool lists the warm pool members of all NodePools in the cluster, in any non-terminal state
+func (p *DefaultProvider) ListWarmPool(ctx context.Context) ([]*Instance, error) {
+	instances, err := p.describeWarmPool(ctx, ec2types.Filter{
+		Name:   aws.String("tag-key"),
+		Values: []string{v1.WarmPoolTagKey},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lo.Filter(instances, func(i *Instance, _ int) bool { return i.WarmPool != "" }), nil
+}
+
+// listWarmPoolMembers lists all warm pool members for the NodePool in the cluster, in any non-terminal state
+func (p *DefaultProvider) listWarmPoolMembers(ctx context.Context, nodePoolName string) ([]*Instance, error) {
+	instances, err := p.describeWarmPool(ctx, ec2types.Filter{
+		Name:   aws.String(fmt.Sprintf("tag:%s", v1.WarmPoolTagKey)),
+		Values: []string{nodePoolName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lo.Filter(instances, func(i *Instance, _ int) bool { return i.WarmPool == nodePoolName }), nil
+}
+
+func (p *DefaultProvider) describeWarmPool(ctx context.Context, filter ec2types.Filter) ([]*Instance, error) {
+	var out = &ec2.DescribeInstancesOutput{}
+	paginator := ec2.NewDescribeInstancesPaginator(p.ec2api, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			filter,
+			{
+				Name:   aws.String(fmt.Sprintf("tag:%s", v1.EKSClusterNameTagKey)),
+				Values: []string{options.FromContext(ctx).ClusterName},
+			},
+			instanceStateFilter,
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing warm pool instances, %w", err)
+		}
+		out.Reservations = append(out.Reservations, page.Reservations...)
+	}
+	instances, err := instancesFromOutput(ctx, out)
+	if err != nil {
+		return nil, cloudprovider.IgnoreNodeClaimNotFoundError(err)
+	}
+	return instances, nil
+}
+
+// isCompatibleWarmPoolMember returns true if the member's instance type has an available on-demand offering in the
+// member's zone that satisfies the requirements
+func isCompatibleWarmPoolMember(member *Instance, reqs scheduling.Requirements, instanceTypes []*cloudprovider.InstanceType) bool {
+	it, ok := lo.Find(instanceTypes, func(it *cloudprovider.InstanceType) bool { return it.Name == string(member.Type) })
+	if !ok {
+		return false
+	}
+	memberReqs := scheduling.NewRequirements(reqs.Values()...)
+	memberReqs.Add(
+		scheduling.NewRequirement(karpv1.CapacityTypeLabelKey, corev1.NodeSelectorOpIn, karpv1.CapacityTypeOnDemand),
+		scheduling.NewRequirement(corev1.LabelTopologyZone, corev1.NodeSelectorOpIn, member.Zone),
+	)
+	return len(it.Offerings.Available().Compatible(memberReqs)) != 0
+}
+
+func (p *DefaultProvider) Stop(ctx context.Context, id string, hibernate bool) error {
+	if _, err := p.ec2api.StopInstances(ctx, &ec2.StopInstancesInput{
+		InstanceIds: []string{id},
+		Hibernate:   lo.ToPtr(hibernate),
+	}); err != nil {
+		if awserrors.IsNotFound(err) {
+			return cloudprovider.NewNodeClaimNotFoundError(fmt.Errorf("stopping instance, %w", err))
+		}
+		return fmt.Errorf("stopping instance, %w", err)
+	}
+	return nil
+}
+
+func (p *DefaultProvider) DeleteTags(ctx context.Context, id string, keys []string) error {
+	if _, err := p.ec2api.DeleteTags(ctx, &ec2.DeleteTagsInput{
+		Resources: []string{id},
+		Tags: lo.Map(keys, func(key string, _ int) ec2types.Tag {
+			return ec2types.Tag{Key: aws.String(key)}
+		}),
+	}); err != nil {
+		if awserrors.IsNotFound(err) {
+			return cloudprovider.NewNodeClaimNotFoundError(fmt.Errorf("untagging instance, %w", err))
+		}
+		return fmt.Errorf("untagging instance, %w", err)
+	}
+	return nil
+}
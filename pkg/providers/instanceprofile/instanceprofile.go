@@ -25,15 +25,18 @@ import (
 	"github.com/patrickmn/go-cache"
 	"github.com/samber/lo"
 
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
 	sdk "github.com/aws/karpenter-provider-aws/pkg/aws"
 	awserrors "github.com/aws/karpenter-provider-aws/pkg/errors"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 	"github.com/aws/karpenter-provider-aws/pkg/utils"
 )
 
 type Provider interface {
 	Get(context.Context, string) (*iamtypes.InstanceProfile, error)
-	Create(context.Context, string, string, map[string]string) error
+	Create(context.Context, string, string, string, map[string]string) error
 	Delete(context.Context, string) error
+	ListClusterProfiles(context.Context) ([]*iamtypes.InstanceProfile, error)
 }
 
 type DefaultProvider struct {
@@ -62,7 +65,7 @@ func (p *DefaultProvider) Get(ctx context.Context, instanceProfileName string) (
 	return out.InstanceProfile, nil
 }
 
-func (p *DefaultProvider) Create(ctx context.Context, instanceProfileName string, roleName string, tags map[string]string) error {
+func (p *DefaultProvider) Create(ctx context.Context, instanceProfileName string, path string, roleName string, tags map[string]string) error {
 	instanceProfile, err := p.Get(ctx, instanceProfileName)
 	if err != nil {
 		if !awserrors.IsNotFound(err) {
@@ -70,12 +73,23 @@ func (p *DefaultProvider) Create(ctx context.Context, instanceProfileName string
 		}
 		o, err := p.iamapi.CreateInstanceProfile(ctx, &iam.CreateInstanceProfileInput{
 			InstanceProfileName: lo.ToPtr(instanceProfileName),
+			Path:                lo.EmptyableToPtr(path),
 			Tags:                utils.IAMMergeTags(tags),
 		})
 		if err != nil {
 			return serrors.Wrap(fmt.Errorf("creating instance profile, %w", err), "instance-profile", instanceProfileName)
 		}
 		instanceProfile = o.InstanceProfile
+	} else if IsOwned(instanceProfile, tags) && !hasTags(instanceProfile, tags) {
+		// The ownership tags are included in the request since tagging is scoped to the ownership tags in the
+		// recommended controller policy
+		if _, err = p.iamapi.TagInstanceProfile(ctx, &iam.TagInstanceProfileInput{
+			InstanceProfileName: lo.ToPtr(instanceProfileName),
+			Tags:                utils.IAMMergeTags(tags),
+		}); err != nil {
+			return serrors.Wrap(fmt.Errorf("tagging instance profile, %w", err), "instance-profile", instanceProfileName)
+		}
+		instanceProfile.Tags = utils.IAMMergeTags(iamTagsToMap(instanceProfile.Tags), tags)
 	}
 	// Instance profiles can only have a single role assigned to them so this profile either has 1 or 0 roles
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/id_roles_use_switch-role-ec2_instance-profiles.html
@@ -131,3 +145,60 @@ func (p *DefaultProvider) Delete(ctx context.Context, instanceProfileName string
 	p.cache.Delete(instanceProfileName)
 	return nil
 }
+
+// ListClusterProfiles returns the instance profiles, including their tags, that Karpenter created for EC2NodeClasses
+// in the cluster
+func (p *DefaultProvider) ListClusterProfiles(ctx context.Context) ([]*iamtypes.InstanceProfile, error) {
+	clusterName := options.FromContext(ctx).ClusterName
+	var instanceProfiles []*iamtypes.InstanceProfile
+	paginator := iam.NewListInstanceProfilesPaginator(p.iamapi, &iam.ListInstanceProfilesInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing instance profiles, %w", err)
+		}
+		for _, instanceProfile := range out.InstanceProfiles {
+			// ListInstanceProfiles doesn't return tags, so we only describe the instance profiles that match the
+			// naming scheme used by EC2NodeClass.InstanceProfileName
+			if !strings.HasPrefix(lo.FromPtr(instanceProfile.InstanceProfileName), clusterName+"_") {
+				continue
+			}
+			o, err := p.iamapi.GetInstanceProfile(ctx, &iam.GetInstanceProfileInput{
+				InstanceProfileName: instanceProfile.InstanceProfileName,
+			})
+			if err != nil {
+				if awserrors.IsNotFound(err) {
+					continue
+				}
+				return nil, serrors.Wrap(fmt.Errorf("getting instance profile, %w", err), "instance-profile", lo.FromPtr(instanceProfile.InstanceProfileName))
+			}
+			tags := iamTagsToMap(o.InstanceProfile.Tags)
+			if _, ok := tags[v1.LabelNodeClass]; !ok || tags[fmt.Sprintf("kubernetes.io/cluster/%s", clusterName)] != "owned" {
+				continue
+			}
+			instanceProfiles = append(instanceProfiles, o.InstanceProfile)
+		}
+	}
+	return instanceProfiles, nil
+}
+
+// IsOwned returns true if the instance profile carries the ownership tags that Karpenter applies when it creates an
+// instance profile, i.e. the cluster and EC2NodeClass tags from the passed tags
+func IsOwned(instanceProfile *iamtypes.InstanceProfile, tags map[string]string) bool {
+	ownershipTags := lo.PickBy(tags, func(k string, _ string) bool {
+		return strings.HasPrefix(k, "kubernetes.io/cluster/") || k == v1.LabelNodeClass
+	})
+	return len(ownershipTags) != 0 && hasTags(instanceProfile, ownershipTags)
+}
+
+func hasTags(instanceProfile *iamtypes.InstanceProfile, tags map[string]string) bool {
+	existing := iamTagsToMap(instanceProfile.Tags)
+	return lo.EveryBy(lo.Entries(tags), func(e lo.Entry[string, string]) bool {
+		v, ok := existing[e.Key]
+		return ok && v == e.Value
+	})
+}
+
+func iamTagsToMap(tags []iamtypes.Tag) map[string]string {
+	return lo.SliceToMap(tags, func(t iamtypes.Tag) (string, string) { return lo.FromPtr(t.Key), lo.FromPtr(t.Value) })
+}
@@ -103,7 +103,8 @@ func (p *DefaultProvider) createOfferings(
 	var offerings []*cloudprovider.Offering
 	itZones := sets.New(it.Requirements.Get(corev1.LabelTopologyZone).Values()...)
 
-	if ofs, ok := p.cache.Get(p.cacheKeyFromInstanceType(it)); ok {
+	tenancy := nodeClass.Tenancy()
+	if ofs, ok := p.cache.Get(p.cacheKeyFromInstanceType(it, tenancy)); ok {
 		offerings = append(offerings, ofs.([]*cloudprovider.Offering)...)
 	} else {
 		var cachedOfferings []*cloudprovider.Offering
@@ -113,12 +114,22 @@ func (p *DefaultProvider) createOfferings(
 				if capacityType == karpv1.CapacityTypeReserved {
 					continue
 				}
+				// Spot instances can only be launched with default tenancy
+				if capacityType == karpv1.CapacityTypeSpot && tenancy != v1.TenancyDefault {
+					continue
+				}
 				isUnavailable := p.unavailableOfferings.IsUnavailable(ec2types.InstanceType(it.Name), zone, capacityType)
 				var price float64
 				var hasPrice bool
 				switch capacityType {
 				case karpv1.CapacityTypeOnDemand:
-					price, hasPrice = p.pricingProvider.OnDemandPrice(ec2types.InstanceType(it.Name))
+					if tenancy == v1.TenancyDedicated {
+						price, hasPrice = p.pricingProvider.DedicatedOnDemandPrice(ec2types.InstanceType(it.Name))
+					} else {
+						// Dedicated Hosts are billed per host rather than per instance, so the shared tenancy price is only
+						// used to order instance types on a host
+						price, hasPrice = p.pricingProvider.OnDemandPrice(ec2types.InstanceType(it.Name))
+					}
 				case karpv1.CapacityTypeSpot:
 					price, hasPrice = p.pricingProvider.SpotPrice(ec2types.InstanceType(it.Name), zone)
 				default:
@@ -129,6 +140,7 @@ func (p *DefaultProvider) createOfferings(
 						scheduling.NewRequirement(karpv1.CapacityTypeLabelKey, corev1.NodeSelectorOpIn, capacityType),
 						scheduling.NewRequirement(corev1.LabelTopologyZone, corev1.NodeSelectorOpIn, zone),
 						scheduling.NewRequirement(cloudprovider.ReservationIDLabel, corev1.NodeSelectorOpDoesNotExist),
+						scheduling.NewRequirement(v1.LabelInstanceTenancy, corev1.NodeSelectorOpIn, string(tenancy)),
 					),
 					Price:     price,
 					Available: !isUnavailable && hasPrice && itZones.Has(zone),
@@ -139,10 +151,11 @@ func (p *DefaultProvider) createOfferings(
 				cachedOfferings = append(cachedOfferings, offering)
 			}
 		}
-		p.cache.SetDefault(p.cacheKeyFromInstanceType(it), cachedOfferings)
+		p.cache.SetDefault(p.cacheKeyFromInstanceType(it, tenancy), cachedOfferings)
 		offerings = append(offerings, cachedOfferings...)
 	}
-	if !options.FromContext(ctx).FeatureGates.ReservedCapacity {
+	// Capacity reservations are only matched by instances with default tenancy
+	if !options.FromContext(ctx).FeatureGates.ReservedCapacity || tenancy != v1.TenancyDefault {
 		return offerings
 	}
 
@@ -165,6 +178,7 @@ func (p *DefaultProvider) createOfferings(
 				scheduling.NewRequirement(karpv1.CapacityTypeLabelKey, corev1.NodeSelectorOpIn, karpv1.CapacityTypeReserved),
 				scheduling.NewRequirement(corev1.LabelTopologyZone, corev1.NodeSelectorOpIn, reservation.AvailabilityZone),
 				scheduling.NewRequirement(cloudprovider.ReservationIDLabel, corev1.NodeSelectorOpIn, reservation.ID),
+				scheduling.NewRequirement(v1.LabelInstanceTenancy, corev1.NodeSelectorOpIn, string(tenancy)),
 			),
 			Price:               price,
 			Available:           reservationCapacity != 0 && itZones.Has(reservation.AvailabilityZone),
@@ -178,7 +192,7 @@ func (p *DefaultProvider) createOfferings(
 	return offerings
 }
 
-func (p *DefaultProvider) cacheKeyFromInstanceType(it *cloudprovider.InstanceType) string {
+func (p *DefaultProvider) cacheKeyFromInstanceType(it *cloudprovider.InstanceType, tenancy v1.Tenancy) string {
 	zonesHash, _ := hashstructure.Hash(
 		it.Requirements.Get(corev1.LabelTopologyZone).Values(),
 		hashstructure.FormatV2,
@@ -190,10 +204,11 @@ func (p *DefaultProvider) cacheKeyFromInstanceType(it *cloudprovider.InstanceTyp
 		&hashstructure.HashOptions{SlicesAsSets: true},
 	)
 	return fmt.Sprintf(
-		"%s-%016x-%016x-%d",
+		"%s-%016x-%016x-%s-%d",
 		it.Name,
 		zonesHash,
 		capacityTypesHash,
+		tenancy,
 		p.unavailableOfferings.SeqNum,
 	)
 }
@@ -21,6 +21,7 @@ import (
 	"net"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -255,6 +256,9 @@ var _ = Describe("InstanceTypeProvider", func() {
 			v1.LabelInstanceAcceleratorManufacturer: "aws",
 			v1.LabelInstanceAcceleratorCount:        "1",
 			v1.LabelTopologyZoneID:                  "tstz1-1a",
+			v1.LabelInstanceTenancy:                 "default",
+			v1.LabelInstanceEnclaveSupported:        "true",
+			v1.LabelInstanceHibernationSupported:    "true",
 			// Deprecated Labels
 			corev1.LabelFailureDomainBetaRegion: fake.DefaultRegion,
 			corev1.LabelFailureDomainBetaZone:   "test-zone-1a",
@@ -269,6 +273,8 @@ var _ = Describe("InstanceTypeProvider", func() {
 		Expect(lo.Keys(nodeSelector)).To(ContainElements(append(karpv1.WellKnownLabels.Difference(sets.New(
 			// TODO: add back to test with a preconfigured reserved instance type
 			v1.LabelCapacityReservationID,
+			// The partition is only known once an instance is launched into a partition placement group
+			v1.LabelPlacementGroupPartition,
 		)).UnsortedList(), lo.Keys(karpv1.NormalizedLabels)...)))
 
 		var pods []*corev1.Pod
@@ -311,6 +317,9 @@ var _ = Describe("InstanceTypeProvider", func() {
 			v1.LabelInstanceGPUMemory:                    "16384",
 			v1.LabelInstanceLocalNVME:                    "900",
 			v1.LabelTopologyZoneID:                       "tstz1-1a",
+			v1.LabelInstanceTenancy:                      "default",
+			v1.LabelInstanceEnclaveSupported:             "true",
+			v1.LabelInstanceHibernationSupported:         "true",
 			// Deprecated Labels
 			corev1.LabelFailureDomainBetaRegion: fake.DefaultRegion,
 			corev1.LabelFailureDomainBetaZone:   "test-zone-1a",
@@ -364,6 +373,9 @@ var _ = Describe("InstanceTypeProvider", func() {
 			v1.LabelInstanceAcceleratorManufacturer:      "aws",
 			v1.LabelInstanceAcceleratorCount:             "1",
 			v1.LabelTopologyZoneID:                       "tstz1-1a",
+			v1.LabelInstanceTenancy:                      "default",
+			v1.LabelInstanceEnclaveSupported:             "false",
+			v1.LabelInstanceHibernationSupported:         "false",
 			// Deprecated Labels
 			corev1.LabelFailureDomainBetaRegion: fake.DefaultRegion,
 			corev1.LabelFailureDomainBetaZone:   "test-zone-1a",
@@ -1681,6 +1693,113 @@ var _ = Describe("InstanceTypeProvider", func() {
 			maxPods := 24
 			Expect(it.Capacity.Pods().Value()).To(BeNumerically("==", maxPods))
 		})
+		It("should use prefixes in max-pods calculation when prefix delegation is enabled", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{
+				IPAccountingMode: lo.ToPtr(options.IPAccountingModePrefixDelegation),
+			}))
+
+			instanceInfo, err := awsEnv.EC2API.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{})
+			Expect(err).To(BeNil())
+			t3Large, ok := lo.Find(instanceInfo.InstanceTypes, func(info ec2types.InstanceTypeInfo) bool {
+				return info.InstanceType == "t3.large"
+			})
+			Expect(ok).To(Equal(true))
+			nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{}
+			it := instancetype.NewInstanceType(ctx,
+				t3Large,
+				fake.DefaultRegion,
+				nil,
+				nil,
+				nodeClass.Spec.BlockDeviceMappings,
+				nodeClass.Spec.InstanceStorePolicy,
+				nodeClass.Spec.Kubelet.MaxPods,
+				nodeClass.Spec.Kubelet.PodsPerCore,
+				nodeClass.Spec.Kubelet.KubeReserved,
+				nodeClass.Spec.Kubelet.SystemReserved,
+				nodeClass.Spec.Kubelet.EvictionHard,
+				nodeClass.Spec.Kubelet.EvictionSoft,
+				nodeClass.AMIFamily(),
+				nil,
+			)
+			// t3.large
+			// maxInterfaces = 3
+			// maxIPv4PerInterface = 12
+			// vCPUs = 2
+			// min(3 * (12 - 1) * 16 + 2, 110) = 110
+			maxPods := 110
+			Expect(it.Capacity.Pods().Value()).To(BeNumerically("==", maxPods))
+		})
+		It("should reserve the primary ENI in max-pods calculation when custom networking is enabled", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{
+				IPAccountingMode: lo.ToPtr(options.IPAccountingModeCustomNetworking),
+			}))
+
+			instanceInfo, err := awsEnv.EC2API.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{})
+			Expect(err).To(BeNil())
+			t3Large, ok := lo.Find(instanceInfo.InstanceTypes, func(info ec2types.InstanceTypeInfo) bool {
+				return info.InstanceType == "t3.large"
+			})
+			Expect(ok).To(Equal(true))
+			nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{}
+			it := instancetype.NewInstanceType(ctx,
+				t3Large,
+				fake.DefaultRegion,
+				nil,
+				nil,
+				nodeClass.Spec.BlockDeviceMappings,
+				nodeClass.Spec.InstanceStorePolicy,
+				nodeClass.Spec.Kubelet.MaxPods,
+				nodeClass.Spec.Kubelet.PodsPerCore,
+				nodeClass.Spec.Kubelet.KubeReserved,
+				nodeClass.Spec.Kubelet.SystemReserved,
+				nodeClass.Spec.Kubelet.EvictionHard,
+				nodeClass.Spec.Kubelet.EvictionSoft,
+				nodeClass.AMIFamily(),
+				nil,
+			)
+			// t3.large
+			// maxInterfaces = 3
+			// maxIPv4PerInterface = 12
+			// (3 - 1) * (12 - 1) + 2 = 24
+			maxPods := 24
+			Expect(it.Capacity.Pods().Value()).To(BeNumerically("==", maxPods))
+		})
+		It("should not reserve an additional ENI when custom networking is enabled and aws.reservedENIs is set", func() {
+			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{
+				IPAccountingMode: lo.ToPtr(options.IPAccountingModeCustomNetworking),
+				ReservedENIs:     lo.ToPtr(1),
+			}))
+
+			instanceInfo, err := awsEnv.EC2API.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{})
+			Expect(err).To(BeNil())
+			t3Large, ok := lo.Find(instanceInfo.InstanceTypes, func(info ec2types.InstanceTypeInfo) bool {
+				return info.InstanceType == "t3.large"
+			})
+			Expect(ok).To(Equal(true))
+			nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{}
+			it := instancetype.NewInstanceType(ctx,
+				t3Large,
+				fake.DefaultRegion,
+				nil,
+				nil,
+				nodeClass.Spec.BlockDeviceMappings,
+				nodeClass.Spec.InstanceStorePolicy,
+				nodeClass.Spec.Kubelet.MaxPods,
+				nodeClass.Spec.Kubelet.PodsPerCore,
+				nodeClass.Spec.Kubelet.KubeReserved,
+				nodeClass.Spec.Kubelet.SystemReserved,
+				nodeClass.Spec.Kubelet.EvictionHard,
+				nodeClass.Spec.Kubelet.EvictionSoft,
+				nodeClass.AMIFamily(),
+				nil,
+			)
+			// t3.large
+			// maxInterfaces = 3
+			// maxIPv4PerInterface = 12
+			// (3 - 1) * (12 - 1) + 2 = 24
+			maxPods := 24
+			Expect(it.Capacity.Pods().Value()).To(BeNumerically("==", maxPods))
+		})
 		It("should reserve ENIs when aws.reservedENIs is set and not go below 0 ENIs in max-pods calculation", func() {
 			ctx = options.ToContext(ctx, test.Options(test.OptionsFields{
 				ReservedENIs: lo.ToPtr(1_000_000),
@@ -2234,6 +2353,202 @@ var _ = Describe("InstanceTypeProvider", func() {
 			Expect(node.Labels).To(HaveKeyWithValue(karpv1.NodePoolLabelKey, nodePool.Name))
 		})
 	})
+	Context("CPU Options", func() {
+		It("should compute cpu capacity from the threads per core", func() {
+			nodeClass.Spec.CPUOptions = &v1.CPUOptions{ThreadsPerCore: lo.ToPtr[int32](1)}
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			cpus := lo.SliceToMap(instanceTypes, func(it *corecloudprovider.InstanceType) (string, string) {
+				return it.Name, it.Capacity.Cpu().String()
+			})
+			// Instance types that don't support configuring threads per core are excluded
+			Expect(cpus).To(Equal(map[string]string{"m5.large": "1", "m5.xlarge": "2", "c6g.large": "2"}))
+		})
+		It("should compute kube-reserved and pods from the threads per core", func() {
+			nodeClass.Spec.CPUOptions = &v1.CPUOptions{ThreadsPerCore: lo.ToPtr[int32](1)}
+			nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{PodsPerCore: lo.ToPtr[int32](4)}
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			it, ok := lo.Find(instanceTypes, func(it *corecloudprovider.InstanceType) bool { return it.Name == "m5.large" })
+			Expect(ok).To(BeTrue())
+			Expect(it.Capacity.Pods().Value()).To(BeNumerically("==", 4))
+			Expect(it.Overhead.KubeReserved.Cpu().String()).To(Equal("60m"))
+			Expect(it.Requirements.Get(v1.LabelInstanceCPU).Values()).To(ConsistOf("1"))
+		})
+		It("should only include instance types that support the core count", func() {
+			nodeClass.Spec.CPUOptions = &v1.CPUOptions{CoreCount: lo.ToPtr[int32](1)}
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			cpus := lo.SliceToMap(instanceTypes, func(it *corecloudprovider.InstanceType) (string, string) {
+				return it.Name, it.Capacity.Cpu().String()
+			})
+			Expect(cpus).To(Equal(map[string]string{"m5.large": "2", "c6g.large": "1"}))
+		})
+		It("should only include instance types that support AMD SEV-SNP", func() {
+			out := lo.Must(awsEnv.EC2API.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{}))
+			awsEnv.EC2API.DescribeInstanceTypesOutput.Set(&ec2.DescribeInstanceTypesOutput{
+				InstanceTypes: lo.Map(out.InstanceTypes, func(info ec2types.InstanceTypeInfo, _ int) ec2types.InstanceTypeInfo {
+					if info.InstanceType == "m5.xlarge" {
+						processorInfo := *info.ProcessorInfo
+						processorInfo.SupportedFeatures = []ec2types.SupportedAdditionalProcessorFeature{ec2types.SupportedAdditionalProcessorFeatureAmdSevSnp}
+						info.ProcessorInfo = &processorInfo
+					}
+					return info
+				}),
+			})
+			Expect(awsEnv.InstanceTypesProvider.UpdateInstanceTypes(ctx)).To(Succeed())
+			nodeClass.Spec.CPUOptions = &v1.CPUOptions{AMDSEVSNP: lo.ToPtr("enabled")}
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lo.Map(instanceTypes, func(it *corecloudprovider.InstanceType, _ int) string { return it.Name })).To(ConsistOf("m5.xlarge"))
+		})
+		It("should not filter instance types when AMD SEV-SNP is disabled", func() {
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			nodeClass.Spec.CPUOptions = &v1.CPUOptions{AMDSEVSNP: lo.ToPtr("disabled")}
+			filtered, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filtered).To(HaveLen(len(instanceTypes)))
+		})
+	})
+	Context("Instance Store Policy", func() {
+		BeforeEach(func() {
+			out := lo.Must(awsEnv.EC2API.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{}))
+			awsEnv.EC2API.DescribeInstanceTypesOutput.Set(&ec2.DescribeInstanceTypesOutput{
+				InstanceTypes: lo.Map(out.InstanceTypes, func(info ec2types.InstanceTypeInfo, _ int) ec2types.InstanceTypeInfo {
+					if info.InstanceType == "m6idn.32xlarge" {
+						instanceStorageInfo := *info.InstanceStorageInfo
+						instanceStorageInfo.Disks = []ec2types.DiskInfo{{Count: lo.ToPtr[int32](4), SizeInGB: lo.ToPtr[int64](1900), Type: ec2types.DiskTypeSsd}}
+						info.InstanceStorageInfo = &instanceStorageInfo
+					}
+					return info
+				}),
+			})
+			Expect(awsEnv.InstanceTypesProvider.UpdateInstanceTypes(ctx)).To(Succeed())
+		})
+		DescribeTable("should compute ephemeral storage from the disks used by the policy",
+			func(policy v1.InstanceStorePolicy, alias string, expected string) {
+				nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(policy)
+				nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: alias}}
+				instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+				Expect(err).ToNot(HaveOccurred())
+				it, ok := lo.Find(instanceTypes, func(it *corecloudprovider.InstanceType) bool { return it.Name == "m6idn.32xlarge" })
+				Expect(ok).To(BeTrue())
+				Expect(it.Capacity.StorageEphemeral().Value()).To(Equal(lo.ToPtr(resource.MustParse(expected)).Value()))
+			},
+			Entry("RAID0", v1.InstanceStorePolicyRAID0, "al2023@latest", "7600G"),
+			Entry("Mount", v1.InstanceStorePolicyMount, "al2023@latest", "1900G"),
+			Entry("Mount on Bottlerocket", v1.InstanceStorePolicyMount, "bottlerocket@latest", "7600G"),
+			Entry("ContainerdOnly", v1.InstanceStorePolicyContainerdOnly, "al2023@latest", "20Gi"),
+			Entry("Disabled", v1.InstanceStorePolicyDisabled, "al2023@latest", "20Gi"),
+		)
+	})
+	Context("Nitro Enclaves", func() {
+		It("should only include instance types that support Nitro Enclaves", func() {
+			nodeClass.Spec.EnclaveOptions = &v1.EnclaveOptions{Enabled: lo.ToPtr(true)}
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lo.Map(instanceTypes, func(it *corecloudprovider.InstanceType, _ int) string { return it.Name })).To(ConsistOf(
+				"c6g.large", "g4ad.16xlarge", "g4dn.8xlarge", "m5.xlarge", "m6idn.32xlarge",
+			))
+		})
+		It("should add the nitro enclaves resource when Nitro Enclaves are enabled", func() {
+			nodeClass.Spec.EnclaveOptions = &v1.EnclaveOptions{Enabled: lo.ToPtr(true)}
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			for _, it := range instanceTypes {
+				Expect(it.Capacity).To(HaveKeyWithValue(v1.ResourceAWSNitroEnclaves, resource.MustParse("1")))
+			}
+		})
+		It("should not add the nitro enclaves resource when Nitro Enclaves aren't enabled", func() {
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			nodeClass.Spec.EnclaveOptions = &v1.EnclaveOptions{Enabled: lo.ToPtr(false)}
+			filtered, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filtered).To(HaveLen(len(instanceTypes)))
+			for _, it := range filtered {
+				Expect(it.Capacity).ToNot(HaveKey(v1.ResourceAWSNitroEnclaves))
+			}
+		})
+		It("should schedule pods requesting nitro enclaves", func() {
+			nodeClass.Spec.EnclaveOptions = &v1.EnclaveOptions{Enabled: lo.ToPtr(true)}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod(coretest.PodOptions{
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{v1.ResourceAWSNitroEnclaves: resource.MustParse("1")},
+					Limits:   corev1.ResourceList{v1.ResourceAWSNitroEnclaves: resource.MustParse("1")},
+				},
+			})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels).To(HaveKeyWithValue(v1.LabelInstanceEnclaveSupported, "true"))
+		})
+	})
+	Context("Hibernation", func() {
+		It("should only include instance types that support hibernation", func() {
+			nodeClass.Spec.HibernationOptions = &v1.HibernationOptions{Configured: lo.ToPtr(true)}
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lo.Map(instanceTypes, func(it *corecloudprovider.InstanceType, _ int) string { return it.Name })).To(ConsistOf(
+				"c6g.large", "g4dn.8xlarge", "m5.large", "m5.xlarge", "t3.large", "t4g.medium", "t4g.small", "t4g.xlarge",
+			))
+		})
+		It("should schedule pods selecting the hibernation supported label", func() {
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{v1.LabelInstanceHibernationSupported: "true"}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+		})
+	})
+	Context("Tenancy", func() {
+		It("should only create on-demand offerings for dedicated tenancy", func() {
+			nodeClass.Spec.Tenancy = lo.ToPtr(v1.TenancyDedicated)
+			ExpectApplied(ctx, env.Client, nodeClass)
+			instanceTypes, err := cloudProvider.GetInstanceTypes(ctx, nodePool)
+			Expect(err).ToNot(HaveOccurred())
+			for _, it := range instanceTypes {
+				for _, o := range it.Offerings {
+					Expect(o.CapacityType()).To(Equal(karpv1.CapacityTypeOnDemand))
+					Expect(o.Requirements.Get(v1.LabelInstanceTenancy).Values()).To(ConsistOf(string(v1.TenancyDedicated)))
+					price, ok := awsEnv.PricingProvider.DedicatedOnDemandPrice(ec2types.InstanceType(it.Name))
+					Expect(ok).To(BeTrue())
+					Expect(o.Price).To(Equal(price))
+				}
+			}
+		})
+		It("should price offerings for host tenancy with the shared tenancy price", func() {
+			nodeClass.Spec.Tenancy = lo.ToPtr(v1.TenancyHost)
+			ExpectApplied(ctx, env.Client, nodeClass)
+			instanceTypes, err := cloudProvider.GetInstanceTypes(ctx, nodePool)
+			Expect(err).ToNot(HaveOccurred())
+			for _, it := range instanceTypes {
+				for _, o := range it.Offerings {
+					Expect(o.Requirements.Get(v1.LabelInstanceTenancy).Values()).To(ConsistOf(string(v1.TenancyHost)))
+					price, ok := awsEnv.PricingProvider.OnDemandPrice(ec2types.InstanceType(it.Name))
+					Expect(ok).To(BeTrue())
+					Expect(o.Price).To(Equal(price))
+				}
+			}
+		})
+		It("should launch on-demand capacity with dedicated tenancy when flexible to spot", func() {
+			nodeClass.Spec.Tenancy = lo.ToPtr(v1.TenancyDedicated)
+			nodePool.Spec.Template.Spec.Requirements = []karpv1.NodeSelectorRequirementWithMinValues{
+				{NodeSelectorRequirement: corev1.NodeSelectorRequirement{Key: karpv1.CapacityTypeLabelKey, Operator: corev1.NodeSelectorOpIn, Values: []string{karpv1.CapacityTypeSpot, karpv1.CapacityTypeOnDemand}}}}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels).To(HaveKeyWithValue(karpv1.CapacityTypeLabelKey, karpv1.CapacityTypeOnDemand))
+			Expect(node.Labels).To(HaveKeyWithValue(v1.LabelInstanceTenancy, string(v1.TenancyDedicated)))
+		})
+		It("should not schedule pods selecting a different tenancy", func() {
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{v1.LabelInstanceTenancy: string(v1.TenancyDedicated)}})
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectNotScheduled(ctx, env.Client, pod)
+		})
+	})
 	Context("Ephemeral Storage", func() {
 		BeforeEach(func() {
 			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "al2@latest"}}
@@ -2317,6 +2632,28 @@ var _ = Describe("InstanceTypeProvider", func() {
 				Expect(*ltInput.LaunchTemplateData.BlockDeviceMappings[0].Ebs.VolumeInitializationRate).To(Equal(int32(100)))
 			})
 		})
+		It("should compute ephemeral storage from the volume size expression", func() {
+			nodeClass.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{
+				{
+					DeviceName: aws.String("/dev/xvda"),
+					EBS: &v1.BlockDevice{
+						VolumeSizeExpression: &v1.VolumeSizeExpression{
+							Base:    lo.ToPtr(resource.MustParse("20Gi")),
+							PerVCPU: lo.ToPtr(resource.MustParse("10Gi")),
+							Max:     lo.ToPtr(resource.MustParse("500Gi")),
+						},
+					},
+				},
+			}
+			instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+			Expect(err).To(BeNil())
+			Expect(len(instanceTypes)).To(BeNumerically(">", 0))
+			for _, it := range instanceTypes {
+				vcpus := lo.Must(strconv.ParseInt(it.Requirements.Get(v1.LabelInstanceCPU).Any(), 10, 64))
+				expected := resource.MustParse(fmt.Sprintf("%dGi", min(20+10*vcpus, 500)))
+				Expect(it.Capacity.StorageEphemeral().Value()).To(Equal(expected.Value()), it.Name)
+			}
+		})
 	})
 	Context("Metadata Options", func() {
 		It("should default metadata options on generated launch template", func() {
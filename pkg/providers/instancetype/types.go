@@ -80,13 +80,17 @@ func (d *DefaultResolver) CacheKey(nodeClass *v1.EC2NodeClass) string {
 	kcHash, _ := hashstructure.Hash(kc, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
 	blockDeviceMappingsHash, _ := hashstructure.Hash(nodeClass.Spec.BlockDeviceMappings, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
 	capacityReservationHash, _ := hashstructure.Hash(nodeClass.Status.CapacityReservations, hashstructure.FormatV2, nil)
+	cpuOptionsHash, _ := hashstructure.Hash(nodeClass.Spec.CPUOptions, hashstructure.FormatV2, nil)
 	return fmt.Sprintf(
-		"%016x-%016x-%016x-%s-%s",
+		"%016x-%016x-%016x-%016x-%s-%s-%t-%t",
 		kcHash,
 		blockDeviceMappingsHash,
 		capacityReservationHash,
+		cpuOptionsHash,
 		lo.FromPtr((*string)(nodeClass.Spec.InstanceStorePolicy)),
 		nodeClass.AMIFamily(),
+		nodeClass.EnclavesEnabled(),
+		nodeClass.HibernationConfigured(),
 	)
 }
 
@@ -99,7 +103,17 @@ func (d *DefaultResolver) Resolve(ctx context.Context, info ec2types.InstanceTyp
 	if nodeClass.Spec.Kubelet != nil {
 		kc = nodeClass.Spec.Kubelet
 	}
-	return NewInstanceType(
+	info, ok := applyCPUOptions(info, nodeClass.Spec.CPUOptions)
+	if !ok {
+		return nil
+	}
+	if nodeClass.EnclavesEnabled() && info.NitroEnclavesSupport != ec2types.NitroEnclavesSupportSupported {
+		return nil
+	}
+	if nodeClass.HibernationConfigured() && !aws.ToBool(info.HibernationSupported) {
+		return nil
+	}
+	it := NewInstanceType(
 		ctx,
 		info,
 		d.region,
@@ -118,6 +132,39 @@ func (d *DefaultResolver) Resolve(ctx context.Context, info ec2types.InstanceTyp
 			return cr.InstanceType == string(info.InstanceType)
 		}),
 	)
+	// The Nitro Enclaves device plugin advertises a single enclave device on instances launched with enclaves enabled
+	if nodeClass.EnclavesEnabled() {
+		it.Capacity[v1.ResourceAWSNitroEnclaves] = *resources.Quantity("1")
+	}
+	return it
+}
+
+// applyCPUOptions returns the instance type info with its vCPUs adjusted to the EC2NodeClass's CPU options, so that
+// the CPU capacity, pod density and kube-reserved resources are computed from the vCPUs the instance launches with.
+// It returns false if the instance type doesn't support the CPU options.
+func applyCPUOptions(info ec2types.InstanceTypeInfo, cpuOptions *v1.CPUOptions) (ec2types.InstanceTypeInfo, bool) {
+	if cpuOptions == nil {
+		return info, true
+	}
+	if lo.FromPtr(cpuOptions.AMDSEVSNP) == string(ec2types.AmdSevSnpSpecificationEnabled) &&
+		(info.ProcessorInfo == nil || !lo.Contains(info.ProcessorInfo.SupportedFeatures, ec2types.SupportedAdditionalProcessorFeatureAmdSevSnp)) {
+		return info, false
+	}
+	if cpuOptions.CoreCount == nil && cpuOptions.ThreadsPerCore == nil {
+		return info, true
+	}
+	if cpuOptions.CoreCount != nil && !lo.Contains(info.VCpuInfo.ValidCores, *cpuOptions.CoreCount) {
+		return info, false
+	}
+	if cpuOptions.ThreadsPerCore != nil && !lo.Contains(info.VCpuInfo.ValidThreadsPerCore, *cpuOptions.ThreadsPerCore) {
+		return info, false
+	}
+	vCPUInfo := *info.VCpuInfo
+	vCPUInfo.DefaultCores = lo.ToPtr(lo.FromPtrOr(cpuOptions.CoreCount, lo.FromPtr(info.VCpuInfo.DefaultCores)))
+	vCPUInfo.DefaultThreadsPerCore = lo.ToPtr(lo.FromPtrOr(cpuOptions.ThreadsPerCore, lo.FromPtr(info.VCpuInfo.DefaultThreadsPerCore)))
+	vCPUInfo.DefaultVCpus = lo.ToPtr(*vCPUInfo.DefaultCores * *vCPUInfo.DefaultThreadsPerCore)
+	info.VCpuInfo = &vCPUInfo
+	return info, true
 }
 
 func NewInstanceType(
@@ -207,6 +254,8 @@ func computeRequirements(
 		scheduling.NewRequirement(v1.LabelInstanceAcceleratorCount, corev1.NodeSelectorOpDoesNotExist),
 		scheduling.NewRequirement(v1.LabelInstanceHypervisor, corev1.NodeSelectorOpIn, string(info.Hypervisor)),
 		scheduling.NewRequirement(v1.LabelInstanceEncryptionInTransitSupported, corev1.NodeSelectorOpIn, fmt.Sprint(aws.ToBool(info.NetworkInfo.EncryptionInTransitSupported))),
+		scheduling.NewRequirement(v1.LabelInstanceEnclaveSupported, corev1.NodeSelectorOpIn, fmt.Sprint(info.NitroEnclavesSupport == ec2types.NitroEnclavesSupportSupported)),
+		scheduling.NewRequirement(v1.LabelInstanceHibernationSupported, corev1.NodeSelectorOpIn, fmt.Sprint(aws.ToBool(info.HibernationSupported))),
 	)
 	// Only add zone-id label when available in offerings. It may not be available if a user has upgraded from a
 	// previous version of Karpenter w/o zone-id support and the nodeclass subnet status has not yet updated.
@@ -340,13 +389,26 @@ func memory(ctx context.Context, info ec2types.InstanceTypeInfo) *resource.Quant
 
 // Setting ephemeral-storage to be either the default value, what is defined in blockDeviceMappings, or the combined size of local store volumes.
 func ephemeralStorage(info ec2types.InstanceTypeInfo, amiFamily amifamily.AMIFamily, blockDeviceMappings []*v1.BlockDeviceMapping, instanceStorePolicy *v1.InstanceStorePolicy) *resource.Quantity {
-	// If local store disks have been configured for node ephemeral-storage, use the total size of the disks.
-	if lo.FromPtr(instanceStorePolicy) == v1.InstanceStorePolicyRAID0 {
-		if info.InstanceStorageInfo != nil && info.InstanceStorageInfo.TotalSizeInGB != nil {
-			return resources.Quantity(fmt.Sprintf("%dG", *info.InstanceStorageInfo.TotalSizeInGB))
+	// If local store disks have been configured for node ephemeral-storage, use the size of the disks that the kubelet
+	// state directory is placed on. ContainerdOnly and Disabled leave the kubelet on the EBS root volume.
+	if info.InstanceStorageInfo != nil {
+		switch lo.FromPtr(instanceStorePolicy) {
+		case v1.InstanceStorePolicyRAID0:
+			if info.InstanceStorageInfo.TotalSizeInGB != nil {
+				return resources.Quantity(fmt.Sprintf("%dG", *info.InstanceStorageInfo.TotalSizeInGB))
+			}
+		case v1.InstanceStorePolicyMount:
+			// Bottlerocket can't select individual disks, so every disk is used as with RAID0
+			if _, ok := amiFamily.(*amifamily.Bottlerocket); ok && info.InstanceStorageInfo.TotalSizeInGB != nil {
+				return resources.Quantity(fmt.Sprintf("%dG", *info.InstanceStorageInfo.TotalSizeInGB))
+			}
+			if len(info.InstanceStorageInfo.Disks) != 0 && info.InstanceStorageInfo.Disks[0].SizeInGB != nil {
+				return resources.Quantity(fmt.Sprintf("%dG", *info.InstanceStorageInfo.Disks[0].SizeInGB))
+			}
 		}
 	}
 	if len(blockDeviceMappings) != 0 {
+		blockDeviceMappings = amifamily.ResolveVolumeSizes(blockDeviceMappings, int64(lo.FromPtr(info.VCpuInfo.DefaultVCpus)), lo.FromPtr(info.MemoryInfo.SizeInMiB))
 		// First check if there's a root volume configured in blockDeviceMappings.
 		if blockDeviceMapping, ok := lo.Find(blockDeviceMappings, func(bdm *v1.BlockDeviceMapping) bool {
 			return bdm.RootVolume
@@ -458,11 +520,25 @@ func ENILimitedPods(ctx context.Context, info ec2types.InstanceTypeInfo) *resour
 	// VPC CNI only uses the default network interface
 	// https://github.com/aws/amazon-vpc-cni-k8s/blob/3294231c0dce52cfe473bf6c62f47956a3b333b6/scripts/gen_vpc_ip_limits.go#L162
 	networkInterfaces := *info.NetworkInfo.NetworkCards[*info.NetworkInfo.DefaultNetworkCardIndex].MaximumNetworkInterfaces
-	usableNetworkInterfaces := lo.Max([]int64{int64(int(networkInterfaces) - options.FromContext(ctx).ReservedENIs), 0})
+	reservedENIs := options.FromContext(ctx).ReservedENIs
+	// With custom networking, the primary network interface is only used for the node's IP address. Setups which already
+	// reserve it through reserved-enis aren't reserved an additional interface.
+	if options.FromContext(ctx).CustomNetworkingEnabled() && reservedENIs == 0 {
+		reservedENIs = 1
+	}
+	usableNetworkInterfaces := lo.Max([]int64{int64(int(networkInterfaces) - reservedENIs), 0})
 	if usableNetworkInterfaces == 0 {
 		return resource.NewQuantity(0, resource.DecimalSI)
 	}
 	addressesPerInterface := *info.NetworkInfo.Ipv4AddressesPerInterface
+	// With prefix delegation, each secondary address slot holds a /28 prefix (16 addresses) on nitro instances. Matching
+	// the EKS max-pods calculator, the result is capped at 110 pods for instances with fewer than 30 vCPUs and 250 otherwise.
+	if options.FromContext(ctx).PrefixDelegationEnabled() && (info.Hypervisor == ec2types.InstanceTypeHypervisorNitro || lo.FromPtr(info.BareMetal)) {
+		return resources.Quantity(fmt.Sprint(lo.Min([]int64{
+			usableNetworkInterfaces*(int64(addressesPerInterface)-1)*16 + 2,
+			lo.Ternary[int64](lo.FromPtr(info.VCpuInfo.DefaultVCpus) < 30, 110, 250),
+		})))
+	}
 	return resources.Quantity(fmt.Sprint(usableNetworkInterfaces*(int64(addressesPerInterface)-1) + 2))
 }
 
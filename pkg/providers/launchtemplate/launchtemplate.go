@@ -69,6 +69,8 @@ type LaunchTemplate struct {
 	InstanceTypes         []*cloudprovider.InstanceType
 	ImageID               string
 	CapacityReservationID string
+	// Zone restricts the launch template to subnets in a single zone. It's empty if the launch template can be used in any zone.
+	Zone string
 }
 
 type DefaultProvider struct {
@@ -126,15 +128,7 @@ func (p *DefaultProvider) EnsureAll(
 	p.Lock()
 	defer p.Unlock()
 
-	opts, err := p.CreateAMIOptions(ctx, nodeClass, lo.Assign(
-		nodeClaim.Labels,
-		scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaim.Spec.Requirements...).Labels(), // Inject single-value requirements into userData
-		map[string]string{karpv1.CapacityTypeLabelKey: capacityType},
-	), tags)
-	if err != nil {
-		return nil, err
-	}
-	resolvedLaunchTemplates, err := p.amiFamily.Resolve(nodeClass, nodeClaim, instanceTypes, capacityType, opts)
+	resolvedLaunchTemplates, err := p.resolve(ctx, nodeClass, nodeClaim, instanceTypes, capacityType, tags)
 	if err != nil {
 		return nil, err
 	}
@@ -150,11 +144,31 @@ func (p *DefaultProvider) EnsureAll(
 			InstanceTypes:         resolvedLaunchTemplate.InstanceTypes,
 			ImageID:               resolvedLaunchTemplate.AMIID,
 			CapacityReservationID: resolvedLaunchTemplate.CapacityReservationID,
+			Zone:                  resolvedLaunchTemplate.Zone,
 		})
 	}
 	return launchTemplates, nil
 }
 
+func (p *DefaultProvider) resolve(
+	ctx context.Context,
+	nodeClass *v1.EC2NodeClass,
+	nodeClaim *karpv1.NodeClaim,
+	instanceTypes []*cloudprovider.InstanceType,
+	capacityType string,
+	tags map[string]string,
+) ([]*amifamily.LaunchTemplate, error) {
+	opts, err := p.CreateAMIOptions(ctx, nodeClass, lo.Assign(
+		nodeClaim.Labels,
+		scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaim.Spec.Requirements...).Labels(), // Inject single-value requirements into userData
+		map[string]string{karpv1.CapacityTypeLabelKey: capacityType},
+	), tags)
+	if err != nil {
+		return nil, err
+	}
+	return p.amiFamily.Resolve(nodeClass, nodeClaim, instanceTypes, capacityType, opts)
+}
+
 // InvalidateCache deletes a launch template from cache if it exists
 func (p *DefaultProvider) InvalidateCache(ctx context.Context, ltName string, ltID string) {
 	ctx = log.IntoContext(ctx, log.FromContext(ctx).WithValues("launch-template-name", ltName, "launch-template-id", ltID))
@@ -194,6 +208,8 @@ func (p *DefaultProvider) CreateAMIOptions(ctx context.Context, nodeClass *v1.EC
 		ClusterCIDR:              p.ClusterCIDR.Load(),
 		InstanceProfile:          nodeClass.Status.InstanceProfile,
 		InstanceStorePolicy:      nodeClass.Spec.InstanceStorePolicy,
+		Bottlerocket:             nodeClass.Spec.Bottlerocket,
+		NodeConfig:               nodeClass.Spec.NodeConfig,
 		SecurityGroups:           nodeClass.Status.SecurityGroups,
 		Tags:                     tags,
 		Labels:                   labels,
@@ -303,6 +319,22 @@ func GetCreateLaunchTemplateInput(
 			},
 		},
 	}
+	if placement := placementRequest(options); placement != nil {
+		lt.LaunchTemplateData.Placement = placement
+	}
+	if options.CPUOptions != nil {
+		lt.LaunchTemplateData.CpuOptions = &ec2types.LaunchTemplateCpuOptionsRequest{
+			CoreCount:      options.CPUOptions.CoreCount,
+			ThreadsPerCore: options.CPUOptions.ThreadsPerCore,
+			AmdSevSnp:      ec2types.AmdSevSnpSpecification(lo.FromPtr(options.CPUOptions.AMDSEVSNP)),
+		}
+	}
+	if options.EnclavesEnabled {
+		lt.LaunchTemplateData.EnclaveOptions = &ec2types.LaunchTemplateEnclaveOptionsRequest{Enabled: lo.ToPtr(true)}
+	}
+	if options.HibernationConfigured {
+		lt.LaunchTemplateData.HibernationOptions = &ec2types.LaunchTemplateHibernationOptionsRequest{Configured: lo.ToPtr(true)}
+	}
 	// Gate this specifically since the update to CapacityReservationPreference will opt od / spot launches out of open
 	// ODCRs, which is a breaking change from the pre-native ODCR support behavior.
 	if karpoptions.FromContext(ctx).FeatureGates.ReservedCapacity {
@@ -326,8 +358,9 @@ func GetCreateLaunchTemplateInput(
 
 // generateNetworkInterfaces generates network interfaces for the launch template.
 func generateNetworkInterfaces(options *amifamily.LaunchTemplate, clusterIPFamily corev1.IPFamily) []ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest {
+	var networkInterfaces []ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest
 	if options.EFACount != 0 {
-		return lo.Times(options.EFACount, func(i int) ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest {
+		networkInterfaces = lo.Times(options.EFACount, func(i int) ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest {
 			return ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
 				//nolint: gosec
 				NetworkCardIndex: lo.ToPtr(int32(i)),
@@ -342,18 +375,81 @@ func generateNetworkInterfaces(options *amifamily.LaunchTemplate, clusterIPFamil
 				Ipv6AddressCount:         lo.Ternary(clusterIPFamily == corev1.IPv6Protocol, lo.ToPtr(int32(1)), nil),
 			}
 		})
+	} else {
+		networkInterfaces = []ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+			{
+				AssociatePublicIpAddress: options.AssociatePublicIPAddress,
+				DeviceIndex:              aws.Int32(0),
+				Groups: lo.Map(options.SecurityGroups, func(s v1.SecurityGroup, _ int) string {
+					return s.ID
+				}),
+				PrimaryIpv6:      lo.Ternary(clusterIPFamily == corev1.IPv6Protocol, lo.ToPtr(true), nil),
+				Ipv6AddressCount: lo.Ternary(clusterIPFamily == corev1.IPv6Protocol, lo.ToPtr(int32(1)), nil),
+			},
+		}
 	}
-
-	return []ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
-		{
-			AssociatePublicIpAddress: options.AssociatePublicIPAddress,
-			DeviceIndex:              aws.Int32(0),
-			Groups: lo.Map(options.SecurityGroups, func(s v1.SecurityGroup, _ int) string {
+	for _, ni := range options.NetworkInterfaces {
+		// The primary network interface has already been generated, so we only need to apply the configured overrides
+		if ni.DeviceIndex == 0 {
+			primary := &networkInterfaces[0]
+			if ni.InterfaceType != nil {
+				primary.InterfaceType = ni.InterfaceType
+			}
+			if len(ni.SecurityGroups) != 0 {
+				primary.Groups = lo.Map(ni.SecurityGroups, func(s v1.SecurityGroup, _ int) string { return s.ID })
+			}
+			primary.EnaSrdSpecification = enaSrdSpecification(ni.ENASrdSpecification)
+			continue
+		}
+		networkInterfaces = append(networkInterfaces, ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+			DeviceIndex:   lo.ToPtr(ni.DeviceIndex),
+			InterfaceType: ni.InterfaceType,
+			SubnetId:      lo.EmptyableToPtr(ni.SubnetID),
+			// Secondary network interfaces fall back to the EC2NodeClass's security groups if they don't select their own
+			Groups: lo.Map(lo.Ternary(len(ni.SecurityGroups) != 0, ni.SecurityGroups, options.SecurityGroups), func(s v1.SecurityGroup, _ int) string {
 				return s.ID
 			}),
-			PrimaryIpv6:      lo.Ternary(clusterIPFamily == corev1.IPv6Protocol, lo.ToPtr(true), nil),
-			Ipv6AddressCount: lo.Ternary(clusterIPFamily == corev1.IPv6Protocol, lo.ToPtr(int32(1)), nil),
-		},
+			EnaSrdSpecification: enaSrdSpecification(ni.ENASrdSpecification),
+		})
+	}
+	return networkInterfaces
+}
+
+// placementRequest returns the placement of instances launched with the launch template, or nil if the instances should
+// use the default placement
+func placementRequest(options *amifamily.LaunchTemplate) *ec2types.LaunchTemplatePlacementRequest {
+	if options.PlacementGroupID == "" && (options.Tenancy == "" || options.Tenancy == v1.TenancyDefault) {
+		return nil
+	}
+	placement := &ec2types.LaunchTemplatePlacementRequest{}
+	if options.PlacementGroupID != "" {
+		placement.GroupId = aws.String(options.PlacementGroupID)
+	}
+	switch options.Tenancy {
+	case v1.TenancyDedicated:
+		placement.Tenancy = ec2types.TenancyDedicated
+	case v1.TenancyHost:
+		placement.Tenancy = ec2types.TenancyHost
+		if options.HostID != "" {
+			placement.HostId = aws.String(options.HostID)
+			placement.Affinity = aws.String("host")
+		}
+		if options.HostResourceGroupARN != "" {
+			placement.HostResourceGroupArn = aws.String(options.HostResourceGroupARN)
+		}
+	}
+	return placement
+}
+
+func enaSrdSpecification(spec *v1.ENASrdSpecification) *ec2types.EnaSrdSpecificationRequest {
+	if spec == nil {
+		return nil
+	}
+	return &ec2types.EnaSrdSpecificationRequest{
+		EnaSrdEnabled: spec.ENASrdEnabled,
+		EnaSrdUdpSpecification: lo.Ternary(spec.ENASrdUDPEnabled != nil, &ec2types.EnaSrdUdpSpecificationRequest{
+			EnaSrdUdpEnabled: spec.ENASrdUDPEnabled,
+		}, nil),
 	}
 }
 
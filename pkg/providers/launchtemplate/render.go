@@ -0,0 +1,205 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package launchtemplate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/cloudprovider"
+	"sigs.k8s.io/karpenter/pkg/scheduling"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/instancetype"
+	"github.com/aws/karpenter-provider-aws/pkg/utils"
+)
+
+// RenderedLaunchTemplate is a launch template that would be created for a NodeClaim
+type RenderedLaunchTemplate struct {
+	InstanceTypes []string `json:"instanceTypes"`
+	// UserData is the decoded userData of the launch template
+	UserData                  string                         `json:"userData"`
+	CreateLaunchTemplateInput *ec2.CreateLaunchTemplateInput `json:"createLaunchTemplateInput"`
+}
+
+// Render returns the launch templates that would be created for the NodeClaim, including the rendered userData,
+// without creating them. It's intended for debugging the userData of an EC2NodeClass.
+func (p *DefaultProvider) Render(
+	ctx context.Context,
+	nodeClass *v1.EC2NodeClass,
+	nodeClaim *karpv1.NodeClaim,
+	instanceTypes []*cloudprovider.InstanceType,
+	capacityType string,
+	tags map[string]string,
+) ([]RenderedLaunchTemplate, error) {
+	resolvedLaunchTemplates, err := p.resolve(ctx, nodeClass, nodeClaim, instanceTypes, capacityType, tags)
+	if err != nil {
+		return nil, err
+	}
+	var rendered []RenderedLaunchTemplate
+	for _, resolvedLaunchTemplate := range resolvedLaunchTemplates {
+		encoded, err := resolvedLaunchTemplate.UserData.Script()
+		if err != nil {
+			return nil, err
+		}
+		userData, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decoding userData, %w", err)
+		}
+		rendered = append(rendered, RenderedLaunchTemplate{
+			InstanceTypes:             lo.Map(resolvedLaunchTemplate.InstanceTypes, func(it *cloudprovider.InstanceType, _ int) string { return it.Name }),
+			UserData:                  string(userData),
+			CreateLaunchTemplateInput: GetCreateLaunchTemplateInput(ctx, resolvedLaunchTemplate, p.ClusterIPFamily, encoded),
+		})
+	}
+	return rendered, nil
+}
+
+// RenderHandler serves the launch templates that would be created for a NodePool. The NodePool is set with the
+// nodepool query parameter, and the instance-type, capacity-type and ec2nodeclass query parameters optionally narrow
+// the instance type and capacity type, or override the NodePool's EC2NodeClass.
+type RenderHandler struct {
+	ctx                  context.Context
+	kubeClient           client.Client
+	provider             *DefaultProvider
+	instanceTypeProvider instancetype.Provider
+}
+
+// NewRenderServer returns a server for the RenderHandler at /debug/userdata. The server is run by every replica rather than
+// only the leader, and should only listen on localhost since the rendered userData isn't authorized.
+func NewRenderServer(addr string, handler *RenderHandler) *manager.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/userdata", handler)
+	return &manager.Server{
+		Name: "userdata-rendering",
+		Server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		ShutdownTimeout: lo.ToPtr(10 * time.Second),
+	}
+}
+
+func NewRenderHandler(ctx context.Context, kubeClient client.Client, provider *DefaultProvider, instanceTypeProvider instancetype.Provider) *RenderHandler {
+	return &RenderHandler{
+		ctx:                  ctx,
+		kubeClient:           kubeClient,
+		provider:             provider,
+		instanceTypeProvider: instanceTypeProvider,
+	}
+}
+
+func (h *RenderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rendered, err := h.render(r)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.IsNotFound(err) {
+			status = http.StatusNotFound
+		} else if e, ok := lo.ErrorsAs[badRequestError](err); ok {
+			status = http.StatusBadRequest
+			err = e
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(rendered); err != nil {
+		log.FromContext(h.ctx).Error(err, "failed writing rendered launch templates")
+	}
+}
+
+// nolint:gocyclo
+func (h *RenderHandler) render(r *http.Request) ([]RenderedLaunchTemplate, error) {
+	// The operator's context is used rather than the request's since it carries the options
+	ctx := h.ctx
+	query := r.URL.Query()
+	if query.Get("nodepool") == "" {
+		return nil, badRequestError{fmt.Errorf("nodepool query parameter is required")}
+	}
+	nodePool := &karpv1.NodePool{}
+	if err := h.kubeClient.Get(ctx, client.ObjectKey{Name: query.Get("nodepool")}, nodePool); err != nil {
+		return nil, err
+	}
+	nodeClassName := lo.CoalesceOrEmpty(query.Get("ec2nodeclass"), nodePool.Spec.Template.Spec.NodeClassRef.Name)
+	nodeClass := &v1.EC2NodeClass{}
+	if err := h.kubeClient.Get(ctx, client.ObjectKey{Name: nodeClassName}, nodeClass); err != nil {
+		return nil, err
+	}
+
+	nodeClaim := nodePool.Spec.Template.ToNodeClaim()
+	nodeClaim.Labels = lo.Assign(nodeClaim.Labels, map[string]string{
+		karpv1.NodePoolLabelKey: nodePool.Name,
+		v1.LabelNodeClass:       nodeClass.Name,
+	})
+	requirements := scheduling.NewNodeSelectorRequirementsWithMinValues(nodeClaim.Spec.Requirements...)
+	requirements.Add(scheduling.NewLabelRequirements(nodeClaim.Labels).Values()...)
+	if instanceType := query.Get("instance-type"); instanceType != "" {
+		requirements.Add(scheduling.NewRequirement(corev1.LabelInstanceTypeStable, corev1.NodeSelectorOpIn, instanceType))
+	}
+	// Prefer the capacity types in the order that instances are launched with when the capacity type isn't set
+	capacityType := query.Get("capacity-type")
+	if capacityType == "" {
+		capacityType, _ = lo.Find([]string{karpv1.CapacityTypeOnDemand, karpv1.CapacityTypeSpot, karpv1.CapacityTypeReserved}, func(ct string) bool {
+			return requirements.Get(karpv1.CapacityTypeLabelKey).Has(ct)
+		})
+	}
+	if !lo.Contains([]string{karpv1.CapacityTypeOnDemand, karpv1.CapacityTypeSpot, karpv1.CapacityTypeReserved}, capacityType) ||
+		!requirements.Get(karpv1.CapacityTypeLabelKey).Has(capacityType) {
+		return nil, badRequestError{fmt.Errorf("capacity type %q isn't allowed by the nodepool", capacityType)}
+	}
+	requirements.Add(scheduling.NewRequirement(karpv1.CapacityTypeLabelKey, corev1.NodeSelectorOpIn, capacityType))
+
+	instanceTypes, err := h.instanceTypeProvider.List(ctx, nodeClass)
+	if err != nil {
+		return nil, fmt.Errorf("listing instance types, %w", err)
+	}
+	instanceTypes = lo.Filter(instanceTypes, func(it *cloudprovider.InstanceType, _ int) bool {
+		return requirements.Compatible(it.Requirements, scheduling.AllowUndefinedWellKnownLabels) == nil &&
+			it.Offerings.Available().HasCompatible(requirements)
+	})
+	if len(instanceTypes) == 0 {
+		return nil, badRequestError{fmt.Errorf("no instance types are compatible with the nodepool")}
+	}
+	requirements.Add(scheduling.NewRequirement(corev1.LabelInstanceTypeStable, corev1.NodeSelectorOpIn, lo.Map(instanceTypes, func(it *cloudprovider.InstanceType, _ int) string {
+		return it.Name
+	})...))
+	nodeClaim.Spec.Requirements = requirements.NodeSelectorRequirements()
+
+	tags, err := utils.GetTags(nodeClass, nodeClaim, options.FromContext(ctx).ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	return h.provider.Render(ctx, nodeClass, nodeClaim, instanceTypes, capacityType, tags)
+}
+
+type badRequestError struct {
+	error
+}
@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"strings"
@@ -578,6 +580,38 @@ var _ = Describe("LaunchTemplate Provider", func() {
 			Expect(createFleetInput.TagSpecifications[2].ResourceType).To(Equal(ec2types.ResourceTypeFleet))
 			ExpectTags(createFleetInput.TagSpecifications[2].Tags, nodeClass.Spec.Tags)
 		})
+		It("should request that volume tags be applied to volumes", func() {
+			nodeClass.Spec.Tags = map[string]string{
+				"tag1": "tag1value",
+				"tag2": "tag2value",
+			}
+			nodeClass.Spec.VolumeTags = map[string]string{
+				"tag2":        "volumevalue",
+				"cost-center": "1234",
+			}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Len()).To(Equal(1))
+			createFleetInput := awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Pop()
+			Expect(createFleetInput.TagSpecifications).To(HaveLen(3))
+
+			// volume tags should only be included in the volume tag specification, taking precedence over tags
+			Expect(createFleetInput.TagSpecifications[0].ResourceType).To(Equal(ec2types.ResourceTypeInstance))
+			ExpectTags(createFleetInput.TagSpecifications[0].Tags, nodeClass.Spec.Tags)
+			Expect(lo.ContainsBy(createFleetInput.TagSpecifications[0].Tags, func(t ec2types.Tag) bool { return lo.FromPtr(t.Key) == "cost-center" })).To(BeFalse())
+
+			Expect(createFleetInput.TagSpecifications[1].ResourceType).To(Equal(ec2types.ResourceTypeVolume))
+			ExpectTags(createFleetInput.TagSpecifications[1].Tags, map[string]string{
+				"tag1":        "tag1value",
+				"tag2":        "volumevalue",
+				"cost-center": "1234",
+			})
+
+			Expect(createFleetInput.TagSpecifications[2].ResourceType).To(Equal(ec2types.ResourceTypeFleet))
+			ExpectTags(createFleetInput.TagSpecifications[2].Tags, nodeClass.Spec.Tags)
+		})
 		It("should request that tags be applied to both network interfaces and spot instance requests", func() {
 			nodeClass.Spec.Tags = map[string]string{
 				"tag1": "tag1value",
@@ -662,6 +696,78 @@ var _ = Describe("LaunchTemplate Provider", func() {
 				Expect(lo.FromPtr(ltInput.LaunchTemplateData.BlockDeviceMappings[0].Ebs.Iops)).To(Equal(int32(0)))
 			})
 		})
+		It("should encrypt default block device mappings when the volume encryption policy overrides them", func() {
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "al2@latest"}}
+			nodeClass.Spec.VolumeEncryptionPolicy = &v1.VolumeEncryptionPolicy{
+				KMSKeyID: "arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+				Mode:     v1.VolumeEncryptionPolicyModeOverride,
+			}
+			nodeClass.StatusConditions().SetTrue(v1.ConditionTypeVolumeEncryptionCompliant)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(len(ltInput.LaunchTemplateData.BlockDeviceMappings)).To(Equal(1))
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.BlockDeviceMappings[0].Ebs.VolumeSize)).To(Equal(int32(20)))
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.BlockDeviceMappings[0].Ebs.Encrypted)).To(BeTrue())
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.BlockDeviceMappings[0].Ebs.KmsKeyId)).To(Equal("arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"))
+			})
+			// The AMI family's default mappings shouldn't be modified
+			Expect(amifamily.DefaultEBS.KMSKeyID).To(BeNil())
+		})
+		It("should create a launch template for each volume size resolved from the volume size expression", func() {
+			nodeClass.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{
+				{
+					DeviceName: aws.String("/dev/xvda"),
+					EBS: &v1.BlockDevice{
+						VolumeType: aws.String("gp3"),
+						VolumeSizeExpression: &v1.VolumeSizeExpression{
+							Base:    lo.ToPtr(resource.MustParse("20Gi")),
+							PerVCPU: lo.ToPtr(resource.MustParse("10Gi")),
+						},
+					},
+				},
+			}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically(">", 5))
+			volumeSizes := sets.New[int32]()
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(ltInput.LaunchTemplateData.BlockDeviceMappings).To(HaveLen(1))
+				size := lo.FromPtr(ltInput.LaunchTemplateData.BlockDeviceMappings[0].Ebs.VolumeSize)
+				Expect((size - 20) % 10).To(BeZero())
+				volumeSizes.Insert(size)
+			})
+			Expect(volumeSizes.Len()).To(BeNumerically(">", 1))
+		})
+		It("should clamp the volume size resolved from the volume size expression", func() {
+			nodeClass.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{
+				{
+					DeviceName: aws.String("/dev/xvda"),
+					EBS: &v1.BlockDevice{
+						VolumeType: aws.String("gp3"),
+						VolumeSizeExpression: &v1.VolumeSizeExpression{
+							PerGiBMemory: lo.ToPtr(resource.MustParse("1Gi")),
+							Min:          lo.ToPtr(resource.MustParse("100Gi")),
+							Max:          lo.ToPtr(resource.MustParse("100Gi")),
+						},
+					},
+				},
+			}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			// Every instance type resolves to the same size, so no additional launch templates are needed
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.BlockDeviceMappings[0].Ebs.VolumeSize)).To(Equal(int32(100)))
+			})
+		})
 		It("should use custom block device mapping", func() {
 			nodeClass.Spec.BlockDeviceMappings = []*v1.BlockDeviceMapping{
 				{
@@ -1396,6 +1502,47 @@ var _ = Describe("LaunchTemplate Provider", func() {
 			ExpectScheduled(ctx, env.Client, pod)
 			ExpectLaunchTemplatesCreatedWithUserDataContaining("--cpu-cfs-quota=false")
 		})
+		It("should pass resource manager, registry, container log, seccomp and pod pids flags when specified", func() {
+			nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{
+				CPUManagerPolicy:          lo.ToPtr("static"),
+				CPUManagerReconcilePeriod: &metav1.Duration{Duration: time.Second * 10},
+				TopologyManagerPolicy:     lo.ToPtr("single-numa-node"),
+				TopologyManagerScope:      lo.ToPtr("pod"),
+				RegistryPullQPS:           aws.Int32(10),
+				RegistryBurst:             aws.Int32(20),
+				ContainerLogMaxSize:       lo.ToPtr("50Mi"),
+				ContainerLogMaxFiles:      aws.Int32(5),
+				SeccompDefault:            aws.Bool(true),
+				PodPidsLimit:              aws.Int64(4096),
+			}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			ExpectLaunchTemplatesCreatedWithUserDataContaining(
+				"--cpu-manager-policy=static",
+				"--cpu-manager-reconcile-period=10s",
+				"--topology-manager-policy=single-numa-node",
+				"--topology-manager-scope=pod",
+				"--registry-qps=10",
+				"--registry-burst=20",
+				"--container-log-max-size=50Mi",
+				"--container-log-max-files=5",
+				"--seccomp-default=true",
+				"--pod-max-pids=4096",
+			)
+		})
+		It("should set the shutdown grace periods in the kubelet config when specified", func() {
+			nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{
+				ShutdownGracePeriod:             &metav1.Duration{Duration: time.Minute},
+				ShutdownGracePeriodCriticalPods: &metav1.Duration{Duration: time.Second * 30},
+			}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			ExpectLaunchTemplatesCreatedWithUserDataContaining(`.shutdownGracePeriod="1m0s" | .shutdownGracePeriodCriticalPods="30s"`)
+		})
 		It("should not pass any labels prefixed with the node-restriction.kubernetes.io domain", func() {
 			nodePool.Spec.Template.Labels = lo.Assign(nodePool.Spec.Template.Labels, map[string]string{
 				corev1.LabelNamespaceNodeRestriction + "/team":                        "team-1",
@@ -1427,6 +1574,65 @@ var _ = Describe("LaunchTemplate Provider", func() {
 			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
 			ExpectLaunchTemplatesCreatedWithUserDataContaining("--local-disks raid0")
 		})
+		It("should mount the first instance store disk when the Mount instance-store policy is set on AL2", func() {
+			nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(v1.InstanceStorePolicyMount)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			ExpectLaunchTemplatesCreatedWithUserDataContaining("NVMe_Instance_Storage_", "for DIR in /var/lib/containerd /var/lib/kubelet /var/log/pods; do")
+			ExpectLaunchTemplatesCreatedWithUserDataNotContaining("--local-disks")
+		})
+		It("should only mount the containerd directory when the ContainerdOnly instance-store policy is set on AL2", func() {
+			nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(v1.InstanceStorePolicyContainerdOnly)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			ExpectLaunchTemplatesCreatedWithUserDataContaining("for DIR in /var/lib/containerd; do")
+			ExpectLaunchTemplatesCreatedWithUserDataNotContaining("--local-disks")
+		})
+		It("should leave instance store disks unmounted when the Disabled instance-store policy is set on AL2", func() {
+			nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(v1.InstanceStorePolicyDisabled)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			ExpectLaunchTemplatesCreatedWithUserDataNotContaining("--local-disks", "NVMe_Instance_Storage_")
+		})
+		It("should only bind the containerd directory when the ContainerdOnly instance-store policy is set on Bottlerocket", func() {
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "bottlerocket@latest"}}
+			nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(v1.InstanceStorePolicyContainerdOnly)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			ExpectLaunchTemplatesCreatedWithUserDataContaining(`
+[settings.bootstrap-commands.000-mount-instance-storage]
+commands = [['apiclient', 'ephemeral-storage', 'init'], ['apiclient', 'ephemeral-storage', 'bind', '--dirs', '/var/lib/containerd']]
+mode = 'always'
+essential = true
+`)
+		})
+		It("should not specify a bootstrap-command when the Disabled instance-store policy is set on Bottlerocket", func() {
+			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "bottlerocket@latest"}}
+			nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(v1.InstanceStorePolicyDisabled)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			ExpectLaunchTemplatesCreatedWithUserDataNotContaining("000-mount-instance-storage")
+		})
 		It("should specify RAID0 bootstrap-command when instance-store policy is set on Bottlerocket", func() {
 			nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "bottlerocket@latest"}}
 			nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(v1.InstanceStorePolicyRAID0)
@@ -1506,6 +1712,99 @@ essential = true
 				Expect(err).To(BeNil())
 				ExpectLaunchTemplatesCreatedWithUserData(fmt.Sprintf(string(content), nodeClass.Name, karpv1.NodePoolLabelKey, nodePool.Name))
 			})
+			It("should merge the bottlerocket configuration into user data", func() {
+				nodeClass.Spec.Bottlerocket = &v1.BottlerocketConfiguration{
+					BootstrapContainers: map[string]v1.BottlerocketBootstrapContainer{
+						"setup": {Source: "public.ecr.aws/example/setup:latest", Mode: v1.BottlerocketBootstrapModeOnce, Essential: lo.ToPtr(true)},
+					},
+					BootstrapCommands: map[string]v1.BottlerocketBootstrapCommand{
+						"hello": {Commands: [][]string{{"echo", "hello"}}},
+					},
+					HostContainers: &v1.BottlerocketHostContainers{
+						Admin:   &v1.BottlerocketHostContainer{Enabled: lo.ToPtr(true), Superpowered: lo.ToPtr(true)},
+						Control: &v1.BottlerocketHostContainer{Enabled: lo.ToPtr(false)},
+					},
+					Kernel:            &v1.BottlerocketKernel{Sysctl: map[string]string{"vm.max_map_count": "262144"}},
+					ContainerRegistry: &v1.BottlerocketContainerRegistry{Mirrors: []v1.BottlerocketRegistryMirror{{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}}}},
+					NTP:               &v1.BottlerocketNTP{TimeServers: []string{"169.254.169.123"}, Options: []string{"iburst"}},
+					OCIDefaults: &v1.BottlerocketOCIDefaults{
+						Capabilities:   map[string]bool{"sys-admin": false},
+						ResourceLimits: map[string]v1.BottlerocketResourceLimit{"max-open-files": {HardLimit: 65536, SoftLimit: 32768}},
+					},
+				}
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				pod := coretest.UnschedulablePod()
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically(">=", 1))
+				awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+					userData, err := base64.StdEncoding.DecodeString(*ltInput.LaunchTemplateData.UserData)
+					Expect(err).To(BeNil())
+					config := &bootstrap.BottlerocketConfig{}
+					Expect(config.UnmarshalTOML(userData)).To(Succeed())
+					Expect(config.Settings.BootstrapContainers).To(Equal(map[string]bootstrap.BottlerocketBootstrapContainer{
+						"setup": {Source: lo.ToPtr("public.ecr.aws/example/setup:latest"), Mode: lo.ToPtr("once"), Essential: lo.ToPtr(true)},
+					}))
+					Expect(config.Settings.BootstrapCommands).To(Equal(map[string]bootstrap.BootstrapCommand{
+						"hello": {Commands: [][]string{{"echo", "hello"}}, Mode: bootstrap.BootstrapCommandModeAlways},
+					}))
+					Expect(config.Settings.HostContainers).To(Equal(map[string]bootstrap.BottlerocketHostContainer{
+						"admin":   {Enabled: lo.ToPtr(true), Superpowered: lo.ToPtr(true)},
+						"control": {Enabled: lo.ToPtr(false)},
+					}))
+					Expect(config.Settings.Kernel.Sysctl).To(Equal(map[string]string{"vm.max_map_count": "262144"}))
+					Expect(config.Settings.ContainerRegistry.Mirrors).To(Equal([]bootstrap.BottlerocketRegistryMirror{{Registry: lo.ToPtr("docker.io"), Endpoint: []string{"https://mirror.example.com"}}}))
+					Expect(config.Settings.NTP).To(Equal(&bootstrap.BottlerocketNTP{TimeServers: []string{"169.254.169.123"}, Options: []string{"iburst"}}))
+					Expect(config.Settings.OCIDefaults).To(Equal(&bootstrap.BottlerocketOCIDefaults{
+						Capabilities:   map[string]bool{"sys-admin": false},
+						ResourceLimits: map[string]bootstrap.BottlerocketResourceLimit{"max-open-files": {HardLimit: lo.ToPtr[int64](65536), SoftLimit: lo.ToPtr[int64](32768)}},
+					}))
+				})
+			})
+			It("should keep user data settings that aren't part of the bottlerocket configuration", func() {
+				nodeClass.Spec.UserData = aws.String(`
+[settings.container-registry]
+[[settings.container-registry.credentials]]
+registry = "docker.io"
+username = "user"
+
+[[settings.container-registry.mirrors]]
+registry = "docker.io"
+endpoint = ["https://old-mirror.example.com"]
+
+[[settings.container-registry.mirrors]]
+registry = "quay.io"
+endpoint = ["https://quay-mirror.example.com"]
+
+[settings.kernel.lockdown]
+mode = "integrity"
+
+[settings.kernel.sysctl]
+"net.core.somaxconn" = "1024"
+`)
+				nodeClass.Spec.Bottlerocket = &v1.BottlerocketConfiguration{
+					Kernel:            &v1.BottlerocketKernel{Sysctl: map[string]string{"vm.max_map_count": "262144"}},
+					ContainerRegistry: &v1.BottlerocketContainerRegistry{Mirrors: []v1.BottlerocketRegistryMirror{{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}}}},
+				}
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				pod := coretest.UnschedulablePod()
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically(">=", 1))
+				awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+					userData, err := base64.StdEncoding.DecodeString(*ltInput.LaunchTemplateData.UserData)
+					Expect(err).To(BeNil())
+					config := &bootstrap.BottlerocketConfig{}
+					Expect(config.UnmarshalTOML(userData)).To(Succeed())
+					Expect(config.Settings.Kernel.Sysctl).To(Equal(map[string]string{"net.core.somaxconn": "1024", "vm.max_map_count": "262144"}))
+					Expect(config.Settings.ContainerRegistry.Mirrors).To(ConsistOf(
+						bootstrap.BottlerocketRegistryMirror{Registry: lo.ToPtr("quay.io"), Endpoint: []string{"https://quay-mirror.example.com"}},
+						bootstrap.BottlerocketRegistryMirror{Registry: lo.ToPtr("docker.io"), Endpoint: []string{"https://mirror.example.com"}},
+					))
+					Expect(config.SettingsRaw["container-registry"]).To(HaveKey("credentials"))
+					Expect(config.SettingsRaw["kernel"]).To(HaveKeyWithValue("lockdown", map[string]interface{}{"mode": "integrity"}))
+				})
+			})
 			It("should not bootstrap when provider ref points to a non-existent EC2NodeClass resource", func() {
 				nodePool.Spec.Template.Spec.NodeClassRef = &karpv1.NodeClassReference{
 					Group: "doesnotexist",
@@ -1729,6 +2028,45 @@ eviction-max-pod-grace-period = 10
 					Expect(*config.Settings.Kubernetes.CPUCFSQuota).To(BeFalse())
 				})
 			})
+			It("should pass resource manager, shutdown, registry, container log, seccomp and pod pids settings when specified", func() {
+				nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{
+					CPUManagerPolicy:                lo.ToPtr("static"),
+					CPUManagerReconcilePeriod:       &metav1.Duration{Duration: time.Second * 10},
+					TopologyManagerPolicy:           lo.ToPtr("best-effort"),
+					TopologyManagerScope:            lo.ToPtr("container"),
+					ShutdownGracePeriod:             &metav1.Duration{Duration: time.Minute},
+					ShutdownGracePeriodCriticalPods: &metav1.Duration{Duration: time.Second * 30},
+					RegistryPullQPS:                 aws.Int32(10),
+					RegistryBurst:                   aws.Int32(20),
+					ContainerLogMaxSize:             lo.ToPtr("50Mi"),
+					ContainerLogMaxFiles:            aws.Int32(5),
+					SeccompDefault:                  aws.Bool(true),
+					PodPidsLimit:                    aws.Int64(4096),
+				}
+				ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+				pod := coretest.UnschedulablePod()
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+				awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+					userData, err := base64.StdEncoding.DecodeString(*ltInput.LaunchTemplateData.UserData)
+					Expect(err).To(BeNil())
+					config := &bootstrap.BottlerocketConfig{}
+					Expect(config.UnmarshalTOML(userData)).To(Succeed())
+					Expect(lo.FromPtr(config.Settings.Kubernetes.CPUManagerPolicy)).To(Equal("static"))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.CPUManagerReconcilePeriod)).To(Equal("10s"))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.TopologyManagerPolicy)).To(Equal("best-effort"))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.TopologyManagerScope)).To(Equal("container"))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.ShutdownGracePeriod)).To(Equal("1m0s"))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.ShutdownGracePeriodForCriticalPods)).To(Equal("30s"))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.RegistryQPS)).To(Equal(10))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.RegistryBurst)).To(Equal(20))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.ContainerLogMaxSize)).To(Equal("50Mi"))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.ContainerLogMaxFiles)).To(Equal(5))
+					Expect(lo.FromPtr(config.Settings.Kubernetes.SeccompDefault)).To(BeTrue())
+					Expect(lo.FromPtr(config.Settings.Kubernetes.PodPidsLimit)).To(Equal(4096))
+				})
+			})
 			It("should specify labels in the Kubelet flags when specified in NodePool", func() {
 				desiredLabels := map[string]string{
 					"test-label-1": "value-1",
@@ -1873,6 +2211,40 @@ eviction-max-pod-grace-period = 10
 						})))
 					}
 				})
+				It("should pass the expanded kubelet configuration in the KubeletConfiguration", func() {
+					nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{
+						CPUManagerPolicy:                lo.ToPtr("static"),
+						TopologyManagerPolicy:           lo.ToPtr("restricted"),
+						ShutdownGracePeriod:             &metav1.Duration{Duration: time.Minute},
+						ShutdownGracePeriodCriticalPods: &metav1.Duration{Duration: time.Second * 30},
+						RegistryPullQPS:                 aws.Int32(10),
+						ContainerLogMaxSize:             lo.ToPtr("50Mi"),
+						SeccompDefault:                  aws.Bool(true),
+						PodPidsLimit:                    aws.Int64(4096),
+					}
+					ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+					pod := coretest.UnschedulablePod()
+					ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+					ExpectScheduled(ctx, env.Client, pod)
+					for _, userData := range ExpectUserDataExistsFromCreatedLaunchTemplates() {
+						configs := ExpectUserDataCreatedWithNodeConfigs(userData)
+						Expect(len(configs)).To(Equal(1))
+						for key, value := range map[string]string{
+							"cpuManagerPolicy":                `"static"`,
+							"topologyManagerPolicy":           `"restricted"`,
+							"shutdownGracePeriod":             `"1m0s"`,
+							"shutdownGracePeriodCriticalPods": `"30s"`,
+							"registryPullQPS":                 `10`,
+							"containerLogMaxSize":             `"50Mi"`,
+							"seccompDefault":                  `true`,
+							"podPidsLimit":                    `4096`,
+						} {
+							raw, ok := configs[0].Spec.Kubelet.Config[key]
+							Expect(ok).To(BeTrue())
+							Expect(string(raw.Raw)).To(Equal(value))
+						}
+					}
+				})
 				It("should specify labels in the Kubelet flags when specified in NodePool", func() {
 					desiredLabels := map[string]string{
 						"test-label-1": "value-1",
@@ -2031,6 +2403,78 @@ eviction-max-pod-grace-period = 10
 					Expect(configs[0].Spec.Instance.LocalStorage.Strategy).To(Equal(admv1alpha1.LocalStorageRAID0))
 				}
 			})
+			It("should mount the first instance store disk with a shell script when specified by the InstanceStorePolicy", func() {
+				nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(v1.InstanceStorePolicyMount)
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				pod := coretest.UnschedulablePod()
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				for _, userData := range ExpectUserDataExistsFromCreatedLaunchTemplates() {
+					configs := ExpectUserDataCreatedWithNodeConfigs(userData)
+					Expect(len(configs)).To(Equal(1))
+					Expect(configs[0].Spec.Instance.LocalStorage.Strategy).To(BeEmpty())
+				}
+				ExpectLaunchTemplatesCreatedWithUserDataContaining("text/x-shellscript", "for DIR in /var/lib/containerd /var/lib/kubelet /var/log/pods; do")
+			})
+			It("should only mount the containerd directory when the InstanceStorePolicy is ContainerdOnly", func() {
+				nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(v1.InstanceStorePolicyContainerdOnly)
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				pod := coretest.UnschedulablePod()
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				ExpectLaunchTemplatesCreatedWithUserDataContaining("for DIR in /var/lib/containerd; do")
+			})
+			It("should merge the nodeConfig into the generated NodeConfig", func() {
+				nodeClass.Spec.NodeConfig = &v1.NodeConfigOverlay{
+					Containerd: &v1.NodeConfigContainerd{Config: lo.ToPtr("[plugins.\"io.containerd.grpc.v1.cri\".containerd]\ndiscard_unpacked_layers = false\n")},
+					Instance:   &v1.NodeConfigInstance{LocalStorage: &v1.NodeConfigLocalStorage{Strategy: v1.NodeConfigLocalStorageStrategyMount}},
+					Kubelet:    &v1.NodeConfigKubelet{Flags: []string{"--v=4"}},
+				}
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				pod := coretest.UnschedulablePod()
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				for _, userData := range ExpectUserDataExistsFromCreatedLaunchTemplates() {
+					configs := ExpectUserDataCreatedWithNodeConfigs(userData)
+					Expect(len(configs)).To(Equal(1))
+					Expect(configs[0].Spec.Containerd.Config).To(Equal(*nodeClass.Spec.NodeConfig.Containerd.Config))
+					Expect(configs[0].Spec.Instance.LocalStorage.Strategy).To(Equal(admv1alpha1.LocalStorageMount))
+					Expect(configs[0].Spec.Kubelet.Flags).To(HaveLen(2))
+					Expect(configs[0].Spec.Kubelet.Flags[0]).To(HavePrefix("--node-labels="))
+					Expect(configs[0].Spec.Kubelet.Flags[1]).To(Equal("--v=4"))
+				}
+			})
+			It("should not launch nodes when the containerd config isn't valid TOML", func() {
+				nodeClass.Spec.NodeConfig = &v1.NodeConfigOverlay{
+					Containerd: &v1.NodeConfigContainerd{Config: lo.ToPtr("[plugins")},
+				}
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				pod := coretest.UnschedulablePod()
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectNotScheduled(ctx, env.Client, pod)
+				Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(Equal(0))
+			})
+			It("should render the user data without creating launch templates", func() {
+				nodeClass.Spec.NodeConfig = &v1.NodeConfigOverlay{Kubelet: &v1.NodeConfigKubelet{Flags: []string{"--v=4"}}}
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				instanceTypes, err := awsEnv.InstanceTypesProvider.List(ctx, nodeClass)
+				Expect(err).To(BeNil())
+				instanceTypes = lo.Filter(instanceTypes, func(it *corecloudprovider.InstanceType, _ int) bool { return it.Name == "m5.large" })
+				Expect(instanceTypes).To(HaveLen(1))
+				nodeClaim := coretest.NodeClaim(karpv1.NodeClaim{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{karpv1.NodePoolLabelKey: nodePool.Name}},
+				})
+				rendered, err := awsEnv.LaunchTemplateProvider.Render(ctx, nodeClass, nodeClaim, instanceTypes, karpv1.CapacityTypeOnDemand, nil)
+				Expect(err).To(BeNil())
+				Expect(rendered).To(HaveLen(1))
+				Expect(rendered[0].InstanceTypes).To(ConsistOf("m5.large"))
+				userData, err := base64.StdEncoding.DecodeString(lo.FromPtr(rendered[0].CreateLaunchTemplateInput.LaunchTemplateData.UserData))
+				Expect(err).To(BeNil())
+				Expect(rendered[0].UserData).To(Equal(string(userData)))
+				configs := ExpectUserDataCreatedWithNodeConfigs(rendered[0].UserData)
+				Expect(configs[0].Spec.Kubelet.Flags).To(ContainElement("--v=4"))
+				Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(Equal(0))
+			})
 			DescribeTable(
 				"should merge custom user data",
 				func(inputFile *string, mergedFile string) {
@@ -2200,7 +2644,7 @@ eviction-max-pod-grace-period = 10
 				nodeClass.Spec.AMIFamily = lo.ToPtr(v1.AMIFamilyCustom)
 				nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Tags: map[string]string{"*": "*"}}}
 				ExpectApplied(ctx, env.Client, nodeClass)
-				controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver)
+				controller := nodeclass.NewController(awsEnv.Clock, env.Client, cloudProvider, recorder, fake.DefaultRegion, awsEnv.SubnetProvider, awsEnv.SecurityGroupProvider, awsEnv.AMIProvider, awsEnv.InstanceProfileProvider, awsEnv.InstanceTypesProvider, awsEnv.LaunchTemplateProvider, awsEnv.CapacityReservationProvider, awsEnv.PlacementGroupProvider, awsEnv.EC2API, awsEnv.ValidationCache, awsEnv.AMIResolver, awsEnv.AMIHealthTracker)
 				ExpectObjectReconciled(ctx, env.Client, controller, nodeClass)
 				nodePool.Spec.Template.Spec.Requirements = []karpv1.NodeSelectorRequirementWithMinValues{
 					{
@@ -2306,7 +2750,7 @@ eviction-max-pod-grace-period = 10
 				ExpectLaunchTemplatesCreatedWithUserDataContaining("--dns-cluster-ip '10.0.10.100'")
 			})
 		})
-		Context("Windows Custom UserData", func() {
+		Context("Windows UserData", func() {
 			BeforeEach(func() {
 				nodePool.Spec.Template.Spec.Requirements = []karpv1.NodeSelectorRequirementWithMinValues{{NodeSelectorRequirement: corev1.NodeSelectorRequirement{Key: corev1.LabelOSStable, Operator: corev1.NodeSelectorOpIn, Values: []string{string(corev1.Windows)}}}}
 				nodeClass.Spec.AMISelectorTerms = []v1.AMISelectorTerm{{Alias: "windows2022@latest"}}
@@ -2345,6 +2789,73 @@ eviction-max-pod-grace-period = 10
 				Expect(err).To(BeNil())
 				ExpectLaunchTemplatesCreatedWithUserData(fmt.Sprintf(string(content), nodeClass.Name, karpv1.NodePoolLabelKey, nodePool.Name))
 			})
+			It("should merge custom user data that uses powershell tags", func() {
+				content, err := os.ReadFile("testdata/windows_userdata_tagged_input.golden")
+				Expect(err).To(BeNil())
+				nodeClass.Spec.UserData = aws.String(string(content))
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				Expect(env.Client.Get(ctx, client.ObjectKeyFromObject(nodePool), nodePool)).To(Succeed())
+				pod := coretest.UnschedulablePod(coretest.PodOptions{
+					NodeSelector: map[string]string{
+						corev1.LabelOSStable:     string(corev1.Windows),
+						corev1.LabelWindowsBuild: "10.0.20348",
+					},
+				})
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				content, err = os.ReadFile("testdata/windows_userdata_tagged_merged.golden")
+				Expect(err).To(BeNil())
+				ExpectLaunchTemplatesCreatedWithUserData(fmt.Sprintf(string(content), nodeClass.Name, karpv1.NodePoolLabelKey, nodePool.Name))
+			})
+			It("should pass eviction and reserved resource kubelet args", func() {
+				nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{
+					MaxPods:        lo.ToPtr[int32](110),
+					SystemReserved: map[string]string{string(corev1.ResourceCPU): "500m"},
+					KubeReserved:   map[string]string{string(corev1.ResourceMemory): "1Gi"},
+					EvictionHard:   map[string]string{"memory.available": "5%"},
+				}
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				pod := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelOSStable: string(corev1.Windows)}})
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				ExpectLaunchTemplatesCreatedWithUserDataContaining(`--system-reserved="cpu=500m"`, `--kube-reserved="memory=1Gi"`, `--eviction-hard="memory.available<5%"`)
+			})
+			It("should not pass kubelet args that aren't supported on Windows", func() {
+				nodeClass.Spec.Kubelet = &v1.KubeletConfiguration{
+					MaxPods:          lo.ToPtr[int32](110),
+					CPUCFSQuota:      lo.ToPtr(false),
+					CPUManagerPolicy: lo.ToPtr("static"),
+					PodPidsLimit:     lo.ToPtr[int64](1024),
+					EvictionHard:     map[string]string{"pid.available": "5%"},
+				}
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				pod := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelOSStable: string(corev1.Windows)}})
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				ExpectLaunchTemplatesCreatedWithUserDataContaining("--max-pods=110")
+				ExpectLaunchTemplatesCreatedWithUserDataNotContaining("--cpu-cfs-quota", "--cpu-manager-policy", "--pod-max-pids", "--eviction-hard")
+			})
+			DescribeTable(
+				"should mount instance store disks before bootstrap",
+				func(policy v1.InstanceStorePolicy, expected ...string) {
+					nodeClass.Spec.InstanceStorePolicy = lo.ToPtr(policy)
+					ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+					pod := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelOSStable: string(corev1.Windows)}})
+					ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+					ExpectScheduled(ctx, env.Client, pod)
+					ExpectLaunchTemplatesCreatedWithUserDataContaining(append(expected, `$MountPoint = 'C:\k8s-disks\0'`)...)
+				},
+				Entry("RAID0", v1.InstanceStorePolicyRAID0, "-ResiliencySettingName Simple", `'C:\var\lib\kubelet'`),
+				Entry("Mount", v1.InstanceStorePolicyMount, "Get-Disk -Number $Disks[0].DeviceId", `'C:\var\lib\kubelet'`),
+				Entry("ContainerdOnly", v1.InstanceStorePolicyContainerdOnly, "Get-Disk -Number $Disks[0].DeviceId", `@('C:\ProgramData\containerd\root')`),
+			)
+			It("should not mount instance store disks without an instance store policy", func() {
+				ExpectApplied(ctx, env.Client, nodeClass, nodePool)
+				pod := coretest.UnschedulablePod(coretest.PodOptions{NodeSelector: map[string]string{corev1.LabelOSStable: string(corev1.Windows)}})
+				ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+				ExpectScheduled(ctx, env.Client, pod)
+				ExpectLaunchTemplatesCreatedWithUserDataNotContaining("Get-PhysicalDisk")
+			})
 		})
 	})
 	Context("Detailed Monitoring", func() {
@@ -2370,6 +2881,138 @@ eviction-max-pod-grace-period = 10
 			})
 		})
 	})
+	Context("Placement Group", func() {
+		It("should not set placement in the launch template when no placement group is selected", func() {
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(ltInput.LaunchTemplateData.Placement).To(BeNil())
+			})
+		})
+		It("should pass the resolved placement group to the launch template", func() {
+			nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-test1"}
+			nodeClass.Status.PlacementGroup = &v1.PlacementGroup{ID: "pg-test1", Name: "placementGroup-test1", Strategy: v1.PlacementGroupStrategyCluster}
+			nodeClass.StatusConditions().SetTrue(v1.ConditionTypePlacementGroupReady)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.Placement.GroupId)).To(Equal("pg-test1"))
+			})
+		})
+	})
+	Context("CPU Options", func() {
+		It("should not set cpu options in the launch template by default", func() {
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(ltInput.LaunchTemplateData.CpuOptions).To(BeNil())
+			})
+		})
+		It("should set cpu options in the launch template", func() {
+			nodeClass.Spec.CPUOptions = &v1.CPUOptions{ThreadsPerCore: lo.ToPtr[int32](1)}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically(">", 0))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.CpuOptions.ThreadsPerCore)).To(BeNumerically("==", 1))
+				Expect(ltInput.LaunchTemplateData.CpuOptions.CoreCount).To(BeNil())
+				Expect(ltInput.LaunchTemplateData.CpuOptions.AmdSevSnp).To(BeEmpty())
+			})
+		})
+	})
+	Context("Enclave and Hibernation Options", func() {
+		It("should not set enclave or hibernation options in the launch template by default", func() {
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(ltInput.LaunchTemplateData.EnclaveOptions).To(BeNil())
+				Expect(ltInput.LaunchTemplateData.HibernationOptions).To(BeNil())
+			})
+		})
+		It("should enable enclaves in the launch template", func() {
+			nodeClass.Spec.EnclaveOptions = &v1.EnclaveOptions{Enabled: lo.ToPtr(true)}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically(">", 0))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.EnclaveOptions.Enabled)).To(BeTrue())
+				Expect(ltInput.LaunchTemplateData.HibernationOptions).To(BeNil())
+			})
+		})
+		It("should configure hibernation in the launch template", func() {
+			nodeClass.Spec.HibernationOptions = &v1.HibernationOptions{Configured: lo.ToPtr(true)}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically(">", 0))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.HibernationOptions.Configured)).To(BeTrue())
+				Expect(ltInput.LaunchTemplateData.EnclaveOptions).To(BeNil())
+			})
+		})
+	})
+	Context("Tenancy", func() {
+		It("should set dedicated tenancy in the launch template", func() {
+			nodeClass.Spec.Tenancy = lo.ToPtr(v1.TenancyDedicated)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(ltInput.LaunchTemplateData.Placement.Tenancy).To(Equal(ec2types.TenancyDedicated))
+				Expect(ltInput.LaunchTemplateData.Placement.HostId).To(BeNil())
+				Expect(ltInput.LaunchTemplateData.Placement.Affinity).To(BeNil())
+			})
+		})
+		It("should set host tenancy and host affinity in the launch template when a host id is selected", func() {
+			nodeClass.Spec.Tenancy = lo.ToPtr(v1.TenancyHost)
+			nodeClass.Spec.HostSelector = &v1.HostSelector{ID: "h-0123456789abcdef0"}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(ltInput.LaunchTemplateData.Placement.Tenancy).To(Equal(ec2types.TenancyHost))
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.Placement.HostId)).To(Equal("h-0123456789abcdef0"))
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.Placement.Affinity)).To(Equal("host"))
+			})
+		})
+		It("should set the host resource group in the launch template", func() {
+			nodeClass.Spec.Tenancy = lo.ToPtr(v1.TenancyHost)
+			nodeClass.Spec.HostSelector = &v1.HostSelector{ResourceGroupARN: "arn:aws:resource-groups:us-west-2:123456789012:group/mac-hosts"}
+			nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-test1"}
+			nodeClass.Status.PlacementGroup = &v1.PlacementGroup{ID: "pg-test1", Name: "placementGroup-test1", Strategy: v1.PlacementGroupStrategyCluster}
+			nodeClass.StatusConditions().SetTrue(v1.ConditionTypePlacementGroupReady)
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(BeNumerically("==", 5))
+			awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.ForEach(func(ltInput *ec2.CreateLaunchTemplateInput) {
+				Expect(ltInput.LaunchTemplateData.Placement.Tenancy).To(Equal(ec2types.TenancyHost))
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.Placement.HostResourceGroupArn)).To(Equal("arn:aws:resource-groups:us-west-2:123456789012:group/mac-hosts"))
+				Expect(lo.FromPtr(ltInput.LaunchTemplateData.Placement.GroupId)).To(Equal("pg-test1"))
+				Expect(ltInput.LaunchTemplateData.Placement.Affinity).To(BeNil())
+			})
+		})
+	})
 	Context("Instance Metadata", func() {
 		It("should set the default instance metadata settings on instances", func() {
 			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
@@ -2458,6 +3101,85 @@ eviction-max-pod-grace-period = 10
 			)
 		})
 	})
+	Context("Network Interfaces", func() {
+		BeforeEach(func() {
+			nodeClass.Spec.NetworkInterfaces = []v1.NetworkInterface{
+				{
+					DeviceIndex:         0,
+					ENASrdSpecification: &v1.ENASrdSpecification{ENASrdEnabled: lo.ToPtr(true)},
+				},
+				{
+					DeviceIndex:                1,
+					InterfaceType:              lo.ToPtr("interface"),
+					SubnetSelectorTerms:        []v1.SubnetSelectorTerm{{Tags: map[string]string{"purpose": "storage"}}},
+					SecurityGroupSelectorTerms: []v1.SecurityGroupSelectorTerm{{Tags: map[string]string{"purpose": "storage"}}},
+					ENASrdSpecification:        &v1.ENASrdSpecification{ENASrdEnabled: lo.ToPtr(true), ENASrdUDPEnabled: lo.ToPtr(true)},
+				},
+			}
+			nodeClass.Status.NetworkInterfaces = []v1.NetworkInterfaceStatus{
+				{
+					DeviceIndex: 0,
+				},
+				{
+					DeviceIndex:    1,
+					Subnets:        []v1.Subnet{{ID: "subnet-storage1", Zone: "test-zone-1a"}},
+					SecurityGroups: []v1.SecurityGroup{{ID: "sg-storage"}},
+				},
+			}
+		})
+		It("should configure the primary and secondary network interfaces", func() {
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			input := awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Pop()
+			Expect(input.LaunchTemplateData.NetworkInterfaces).To(HaveLen(2))
+
+			primary := input.LaunchTemplateData.NetworkInterfaces[0]
+			Expect(lo.FromPtr(primary.DeviceIndex)).To(Equal(int32(0)))
+			Expect(primary.SubnetId).To(BeNil())
+			Expect(primary.Groups).To(ConsistOf("sg-test1", "sg-test2", "sg-test3"))
+			Expect(lo.FromPtr(primary.EnaSrdSpecification.EnaSrdEnabled)).To(BeTrue())
+			Expect(primary.EnaSrdSpecification.EnaSrdUdpSpecification).To(BeNil())
+
+			secondary := input.LaunchTemplateData.NetworkInterfaces[1]
+			Expect(lo.FromPtr(secondary.DeviceIndex)).To(Equal(int32(1)))
+			Expect(lo.FromPtr(secondary.InterfaceType)).To(Equal("interface"))
+			Expect(lo.FromPtr(secondary.SubnetId)).To(Equal("subnet-storage1"))
+			Expect(secondary.Groups).To(ConsistOf("sg-storage"))
+			Expect(lo.FromPtr(secondary.EnaSrdSpecification.EnaSrdEnabled)).To(BeTrue())
+			Expect(lo.FromPtr(secondary.EnaSrdSpecification.EnaSrdUdpSpecification.EnaSrdUdpEnabled)).To(BeTrue())
+		})
+		It("should only launch into zones where the secondary network interfaces have a subnet", func() {
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			node := ExpectScheduled(ctx, env.Client, pod)
+			Expect(node.Labels).To(HaveKeyWithValue(corev1.LabelTopologyZone, "test-zone-1a"))
+			createFleetInput := awsEnv.EC2API.CreateFleetBehavior.CalledWithInput.Pop()
+			for _, ltConfig := range createFleetInput.LaunchTemplateConfigs {
+				for _, override := range ltConfig.Overrides {
+					Expect(lo.FromPtr(override.AvailabilityZone)).To(Equal("test-zone-1a"))
+				}
+			}
+		})
+		It("should fall back to the nodeclass security groups for secondary network interfaces", func() {
+			nodeClass.Status.NetworkInterfaces[1].SecurityGroups = nil
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectScheduled(ctx, env.Client, pod)
+			input := awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Pop()
+			Expect(input.LaunchTemplateData.NetworkInterfaces[1].Groups).To(ConsistOf("sg-test1", "sg-test2", "sg-test3"))
+		})
+		It("should fail to schedule when the secondary network interfaces don't have a subnet in a compatible zone", func() {
+			nodeClass.Status.NetworkInterfaces[1].Subnets = []v1.Subnet{{ID: "subnet-storage1", Zone: "test-zone-1d"}}
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			pod := coretest.UnschedulablePod()
+			ExpectProvisioned(ctx, env.Client, cluster, cloudProvider, prov, pod)
+			ExpectNotScheduled(ctx, env.Client, pod)
+		})
+	})
 	It("should generate a unique launch template per capacity reservation", func() {
 		crs := []ec2types.CapacityReservation{
 			{
@@ -2581,6 +3303,53 @@ eviction-max-pod-grace-period = 10
 		Entry("enabled", true),
 		Entry("disabled", false),
 	)
+	Context("Render Handler", func() {
+		var handler *launchtemplate.RenderHandler
+		BeforeEach(func() {
+			handler = launchtemplate.NewRenderHandler(ctx, env.Client, awsEnv.LaunchTemplateProvider, awsEnv.InstanceTypesProvider)
+		})
+		It("should render the launch templates for a nodepool", func() {
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/debug/userdata?nodepool=%s&instance-type=m5.large", nodePool.Name), nil))
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			var rendered []launchtemplate.RenderedLaunchTemplate
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &rendered)).To(Succeed())
+			Expect(rendered).To(HaveLen(1))
+			Expect(rendered[0].InstanceTypes).To(ConsistOf("m5.large"))
+			Expect(rendered[0].UserData).To(ContainSubstring(fmt.Sprintf("%s=%s", karpv1.NodePoolLabelKey, nodePool.Name)))
+			Expect(rendered[0].UserData).To(ContainSubstring(fmt.Sprintf("%s=%s", karpv1.CapacityTypeLabelKey, karpv1.CapacityTypeOnDemand)))
+			Expect(rendered[0].CreateLaunchTemplateInput.LaunchTemplateData.BlockDeviceMappings).ToNot(BeEmpty())
+			Expect(awsEnv.EC2API.CreateLaunchTemplateBehavior.CalledWithInput.Len()).To(Equal(0))
+		})
+		It("should fail when the capacity type isn't allowed by the nodepool", func() {
+			ExpectApplied(ctx, env.Client, nodePool, nodeClass)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/debug/userdata?nodepool=%s&capacity-type=spot", nodePool.Name), nil))
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+		It("should fail when the nodepool isn't set", func() {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/userdata", nil))
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+		It("should fail when the nodepool doesn't exist", func() {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/userdata?nodepool=missing", nil))
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+		It("should only serve the handler at /debug/userdata on the render server", func() {
+			server := launchtemplate.NewRenderServer("127.0.0.1:8082", handler)
+			Expect(server.Server.Addr).To(Equal("127.0.0.1:8082"))
+			Expect(server.NeedLeaderElection()).To(BeFalse())
+			recorder := httptest.NewRecorder()
+			server.Server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/userdata", nil))
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			recorder = httptest.NewRecorder()
+			server.Server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
 })
 
 // ExpectTags verifies that the expected tags are a subset of the tags found
@@ -0,0 +1,138 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementgroup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/mitchellh/hashstructure/v2"
+	"github.com/patrickmn/go-cache"
+	"github.com/samber/lo"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/karpenter/pkg/utils/pretty"
+
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	sdk "github.com/aws/karpenter-provider-aws/pkg/aws"
+	awserrors "github.com/aws/karpenter-provider-aws/pkg/errors"
+)
+
+type Provider interface {
+	List(context.Context, *v1.EC2NodeClass) ([]ec2types.PlacementGroup, error)
+}
+
+type DefaultProvider struct {
+	sync.Mutex
+	ec2api sdk.EC2API
+	cache  *cache.Cache
+	cm     *pretty.ChangeMonitor
+}
+
+func NewDefaultProvider(ec2api sdk.EC2API, cache *cache.Cache) *DefaultProvider {
+	return &DefaultProvider{
+		ec2api: ec2api,
+		cm:     pretty.NewChangeMonitor(),
+		cache:  cache,
+	}
+}
+
+// List returns the available placement groups that match the EC2NodeClass's placement group selector
+func (p *DefaultProvider) List(ctx context.Context, nodeClass *v1.EC2NodeClass) ([]ec2types.PlacementGroup, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	if nodeClass.Spec.PlacementGroup == nil {
+		return nil, nil
+	}
+	input := describePlacementGroupsInput(nodeClass.Spec.PlacementGroup)
+	placementGroups, err := p.getPlacementGroups(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	placementGroupIDs := lo.Map(placementGroups, func(pg ec2types.PlacementGroup, _ int) string { return aws.ToString(pg.GroupId) })
+	if p.cm.HasChanged(fmt.Sprintf("placement-groups/%s", nodeClass.Name), placementGroupIDs) {
+		log.FromContext(ctx).
+			WithValues("placement-groups", placementGroupIDs).
+			V(1).Info("discovered placement groups")
+	}
+	return placementGroups, nil
+}
+
+func (p *DefaultProvider) getPlacementGroups(ctx context.Context, input *ec2.DescribePlacementGroupsInput) ([]ec2types.PlacementGroup, error) {
+	hash, err := hashstructure.Hash(input, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
+	if err != nil {
+		return nil, err
+	}
+	if pgs, ok := p.cache.Get(fmt.Sprint(hash)); ok {
+		// Ensure what's returned from this function is a shallow-copy of the slice (not a deep-copy of the data itself)
+		// so that modifications to the ordering of the data don't affect the original
+		return append([]ec2types.PlacementGroup{}, pgs.([]ec2types.PlacementGroup)...), nil
+	}
+	var placementGroups []ec2types.PlacementGroup
+	out, err := p.ec2api.DescribePlacementGroups(ctx, input)
+	// EC2 returns an error rather than an empty result when a requested placement group id doesn't exist
+	if err != nil && !awserrors.IsNotFound(err) {
+		return nil, fmt.Errorf("describing placement groups, %w", err)
+	}
+	if err == nil {
+		placementGroups = out.PlacementGroups
+	}
+	p.cache.SetDefault(fmt.Sprint(hash), placementGroups)
+	return append([]ec2types.PlacementGroup{}, placementGroups...), nil
+}
+
+func describePlacementGroupsInput(selector *v1.PlacementGroupSelector) *ec2.DescribePlacementGroupsInput {
+	input := &ec2.DescribePlacementGroupsInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("state"),
+			Values: []string{string(ec2types.PlacementGroupStateAvailable)},
+		}},
+	}
+	if selector.Strategy != nil {
+		input.Filters = append(input.Filters, ec2types.Filter{
+			Name:   aws.String("strategy"),
+			Values: []string{string(*selector.Strategy)},
+		})
+	}
+	switch {
+	case selector.ID != "":
+		input.GroupIds = []string{selector.ID}
+	case selector.Name != "":
+		input.Filters = append(input.Filters, ec2types.Filter{
+			Name:   aws.String("group-name"),
+			Values: []string{selector.Name},
+		})
+	default:
+		for k, v := range selector.Tags {
+			if v == "*" {
+				input.Filters = append(input.Filters, ec2types.Filter{
+					Name:   aws.String("tag-key"),
+					Values: []string{k},
+				})
+			} else {
+				input.Filters = append(input.Filters, ec2types.Filter{
+					Name:   aws.String(fmt.Sprintf("tag:%s", k)),
+					Values: []string{v},
+				})
+			}
+		}
+	}
+	return input
+}
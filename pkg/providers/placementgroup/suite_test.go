@@ -0,0 +1,138 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementgroup_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/samber/lo"
+
+	"github.com/aws/karpenter-provider-aws/pkg/apis"
+	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
+	"github.com/aws/karpenter-provider-aws/pkg/test"
+
+	coreoptions "sigs.k8s.io/karpenter/pkg/operator/options"
+	coretest "sigs.k8s.io/karpenter/pkg/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/karpenter/pkg/utils/testing"
+)
+
+var ctx context.Context
+var stop context.CancelFunc
+var env *coretest.Environment
+var awsEnv *test.Environment
+var nodeClass *v1.EC2NodeClass
+
+func TestAWS(t *testing.T) {
+	ctx = TestContextWithLogger(t)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PlacementGroupProvider")
+}
+
+var _ = BeforeSuite(func() {
+	env = coretest.NewEnvironment(coretest.WithCRDs(apis.CRDs...))
+	ctx = coreoptions.ToContext(ctx, coretest.Options())
+	ctx = options.ToContext(ctx, test.Options())
+	ctx, stop = context.WithCancel(ctx)
+	awsEnv = test.NewEnvironment(ctx, env)
+})
+
+var _ = AfterSuite(func() {
+	stop()
+	Expect(env.Stop()).To(Succeed(), "Failed to stop environment")
+})
+
+var _ = BeforeEach(func() {
+	nodeClass = test.EC2NodeClass()
+	awsEnv.Reset()
+	awsEnv.EC2API.DescribePlacementGroupsOutput.Set(&ec2.DescribePlacementGroupsOutput{
+		PlacementGroups: []ec2types.PlacementGroup{
+			{
+				GroupId:   aws.String("pg-test1"),
+				GroupName: aws.String("placementGroup-test1"),
+				State:     ec2types.PlacementGroupStateAvailable,
+				Strategy:  ec2types.PlacementStrategyCluster,
+				Tags:      []ec2types.Tag{{Key: aws.String("workload"), Value: aws.String("hpc")}},
+			},
+			{
+				GroupId:        aws.String("pg-test2"),
+				GroupName:      aws.String("placementGroup-test2"),
+				State:          ec2types.PlacementGroupStateAvailable,
+				Strategy:       ec2types.PlacementStrategyPartition,
+				PartitionCount: lo.ToPtr[int32](3),
+				Tags:           []ec2types.Tag{{Key: aws.String("workload"), Value: aws.String("hpc")}},
+			},
+		},
+	})
+})
+
+var _ = Describe("PlacementGroupProvider", func() {
+	It("should not discover placement groups when no selector is set", func() {
+		placementGroups, err := awsEnv.PlacementGroupProvider.List(ctx, nodeClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(placementGroups).To(BeEmpty())
+	})
+	It("should discover placement groups by id", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-test2"}
+		placementGroups, err := awsEnv.PlacementGroupProvider.List(ctx, nodeClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lo.Map(placementGroups, func(pg ec2types.PlacementGroup, _ int) string { return *pg.GroupId })).To(ConsistOf("pg-test2"))
+	})
+	It("should not return an error when a placement group id doesn't exist", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{ID: "pg-test3"}
+		placementGroups, err := awsEnv.PlacementGroupProvider.List(ctx, nodeClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(placementGroups).To(BeEmpty())
+	})
+	It("should discover placement groups by name", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{Name: "placementGroup-test1"}
+		placementGroups, err := awsEnv.PlacementGroupProvider.List(ctx, nodeClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lo.Map(placementGroups, func(pg ec2types.PlacementGroup, _ int) string { return *pg.GroupId })).To(ConsistOf("pg-test1"))
+	})
+	It("should discover placement groups by tags", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{Tags: map[string]string{"workload": "hpc"}}
+		placementGroups, err := awsEnv.PlacementGroupProvider.List(ctx, nodeClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lo.Map(placementGroups, func(pg ec2types.PlacementGroup, _ int) string { return *pg.GroupId })).To(ConsistOf("pg-test1", "pg-test2"))
+	})
+	It("should only discover placement groups with the selected strategy", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{
+			Tags:     map[string]string{"workload": "hpc"},
+			Strategy: lo.ToPtr(v1.PlacementGroupStrategyPartition),
+		}
+		placementGroups, err := awsEnv.PlacementGroupProvider.List(ctx, nodeClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lo.Map(placementGroups, func(pg ec2types.PlacementGroup, _ int) string { return *pg.GroupId })).To(ConsistOf("pg-test2"))
+	})
+	It("should cache placement groups for the same selector", func() {
+		nodeClass.Spec.PlacementGroup = &v1.PlacementGroupSelector{Name: "placementGroup-test1"}
+		placementGroups, err := awsEnv.PlacementGroupProvider.List(ctx, nodeClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(placementGroups).To(HaveLen(1))
+
+		awsEnv.EC2API.DescribePlacementGroupsOutput.Set(&ec2.DescribePlacementGroupsOutput{})
+		placementGroups, err = awsEnv.PlacementGroupProvider.List(ctx, nodeClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(placementGroups).To(HaveLen(1))
+	})
+})
@@ -44,8 +44,10 @@ type Provider interface {
 	LivenessProbe(*http.Request) error
 	InstanceTypes() []ec2types.InstanceType
 	OnDemandPrice(ec2types.InstanceType) (float64, bool)
+	DedicatedOnDemandPrice(ec2types.InstanceType) (float64, bool)
 	SpotPrice(ec2types.InstanceType, string) (float64, bool)
 	UpdateOnDemandPricing(context.Context) error
+	UpdateDedicatedOnDemandPricing(context.Context) error
 	UpdateSpotPricing(context.Context) error
 }
 
@@ -62,8 +64,9 @@ type DefaultProvider struct {
 	isolatedVPC bool
 	cm          *pretty.ChangeMonitor
 
-	muOnDemand     sync.RWMutex
-	onDemandPrices map[ec2types.InstanceType]float64
+	muOnDemand              sync.RWMutex
+	onDemandPrices          map[ec2types.InstanceType]float64
+	dedicatedOnDemandPrices map[ec2types.InstanceType]float64
 
 	muSpot             sync.RWMutex
 	spotPrices         map[ec2types.InstanceType]zonal
@@ -152,6 +155,22 @@ func (p *DefaultProvider) OnDemandPrice(instanceType ec2types.InstanceType) (flo
 	return price, true
 }
 
+// DedicatedOnDemandPrice returns the last known on-demand price for a given instance type with dedicated tenancy. If
+// there is no known dedicated price, e.g. when only the static pricing data is available, the shared tenancy price is
+// returned instead, which still provides a relative ordering between instance types.
+func (p *DefaultProvider) DedicatedOnDemandPrice(instanceType ec2types.InstanceType) (float64, bool) {
+	p.muOnDemand.RLock()
+	defer p.muOnDemand.RUnlock()
+	if price, ok := p.dedicatedOnDemandPrices[instanceType]; ok {
+		return price, true
+	}
+	price, ok := p.onDemandPrices[instanceType]
+	if !ok {
+		return 0.0, false
+	}
+	return price, true
+}
+
 // SpotPrice returns the last known spot price for a given instance type and zone, returning an error
 // if there is no known spot pricing for that instance type or zone
 func (p *DefaultProvider) SpotPrice(instanceType ec2types.InstanceType, zone string) (float64, bool) {
@@ -236,6 +255,43 @@ func (p *DefaultProvider) UpdateOnDemandPricing(ctx context.Context) error {
 	if p.cm.HasChanged("on-demand-prices", p.onDemandPrices) {
 		log.FromContext(ctx).WithValues("instance-type-count", len(p.onDemandPrices)).V(1).Info("updated on-demand pricing")
 	}
+	// Bare metal instances are always dedicated, so their prices apply to dedicated tenancy as well
+	p.dedicatedOnDemandPrices = lo.Assign(p.dedicatedOnDemandPrices, onDemandMetalPrices)
+	return nil
+}
+
+// UpdateDedicatedOnDemandPricing updates the on-demand prices of instances with dedicated tenancy. These are only needed
+// when an EC2NodeClass launches dedicated instances, so they're updated separately from the shared tenancy prices. In
+// the event that the update fails, the previously retrieved prices are retained.
+func (p *DefaultProvider) UpdateDedicatedOnDemandPricing(ctx context.Context) error {
+	if p.isolatedVPC {
+		return nil
+	}
+	onDemandDedicatedPrices, err := p.fetchOnDemandPricing(ctx,
+		pricingtypes.Filter{
+			Field: aws.String("tenancy"),
+			Type:  "TERM_MATCH",
+			Value: aws.String("Dedicated"),
+		},
+		pricingtypes.Filter{
+			Field: aws.String("productFamily"),
+			Type:  "TERM_MATCH",
+			Value: aws.String("Compute Instance"),
+		})
+	if err != nil {
+		return fmt.Errorf("retreiving dedicated on-demand pricing data, %w", err)
+	}
+	if len(onDemandDedicatedPrices) == 0 {
+		return fmt.Errorf("no dedicated on-demand pricing found")
+	}
+
+	p.muOnDemand.Lock()
+	defer p.muOnDemand.Unlock()
+	// Maintain previously retrieved pricing data
+	p.dedicatedOnDemandPrices = lo.Assign(p.dedicatedOnDemandPrices, onDemandDedicatedPrices)
+	if p.cm.HasChanged("dedicated-on-demand-prices", p.dedicatedOnDemandPrices) {
+		log.FromContext(ctx).WithValues("instance-type-count", len(p.dedicatedOnDemandPrices)).V(1).Info("updated dedicated on-demand pricing")
+	}
 	return nil
 }
 
@@ -438,6 +494,7 @@ func (p *DefaultProvider) Reset() {
 	}
 
 	p.onDemandPrices = staticPricing
+	p.dedicatedOnDemandPrices = map[ec2types.InstanceType]float64{}
 	// default our spot pricing to the same as the on-demand pricing until a price update
 	p.spotPrices = populateInitialSpotPricing(staticPricing)
 	p.spotPricingUpdated = false
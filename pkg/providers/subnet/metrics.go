@@ -0,0 +1,58 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnet
+
+import (
+	opmetrics "github.com/awslabs/operatorpkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"sigs.k8s.io/karpenter/pkg/metrics"
+)
+
+const (
+	cloudProviderSubsystem = "cloudprovider"
+	subnetIDLabel          = "subnet_id"
+	zoneLabel              = "zone"
+)
+
+var (
+	SubnetAvailableIPAddresses = opmetrics.NewPrometheusGauge(
+		crmetrics.Registry,
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: cloudProviderSubsystem,
+			Name:      "subnet_available_ip_addresses",
+			Help:      "Number of available IP addresses in a subnet, as last reported by EC2.",
+		},
+		[]string{
+			subnetIDLabel,
+			zoneLabel,
+		},
+	)
+	SubnetInflightIPAddresses = opmetrics.NewPrometheusGauge(
+		crmetrics.Registry,
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: cloudProviderSubsystem,
+			Name:      "subnet_inflight_ip_addresses",
+			Help:      "Number of IP addresses in a subnet predicted to be consumed by launches that aren't yet reflected in the available IP addresses reported by EC2.",
+		},
+		[]string{
+			subnetIDLabel,
+			zoneLabel,
+		},
+	)
+)
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -32,6 +33,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	v1 "github.com/aws/karpenter-provider-aws/pkg/apis/v1"
+	awserrors "github.com/aws/karpenter-provider-aws/pkg/errors"
+	"github.com/aws/karpenter-provider-aws/pkg/operator/options"
 
 	karpv1 "sigs.k8s.io/karpenter/pkg/apis/v1"
 	"sigs.k8s.io/karpenter/pkg/cloudprovider"
@@ -41,11 +44,16 @@ import (
 	sdk "github.com/aws/karpenter-provider-aws/pkg/aws"
 )
 
+// ipv4PrefixSize is the number of IP addresses in the /28 prefixes assigned by the VPC CNI with prefix delegation
+const ipv4PrefixSize = 16
+
 type Provider interface {
 	LivenessProbe(*http.Request) error
 	List(context.Context, *v1.EC2NodeClass) ([]ec2types.Subnet, error)
-	ZonalSubnetsForLaunch(context.Context, *v1.EC2NodeClass, []*cloudprovider.InstanceType, string) (map[string]*Subnet, error)
-	UpdateInflightIPs(*ec2.CreateFleetInput, *ec2.CreateFleetOutput, []*cloudprovider.InstanceType, []*Subnet, string)
+	ZonalSubnetsForLaunch(context.Context, *v1.EC2NodeClass, []*cloudprovider.InstanceType, string) (map[string][]*Subnet, error)
+	UpdateInflightIPs(context.Context, *ec2.CreateFleetInput, *ec2.CreateFleetOutput, []*cloudprovider.InstanceType, []*Subnet, string)
+	AvailableIPs(string) (int32, bool)
+	DeleteMetrics(...string)
 }
 
 type DefaultProvider struct {
@@ -56,6 +64,7 @@ type DefaultProvider struct {
 	associatePublicIPAddressCache *cache.Cache
 	cm                            *pretty.ChangeMonitor
 	inflightIPs                   map[string]int32
+	zones                         map[string]string
 }
 
 type Subnet struct {
@@ -76,6 +85,8 @@ func NewDefaultProvider(ec2api sdk.EC2API, cache *cache.Cache, availableIPAddres
 		associatePublicIPAddressCache: associatePublicIPAddressCache,
 		// inflightIPs is used to track IPs from known launched instances
 		inflightIPs: map[string]int32{},
+		// zones is used to label the subnet metrics with the zone of each described subnet
+		zones: map[string]string{},
 	}
 }
 
@@ -114,6 +125,12 @@ func (p *DefaultProvider) List(ctx context.Context, nodeClass *v1.EC2NodeClass)
 				// subnets can be leaked here, if a subnets is never called received from ec2
 				// we are accepting it for now, as this will be an insignificant amount of memory
 				delete(p.inflightIPs, lo.FromPtr(output.Subnets[i].SubnetId)) // remove any previously tracked IP addresses since we just refreshed from EC2
+				p.zones[lo.FromPtr(output.Subnets[i].SubnetId)] = lo.FromPtr(output.Subnets[i].AvailabilityZone)
+				SubnetAvailableIPAddresses.Set(float64(lo.FromPtr(output.Subnets[i].AvailableIpAddressCount)), map[string]string{
+					subnetIDLabel: lo.FromPtr(output.Subnets[i].SubnetId),
+					zoneLabel:     lo.FromPtr(output.Subnets[i].AvailabilityZone),
+				})
+				p.updateInflightIPsMetric(lo.FromPtr(output.Subnets[i].SubnetId))
 			}
 		}
 	}
@@ -131,8 +148,10 @@ func (p *DefaultProvider) List(ctx context.Context, nodeClass *v1.EC2NodeClass)
 	return lo.Values(subnets), nil
 }
 
-// ZonalSubnetsForLaunch returns a mapping of zone to the subnet with the most available IP addresses and deducts the passed ips from the available count
-func (p *DefaultProvider) ZonalSubnetsForLaunch(ctx context.Context, nodeClass *v1.EC2NodeClass, instanceTypes []*cloudprovider.InstanceType, capacityType string) (map[string]*Subnet, error) {
+// ZonalSubnetsForLaunch returns a mapping of zone to the subnets with the most available IP addresses, ordered by available
+// IP addresses descending, and deducts the passed ips from the available count of each returned subnet. The number of
+// subnets returned for each zone is bounded by the subnets-per-zone setting.
+func (p *DefaultProvider) ZonalSubnetsForLaunch(ctx context.Context, nodeClass *v1.EC2NodeClass, instanceTypes []*cloudprovider.InstanceType, capacityType string) (map[string][]*Subnet, error) {
 	if len(nodeClass.Status.Subnets) == 0 {
 		return nil, fmt.Errorf("no subnets matched selector %v", nodeClass.Spec.SubnetSelectorTerms)
 	}
@@ -140,48 +159,49 @@ func (p *DefaultProvider) ZonalSubnetsForLaunch(ctx context.Context, nodeClass *
 	p.Lock()
 	defer p.Unlock()
 
-	zonalSubnets := map[string]*Subnet{}
+	zonalSubnets := map[string][]*Subnet{}
 	availableIPAddressCount := map[string]int32{}
 	for _, subnet := range nodeClass.Status.Subnets {
 		if subnetAvailableIP, ok := p.availableIPAddressCache.Get(subnet.ID); ok {
 			availableIPAddressCount[subnet.ID] = subnetAvailableIP.(int32)
 		}
 	}
-
-	for _, subnet := range nodeClass.Status.Subnets {
-		if v, ok := zonalSubnets[subnet.Zone]; ok {
-			currentZonalSubnetIPAddressCount := v.AvailableIPAddressCount
-			newZonalSubnetIPAddressCount := availableIPAddressCount[subnet.ID]
-			if ips, ok := p.inflightIPs[v.ID]; ok {
-				currentZonalSubnetIPAddressCount = ips
-			}
-			if ips, ok := p.inflightIPs[subnet.ID]; ok {
-				newZonalSubnetIPAddressCount = ips
-			}
-
-			if currentZonalSubnetIPAddressCount >= newZonalSubnetIPAddressCount {
-				continue
-			}
+	// trackedIPs returns the number of IPs we expect to be available in the subnet, preferring the in-flight count
+	trackedIPs := func(s *Subnet) int32 {
+		if ips, ok := p.inflightIPs[s.ID]; ok {
+			return ips
 		}
-		zonalSubnets[subnet.Zone] = &Subnet{ID: subnet.ID, Zone: subnet.Zone, ZoneID: subnet.ZoneID, AvailableIPAddressCount: availableIPAddressCount[subnet.ID]}
+		return s.AvailableIPAddressCount
+	}
+	for _, subnet := range nodeClass.Status.Subnets {
+		zonalSubnets[subnet.Zone] = append(zonalSubnets[subnet.Zone], &Subnet{ID: subnet.ID, Zone: subnet.Zone, ZoneID: subnet.ZoneID, AvailableIPAddressCount: availableIPAddressCount[subnet.ID]})
+	}
+	subnetsPerZone := lo.Max([]int{options.FromContext(ctx).SubnetsPerZone, 1})
+	for zone, subnets := range zonalSubnets {
+		// Stable sort so that subnets with the same number of available IPs retain the ordering from the status
+		sort.SliceStable(subnets, func(i, j int) bool {
+			return trackedIPs(subnets[i]) > trackedIPs(subnets[j])
+		})
+		zonalSubnets[zone] = lo.Slice(subnets, 0, subnetsPerZone)
 	}
 
-	for _, subnet := range zonalSubnets {
-		predictedIPsUsed := p.minPods(instanceTypes, scheduling.NewRequirements(
-			scheduling.NewRequirement(karpv1.CapacityTypeLabelKey, corev1.NodeSelectorOpIn, capacityType),
-			scheduling.NewRequirement(corev1.LabelTopologyZone, corev1.NodeSelectorOpIn, subnet.Zone),
-		))
-		prevIPs := subnet.AvailableIPAddressCount
-		if trackedIPs, ok := p.inflightIPs[subnet.ID]; ok {
-			prevIPs = trackedIPs
+	for _, subnets := range zonalSubnets {
+		for _, subnet := range subnets {
+			predictedIPsUsed := p.predictedIPs(ctx, instanceTypes, scheduling.NewRequirements(
+				scheduling.NewRequirement(karpv1.CapacityTypeLabelKey, corev1.NodeSelectorOpIn, capacityType),
+				scheduling.NewRequirement(corev1.LabelTopologyZone, corev1.NodeSelectorOpIn, subnet.Zone),
+			))
+			p.inflightIPs[subnet.ID] = trackedIPs(subnet) - predictedIPsUsed
+			p.updateInflightIPsMetric(subnet.ID)
 		}
-		p.inflightIPs[subnet.ID] = prevIPs - predictedIPsUsed
 	}
 	return zonalSubnets, nil
 }
 
-// UpdateInflightIPs is used to refresh the in-memory IP usage by adding back unused IPs after a CreateFleet response is returned
-func (p *DefaultProvider) UpdateInflightIPs(createFleetInput *ec2.CreateFleetInput, createFleetOutput *ec2.CreateFleetOutput, instanceTypes []*cloudprovider.InstanceType,
+// UpdateInflightIPs is used to refresh the in-memory IP usage by adding back unused IPs after a CreateFleet response is returned.
+// Since IPs are deducted from every subnet returned by ZonalSubnetsForLaunch, IPs are added back to each of those subnets that
+// Fleet didn't launch into. Subnets that Fleet reported as out of free addresses are marked as exhausted until the next refresh.
+func (p *DefaultProvider) UpdateInflightIPs(ctx context.Context, createFleetInput *ec2.CreateFleetInput, createFleetOutput *ec2.CreateFleetOutput, instanceTypes []*cloudprovider.InstanceType,
 	subnets []*Subnet, capacityType string) {
 	p.Lock()
 	defer p.Unlock()
@@ -193,8 +213,8 @@ func (p *DefaultProvider) UpdateInflightIPs(createFleetInput *ec2.CreateFleetInp
 		})
 	})))
 
-	// Process the CreateFleetOutput to pull out all the fulfilled subnetIDs
-	var fleetOutputSubnets []string
+	// Process the CreateFleetOutput to pull out all the fulfilled subnetIDs and the subnets which ran out of addresses
+	var fleetOutputSubnets, exhaustedSubnets []string
 	if createFleetOutput != nil {
 		fleetOutputSubnets = lo.Compact(lo.Uniq(lo.Map(createFleetOutput.Instances, func(fleetInstance ec2types.CreateFleetInstance, _ int) string {
 			if fleetInstance.LaunchTemplateAndOverrides == nil || fleetInstance.LaunchTemplateAndOverrides.Overrides == nil {
@@ -202,10 +222,17 @@ func (p *DefaultProvider) UpdateInflightIPs(createFleetInput *ec2.CreateFleetInp
 			}
 			return lo.FromPtr(fleetInstance.LaunchTemplateAndOverrides.Overrides.SubnetId)
 		})))
+		exhaustedSubnets = lo.Compact(lo.Uniq(lo.FilterMap(createFleetOutput.Errors, func(fleetErr ec2types.CreateFleetError, _ int) (string, bool) {
+			if !awserrors.IsInsufficientFreeAddresses(fleetErr) || fleetErr.LaunchTemplateAndOverrides == nil || fleetErr.LaunchTemplateAndOverrides.Overrides == nil {
+				return "", false
+			}
+			return lo.FromPtr(fleetErr.LaunchTemplateAndOverrides.Overrides.SubnetId), true
+		})))
 	}
 
-	// Find the subnets that were included in the input but not chosen by Fleet, so we need to add the inflight IPs back to them
-	subnetIDsToAddBackIPs, _ := lo.Difference(fleetInputSubnets, fleetOutputSubnets)
+	// Find the subnets that IPs were deducted from but were not chosen by Fleet, so we need to add the inflight IPs back to them.
+	// This includes subnets that were never passed to Fleet since the override limit was reached.
+	subnetIDsToAddBackIPs, _ := lo.Difference(lo.Uniq(append(fleetInputSubnets, lo.Map(subnets, func(s *Subnet, _ int) string { return s.ID })...)), fleetOutputSubnets)
 
 	// Aggregate all the cached subnets ip address count
 	cachedAvailableIPAddressMap := lo.MapEntries(p.availableIPAddressCache.Items(), func(k string, v cache.Item) (string, int32) {
@@ -229,14 +256,60 @@ func (p *DefaultProvider) UpdateInflightIPs(createFleetInput *ec2.CreateFleetInp
 		if originalSubnet.AvailableIPAddressCount == cachedIPAddressCount {
 			// other IPs deducted were opportunistic and need to be readded since Fleet didn't pick those subnets to launch into
 			if ips, ok := p.inflightIPs[originalSubnet.ID]; ok {
-				minPods := p.minPods(instanceTypes, scheduling.NewRequirements(
+				predictedIPsUsed := p.predictedIPs(ctx, instanceTypes, scheduling.NewRequirements(
 					scheduling.NewRequirement(karpv1.CapacityTypeLabelKey, corev1.NodeSelectorOpIn, capacityType),
 					scheduling.NewRequirement(corev1.LabelTopologyZone, corev1.NodeSelectorOpIn, originalSubnet.Zone),
 				))
-				p.inflightIPs[originalSubnet.ID] = ips + minPods
+				p.inflightIPs[originalSubnet.ID] = ips + predictedIPsUsed
+				p.updateInflightIPsMetric(originalSubnet.ID)
 			}
 		}
 	}
+
+	// Fleet told us that these subnets have no free addresses, so we deprioritize them until we refresh the
+	// available IP address count from EC2
+	for _, subnetID := range exhaustedSubnets {
+		p.inflightIPs[subnetID] = 0
+		p.updateInflightIPsMetric(subnetID)
+	}
+}
+
+// AvailableIPs returns the number of IP addresses that are expected to be available in the subnet, accounting for
+// IP addresses consumed by in-flight launches since the subnet was last described
+func (p *DefaultProvider) AvailableIPs(subnetID string) (int32, bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	if ips, ok := p.inflightIPs[subnetID]; ok {
+		return lo.Max([]int32{ips, 0}), true
+	}
+	if ips, ok := p.availableIPAddressCache.Get(subnetID); ok {
+		return ips.(int32), true
+	}
+	return 0, false
+}
+
+// updateInflightIPsMetric records the difference between the available IPs reported by EC2 and the IPs we're tracking
+// for the subnet. This must be called while holding the provider's lock.
+func (p *DefaultProvider) updateInflightIPsMetric(subnetID string) {
+	inflight := int32(0)
+	if available, ok := p.availableIPAddressCache.Get(subnetID); ok {
+		if tracked, ok := p.inflightIPs[subnetID]; ok {
+			inflight = lo.Max([]int32{available.(int32) - tracked, 0})
+		}
+	}
+	SubnetInflightIPAddresses.Set(float64(inflight), map[string]string{subnetIDLabel: subnetID, zoneLabel: p.zones[subnetID]})
+}
+
+// DeleteMetrics deletes the metrics of subnets that are no longer selected by any EC2NodeClass
+func (p *DefaultProvider) DeleteMetrics(subnetIDs ...string) {
+	p.Lock()
+	defer p.Unlock()
+
+	for _, subnetID := range subnetIDs {
+		SubnetAvailableIPAddresses.DeletePartialMatch(map[string]string{subnetIDLabel: subnetID})
+		SubnetInflightIPAddresses.DeletePartialMatch(map[string]string{subnetIDLabel: subnetID})
+	}
 }
 
 func (p *DefaultProvider) LivenessProbe(_ *http.Request) error {
@@ -246,6 +319,25 @@ func (p *DefaultProvider) LivenessProbe(_ *http.Request) error {
 	return nil
 }
 
+// predictedIPs returns the number of IP addresses that we expect a launch to consume from the node's subnet, based on
+// the minimum number of pods supported by the instance types and how the VPC CNI assigns IP addresses to pods
+func (p *DefaultProvider) predictedIPs(ctx context.Context, instanceTypes []*cloudprovider.InstanceType, reqs scheduling.Requirements) int32 {
+	pods := p.minPods(instanceTypes, reqs)
+	if pods == 0 {
+		return 0
+	}
+	switch {
+	case options.FromContext(ctx).CustomNetworkingEnabled():
+		// Pods receive IP addresses from the ENIConfig subnet, so the node's subnet only provides the node's primary IP address
+		return 1
+	case options.FromContext(ctx).PrefixDelegationEnabled():
+		// Addresses are assigned as /28 prefixes, so round up to the nearest prefix
+		return ((pods + ipv4PrefixSize - 1) / ipv4PrefixSize) * ipv4PrefixSize
+	default:
+		return pods
+	}
+}
+
 func (p *DefaultProvider) minPods(instanceTypes []*cloudprovider.InstanceType, reqs scheduling.Requirements) int32 {
 	// filter for instance types available in the zone and capacity type being requested
 	filteredInstanceTypes := lo.Filter(instanceTypes, func(it *cloudprovider.InstanceType, _ int) bool {
@@ -36,6 +36,7 @@ import (
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instanceprofile"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/instancetype"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/launchtemplate"
+	"github.com/aws/karpenter-provider-aws/pkg/providers/placementgroup"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/pricing"
 	"github.com/aws/karpenter-provider-aws/pkg/providers/securitygroup"
 	ssmp "github.com/aws/karpenter-provider-aws/pkg/providers/ssm"
@@ -75,6 +76,7 @@ type Environment struct {
 	AvailableIPAdressCache               *cache.Cache
 	AssociatePublicIPAddressCache        *cache.Cache
 	SecurityGroupCache                   *cache.Cache
+	PlacementGroupCache                  *cache.Cache
 	InstanceProfileCache                 *cache.Cache
 	SSMCache                             *cache.Cache
 	DiscoveredCapacityCache              *cache.Cache
@@ -89,10 +91,12 @@ type Environment struct {
 	InstanceProvider            *instance.DefaultProvider
 	SubnetProvider              *subnet.DefaultProvider
 	SecurityGroupProvider       *securitygroup.DefaultProvider
+	PlacementGroupProvider      *placementgroup.DefaultProvider
 	InstanceProfileProvider     *instanceprofile.DefaultProvider
 	PricingProvider             *pricing.DefaultProvider
 	AMIProvider                 *amifamily.DefaultProvider
 	AMIResolver                 *amifamily.DefaultResolver
+	AMIHealthTracker            *amifamily.HealthTracker
 	VersionProvider             *version.DefaultProvider
 	LaunchTemplateProvider      *launchtemplate.DefaultProvider
 }
@@ -118,6 +122,7 @@ func NewEnvironment(ctx context.Context, env *coretest.Environment) *Environment
 	availableIPAdressCache := cache.New(awscache.AvailableIPAddressTTL, awscache.DefaultCleanupInterval)
 	associatePublicIPAddressCache := cache.New(awscache.AssociatePublicIPAddressTTL, awscache.DefaultCleanupInterval)
 	securityGroupCache := cache.New(awscache.DefaultTTL, awscache.DefaultCleanupInterval)
+	placementGroupCache := cache.New(awscache.DefaultTTL, awscache.DefaultCleanupInterval)
 	instanceProfileCache := cache.New(awscache.DefaultTTL, awscache.DefaultCleanupInterval)
 	ssmCache := cache.New(awscache.DefaultTTL, awscache.DefaultCleanupInterval)
 	capacityReservationCache := cache.New(awscache.DefaultTTL, awscache.DefaultCleanupInterval)
@@ -130,6 +135,7 @@ func NewEnvironment(ctx context.Context, env *coretest.Environment) *Environment
 	pricingProvider := pricing.NewDefaultProvider(fakePricingAPI, ec2api, fake.DefaultRegion, false)
 	subnetProvider := subnet.NewDefaultProvider(ec2api, subnetCache, availableIPAdressCache, associatePublicIPAddressCache)
 	securityGroupProvider := securitygroup.NewDefaultProvider(ec2api, securityGroupCache)
+	placementGroupProvider := placementgroup.NewDefaultProvider(ec2api, placementGroupCache)
 	versionProvider := version.NewDefaultProvider(env.KubernetesInterface, eksapi)
 	// Ensure we're able to hydrate the version before starting any reliant controllers.
 	// Version updates are hydrated asynchronously after this, in the event of a failure
@@ -185,6 +191,7 @@ func NewEnvironment(ctx context.Context, env *coretest.Environment) *Environment
 		AvailableIPAdressCache:               availableIPAdressCache,
 		AssociatePublicIPAddressCache:        associatePublicIPAddressCache,
 		SecurityGroupCache:                   securityGroupCache,
+		PlacementGroupCache:                  placementGroupCache,
 		InstanceProfileCache:                 instanceProfileCache,
 		UnavailableOfferingsCache:            unavailableOfferingsCache,
 		SSMCache:                             ssmCache,
@@ -199,11 +206,13 @@ func NewEnvironment(ctx context.Context, env *coretest.Environment) *Environment
 		InstanceProvider:            instanceProvider,
 		SubnetProvider:              subnetProvider,
 		SecurityGroupProvider:       securityGroupProvider,
+		PlacementGroupProvider:      placementGroupProvider,
 		LaunchTemplateProvider:      launchTemplateProvider,
 		InstanceProfileProvider:     instanceProfileProvider,
 		PricingProvider:             pricingProvider,
 		AMIProvider:                 amiProvider,
 		AMIResolver:                 amiResolver,
+		AMIHealthTracker:            amifamily.NewHealthTracker(),
 		VersionProvider:             versionProvider,
 	}
 }
@@ -217,6 +226,7 @@ func (env *Environment) Reset() {
 	env.PricingAPI.Reset()
 	env.PricingProvider.Reset()
 	env.InstanceTypesProvider.Reset()
+	env.AMIHealthTracker.Reset()
 
 	env.EC2Cache.Flush()
 	env.UnavailableOfferingsCache.Flush()
@@ -226,6 +236,7 @@ func (env *Environment) Reset() {
 	env.AssociatePublicIPAddressCache.Flush()
 	env.AvailableIPAdressCache.Flush()
 	env.SecurityGroupCache.Flush()
+	env.PlacementGroupCache.Flush()
 	env.InstanceProfileCache.Flush()
 	env.SSMCache.Flush()
 	env.DiscoveredCapacityCache.Flush()
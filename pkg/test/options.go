@@ -24,14 +24,20 @@ import (
 )
 
 type OptionsFields struct {
-	ClusterCABundle         *string
-	ClusterName             *string
-	ClusterEndpoint         *string
-	IsolatedVPC             *bool
-	EKSControlPlane         *bool
-	VMMemoryOverheadPercent *float64
-	InterruptionQueue       *string
-	ReservedENIs            *int
+	ClusterCABundle            *string
+	ClusterName                *string
+	ClusterEndpoint            *string
+	IsolatedVPC                *bool
+	EKSControlPlane            *bool
+	VMMemoryOverheadPercent    *float64
+	InterruptionQueue          *string
+	ReservedENIs               *int
+	SubnetsPerZone             *int
+	IPAccountingMode           *string
+	SubnetCapacityThreshold    *int
+	ValidateSecurityGroupRules *bool
+	EnableUserDataRendering    *bool
+	UserDataRenderingPort      *int
 }
 
 func Options(overrides ...OptionsFields) *options.Options {
@@ -42,13 +48,19 @@ func Options(overrides ...OptionsFields) *options.Options {
 		}
 	}
 	return &options.Options{
-		ClusterCABundle:         lo.FromPtrOr(opts.ClusterCABundle, ""),
-		ClusterName:             lo.FromPtrOr(opts.ClusterName, "test-cluster"),
-		ClusterEndpoint:         lo.FromPtrOr(opts.ClusterEndpoint, "https://test-cluster"),
-		IsolatedVPC:             lo.FromPtrOr(opts.IsolatedVPC, false),
-		EKSControlPlane:         lo.FromPtrOr(opts.EKSControlPlane, false),
-		VMMemoryOverheadPercent: lo.FromPtrOr(opts.VMMemoryOverheadPercent, 0.075),
-		InterruptionQueue:       lo.FromPtrOr(opts.InterruptionQueue, ""),
-		ReservedENIs:            lo.FromPtrOr(opts.ReservedENIs, 0),
+		ClusterCABundle:            lo.FromPtrOr(opts.ClusterCABundle, ""),
+		ClusterName:                lo.FromPtrOr(opts.ClusterName, "test-cluster"),
+		ClusterEndpoint:            lo.FromPtrOr(opts.ClusterEndpoint, "https://test-cluster"),
+		IsolatedVPC:                lo.FromPtrOr(opts.IsolatedVPC, false),
+		EKSControlPlane:            lo.FromPtrOr(opts.EKSControlPlane, false),
+		VMMemoryOverheadPercent:    lo.FromPtrOr(opts.VMMemoryOverheadPercent, 0.075),
+		InterruptionQueue:          lo.FromPtrOr(opts.InterruptionQueue, ""),
+		ReservedENIs:               lo.FromPtrOr(opts.ReservedENIs, 0),
+		SubnetsPerZone:             lo.FromPtrOr(opts.SubnetsPerZone, 1),
+		IPAccountingMode:           lo.FromPtrOr(opts.IPAccountingMode, options.IPAccountingModeSecondaryIP),
+		SubnetCapacityThreshold:    lo.FromPtrOr(opts.SubnetCapacityThreshold, 0),
+		ValidateSecurityGroupRules: lo.FromPtrOr(opts.ValidateSecurityGroupRules, false),
+		EnableUserDataRendering:    lo.FromPtrOr(opts.EnableUserDataRendering, false),
+		UserDataRenderingPort:      lo.FromPtrOr(opts.UserDataRenderingPort, 8082),
 	}
 }
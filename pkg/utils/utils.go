@@ -99,7 +99,8 @@ func WithDefaultFloat64(key string, def float64) float64 {
 
 func GetTags(nodeClass *v1.EC2NodeClass, nodeClaim *karpv1.NodeClaim, clusterName string) (map[string]string, error) {
 	var invalidTags []string
-	for key := range nodeClass.Spec.Tags {
+	// Volume tags are validated alongside tags since they're merged with the static tags when volumes are launched
+	for key := range lo.Assign(nodeClass.Spec.Tags, nodeClass.Spec.VolumeTags) {
 		for _, exp := range v1.RestrictedTagPatterns {
 			if exp.MatchString(key) {
 				invalidTags = append(invalidTags, key)
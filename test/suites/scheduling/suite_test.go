@@ -120,6 +120,9 @@ var _ = Describe("Scheduling", Ordered, ContinueOnFailure, func() {
 				v1.LabelInstanceMemory:                    "4096",
 				v1.LabelInstanceEBSBandwidth:              "4750",
 				v1.LabelInstanceNetworkBandwidth:          "750",
+				v1.LabelInstanceTenancy:                   "default",
+				v1.LabelInstanceEnclaveSupported:          "false",
+				v1.LabelInstanceHibernationSupported:      "true",
 			}
 			selectors.Insert(lo.Keys(nodeSelector)...) // Add node selector keys to selectors used in testing to ensure we test all labels
 			requirements := lo.MapToSlice(nodeSelector, func(key string, value string) corev1.NodeSelectorRequirement {
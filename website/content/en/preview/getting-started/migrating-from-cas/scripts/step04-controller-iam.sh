@@ -32,7 +32,11 @@ cat << EOF > controller-policy.json
                 "ec2:RunInstances",
                 "ec2:DescribeSubnets",
                 "ec2:DescribeSecurityGroups",
+                "ec2:DescribeSecurityGroupRules",
+                "ec2:DescribeVpcs",
+                "ec2:GetManagedPrefixListEntries",
                 "ec2:DescribeLaunchTemplates",
+                "ec2:DescribePlacementGroups",
                 "ec2:DescribeInstances",
                 "ec2:DescribeInstanceTypes",
                 "ec2:DescribeInstanceTypeOfferings",
@@ -130,7 +134,10 @@ cat << EOF > controller-policy.json
             "Sid": "AllowInstanceProfileReadActions",
             "Effect": "Allow",
             "Resource": "*",
-            "Action": "iam:GetInstanceProfile"
+            "Action": [
+                "iam:GetInstanceProfile",
+                "iam:ListInstanceProfiles"
+            ]
         }
     ],
     "Version": "2012-10-17"